@@ -0,0 +1,359 @@
+package banyan
+
+import (
+	"context"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// accessTierDataSchema returns the computed attributes shared by the access tier data sources
+func accessTierDataSchema() (s map[string]*schema.Schema) {
+	s = map[string]*schema.Schema{
+		"address": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Public address of the access tier",
+		},
+		"cluster": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cluster the access tier belongs to",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the access tier",
+		},
+		"domains": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Domains served by the access tier",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the access tier as reported by Banyan",
+		},
+		"api_key_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the API key used by the netagents of the access tier",
+		},
+		"deployment_method": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Method used to deploy the access tier",
+		},
+		"disable_snat": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether source NAT is disabled for the access tier",
+		},
+		"src_nat_cidr_range": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "CIDR range used for source NAT",
+		},
+		"created_at": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Unix timestamp of when the access tier was created",
+		},
+		"created_by": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "User or API key which created the access tier",
+		},
+		"updated_at": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Unix timestamp of when the access tier was last updated",
+		},
+		"updated_by": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "User or API key which last updated the access tier",
+		},
+		"netagents": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Netagents which have registered with the access tier",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"hostname": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Hostname of the netagent host",
+					},
+					"ips": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "IP addresses of the netagent host",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"version": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Version of the netagent",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Status of the netagent",
+					},
+					"visibility": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the netagent runs in visibility only mode",
+					},
+					"cidrs": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "CIDRs reported by the netagent",
+					},
+					"host_tags": {
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Description: "Host tags of the netagent host",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"uname": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "uname of the netagent host",
+					},
+					"site_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Site name host tag of the netagent host",
+					},
+					"cluster_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "ID of the cluster the netagent belongs to",
+					},
+					"last_activity_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Time of the last activity of the netagent",
+					},
+					"created_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Time the netagent registered",
+					},
+				},
+			},
+		},
+		"tunnel_satellite": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Tunnel configuration of the access tier for connectors",
+			Elem:        accessTierTunnelDataSchema(),
+		},
+		"tunnel_enduser": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Tunnel configuration of the access tier for end users",
+			Elem:        accessTierTunnelDataSchema(),
+		},
+	}
+	return
+}
+
+func accessTierTunnelDataSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the tunnel configuration",
+			},
+			"tunnel_peer_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the tunnel peers",
+			},
+			"udp_port_number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "UDP port of the tunnel",
+			},
+			"tunnel_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address of the tunnel",
+			},
+			"wireguard_public_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Wireguard public key of the access tier",
+			},
+			"dns_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether DNS is enabled for the tunnel",
+			},
+			"dns_search_domains": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS search domains of the tunnel",
+			},
+			"keepalive": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Keepalive interval of the tunnel in seconds",
+			},
+			"cidrs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "CIDRs routed through the tunnel",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Domains routed through the tunnel",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"shared_fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Shared FQDN of the tunnel",
+			},
+			"client_cidr_range": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CIDR range assigned to tunnel clients",
+			},
+		},
+	}
+}
+
+func dataSourceAccessTierSchema() (s map[string]*schema.Schema) {
+	s = accessTierDataSchema()
+	s["id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "ID of the access tier in Banyan. Exactly one of id or name must be set",
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "Name of the access tier. Exactly one of id or name must be set",
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	return
+}
+
+func dataSourceAccessTier() *schema.Resource {
+	return &schema.Resource{
+		Description: "Obtains information describing an access tier and the health of its netagents from banyan",
+		ReadContext: dataSourceAccessTierRead,
+		Schema:      dataSourceAccessTierSchema(),
+	}
+}
+
+func dataSourceAccessTierRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	var at accesstier.AccessTierInfo
+	var err error
+	if id := d.Get("id").(string); id != "" {
+		at, err = c.AccessTier.Get(ctx, id)
+	} else {
+		at, err = c.AccessTier.GetName(ctx, d.Get("name").(string))
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(at.ID)
+	for k, v := range flattenAccessTierData(at) {
+		err = d.Set(k, v)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return
+}
+
+// flattenAccessTierData flattens an access tier into the attributes of accessTierDataSchema plus id and name
+func flattenAccessTierData(at accesstier.AccessTierInfo) map[string]interface{} {
+	netagents := make([]interface{}, 0, len(at.Netagents))
+	for _, n := range at.Netagents {
+		netagents = append(netagents, map[string]interface{}{
+			"hostname":         n.Hostname,
+			"ips":              n.IPs,
+			"version":          n.Version,
+			"status":           n.Status,
+			"visibility":       n.Visibility,
+			"cidrs":            n.CIDRs,
+			"host_tags":        n.HostTags,
+			"uname":            n.Uname,
+			"site_name":        n.SiteName,
+			"cluster_id":       n.ClusterID,
+			"last_activity_at": n.LastActivityAt,
+			"created_at":       n.CreatedAt,
+		})
+	}
+	return map[string]interface{}{
+		"id":                 at.ID,
+		"name":               at.Name,
+		"address":            at.Address,
+		"cluster":            at.ClusterName,
+		"description":        at.Description,
+		"domains":            at.Domains,
+		"status":             at.Status,
+		"api_key_id":         at.APIKeyID,
+		"deployment_method":  at.DeploymentMethod,
+		"disable_snat":       at.DisableSnat,
+		"src_nat_cidr_range": at.SrcNATCIDRRange,
+		"created_at":         at.CreatedAt,
+		"created_by":         at.CreatedBy,
+		"updated_at":         at.UpdatedAt,
+		"updated_by":         at.UpdatedBy,
+		"netagents":          netagents,
+		"tunnel_satellite":   flattenAccessTierTunnelData(at.TunnelSatellite),
+		"tunnel_enduser":     flattenAccessTierTunnelData(at.TunnelEnduser),
+	}
+}
+
+func flattenAccessTierTunnelData(tunnel *accesstier.AccessTierTunnelInfo) []interface{} {
+	if tunnel == nil {
+		return []interface{}{}
+	}
+	t := *tunnel
+	t.Sanitize()
+	return []interface{}{
+		map[string]interface{}{
+			"id":                   t.ID,
+			"tunnel_peer_type":     t.TunnelPeerType,
+			"udp_port_number":      t.UDPPortNumber,
+			"tunnel_ip_address":    t.TunnelIPAddress,
+			"wireguard_public_key": t.WireguardPublicKey,
+			"dns_enabled":          t.DNSEnabled,
+			"dns_search_domains":   t.DNSSearchDomains,
+			"keepalive":            t.Keepalive,
+			"cidrs":                t.CIDRs,
+			"domains":              t.Domains,
+			"shared_fqdn":          t.SharedFQDN,
+			"client_cidr_range":    t.ClientCIDRRange,
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package banyan
+
+import (
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flattenAccessTierData(t *testing.T) {
+	t.Parallel()
+	at := accesstier.AccessTierInfo{
+		ID:          "b29f24db-fc3a-4eb4-880b-8fb1245e13d3",
+		Name:        "us-west1",
+		ClusterName: "cluster1",
+		Status:      "Healthy",
+		CreatedAt:   1700000000,
+		Netagents: []accesstier.NetagentHostInfo{
+			{
+				HostInfo: accesstier.HostInfo{Hostname: "netagent-1", IPs: []string{"10.0.0.1"}},
+				Version:  "2.5.0",
+				Status:   "Healthy",
+				HostTags: map[string]string{"com.banyanops.hosttag.site_name": "us-west1"},
+			},
+		},
+		TunnelEnduser: &accesstier.AccessTierTunnelInfo{
+			UDPPortNumber:       51820,
+			WireguardPublicKey:  "public",
+			WireguardPrivateKey: "private",
+			CIDRs:               []string{"10.10.0.0/16"},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, dataSourceAccessTierSchema(), map[string]interface{}{})
+	for k, v := range flattenAccessTierData(at) {
+		assert.NoError(t, d.Set(k, v), k)
+	}
+	assert.Equal(t, "Healthy", d.Get("netagents.0.status"))
+	assert.Equal(t, "2.5.0", d.Get("netagents.0.version"))
+	assert.Equal(t, 51820, d.Get("tunnel_enduser.0.udp_port_number"))
+	assert.Equal(t, "public", d.Get("tunnel_enduser.0.wireguard_public_key"))
+	assert.Equal(t, 0, d.Get("tunnel_satellite.#"))
+	assert.Equal(t, "private", at.TunnelEnduser.WireguardPrivateKey, "flattening must not modify the access tier")
+}
@@ -0,0 +1,108 @@
+package banyan
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAccessTiersSchema() (s map[string]*schema.Schema) {
+	accessTier := accessTierDataSchema()
+	accessTier["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the access tier in Banyan",
+	}
+	accessTier["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the access tier",
+	}
+	s = map[string]*schema.Schema{
+		"cluster": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only return access tiers in this cluster",
+		},
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Only return access tiers whose name matches this regular expression",
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "IDs of the matching access tiers, sorted",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"access_tiers": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching access tiers, sorted by ID",
+			Elem: &schema.Resource{
+				Schema: accessTier,
+			},
+		},
+	}
+	return
+}
+
+func dataSourceAccessTiers() *schema.Resource {
+	return &schema.Resource{
+		Description: "Obtains the access tiers in banyan, and the health of their netagents, which match the given filters",
+		ReadContext: dataSourceAccessTiersRead,
+		Schema:      dataSourceAccessTiersSchema(),
+	}
+}
+
+func dataSourceAccessTiersRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	var nameRegex *regexp.Regexp
+	if expr := d.Get("name_regex").(string); expr != "" {
+		var err error
+		nameRegex, err = regexp.Compile(expr)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	cluster := d.Get("cluster").(string)
+	all, err := c.AccessTier.GetAll(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+	ids := []string{}
+	accessTiers := []interface{}{}
+	for _, at := range all {
+		if cluster != "" && at.ClusterName != cluster {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(at.Name) {
+			continue
+		}
+		ids = append(ids, at.ID)
+		accessTiers = append(accessTiers, flattenAccessTierData(at))
+	}
+	err = d.Set("ids", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("access_tiers", accessTiers)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(ids, ",")))))
+	return
+}
@@ -53,7 +53,7 @@ func dataSourceOidcSettings() *schema.Resource {
 
 func dataSourceOidcSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	myClient := m.(*client.Holder)
-	oidcSettings, err := myClient.Admin.OidcSettings.Get()
+	oidcSettings, err := myClient.Admin.OidcSettings.Get(ctx)
 	if err != nil {
 		diagnostics = diag.FromErr(err)
 		return
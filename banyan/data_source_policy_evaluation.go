@@ -0,0 +1,323 @@
+package banyan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy/eval"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourcePolicyEvaluationSchema() (s map[string]*schema.Schema) {
+	s = map[string]*schema.Schema{
+		"policy_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "ID of the policy in Banyan to evaluate. Exactly one of policy_id or policy_json must be set",
+			ExactlyOneOf: []string{"policy_id", "policy_json"},
+		},
+		"policy_json": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "JSON specification of the policy to evaluate, as stored in Banyan. Exactly one of policy_id or policy_json must be set",
+			ExactlyOneOf: []string{"policy_id", "policy_json"},
+			ValidateFunc: validation.StringIsJSON,
+		},
+		"roles_json": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "JSON specifications of the roles used by the policy, keyed by role name, e.g. {\"group\": [\"Engineering\"]}. Roles which are not set are looked up in Banyan by name",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsJSON,
+			},
+		},
+		"principal": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "The end user and device requesting access",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"email": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Email address of the user",
+					},
+					"groups": {
+						Type:        schema.TypeSet,
+						Optional:    true,
+						Description: "Groups of the user in the identity provider",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"device_ownership": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Ownership of the device, e.g. \"Corporate Dedicated\", \"Corporate Shared\", \"Employee Owned\" or \"Other\"",
+					},
+					"platform": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Platform of the device, e.g. \"Windows\", \"macOS\", \"Linux\", \"iOS\" or \"Android\"",
+					},
+					"serial_number": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Serial number of the device",
+					},
+					"known_device": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "Whether the device is registered with Banyan",
+					},
+					"mdm_present": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "Whether the device is managed by an MDM",
+					},
+					"trust_level": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "The trust level of the device, must be one of: \"High\", \"Medium\", \"Low\", or \"\"",
+						ValidateFunc: validateTrustLevel(),
+					},
+				},
+			},
+		},
+		"request": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "What the principal is trying to access. Set path and action for a web request, or ip, port, protocol and fqdn for a network request. If not set, only whether the principal can reach the service at all is evaluated",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"path": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Path of the web request, e.g. \"/admin/users\"",
+					},
+					"action": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "Action of the web request, must be one of: \"read\", \"write\", \"create\", \"update\" or \"delete\"",
+						ValidateFunc: validation.StringInSlice([]string{policy.L7_ACCESS_ACTION_READ, policy.L7_ACCESS_ACTION_WRITE, policy.L7_ACCESS_ACTION_CREATE, policy.L7_ACCESS_ACTION_UPDATE, policy.L7_ACCESS_ACTION_DELETE}, true),
+					},
+					"ip": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "Destination IP address of the network request",
+						ValidateFunc: validation.IsIPAddress,
+					},
+					"port": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Description:  "Destination port of the network request",
+						ValidateFunc: validation.IsPortNumber,
+					},
+					"protocol": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "Protocol of the network request, must be one of: \"TCP\", \"UDP\" or \"ICMP\"",
+						ValidateFunc: validation.StringInSlice([]string{"TCP", "UDP", "ICMP"}, true),
+					},
+					"fqdn": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Destination FQDN of the network request",
+					},
+					"time": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "Time of the request in RFC3339 format, checked against the start and end time of access groups. Defaults to now",
+						ValidateFunc: validation.IsRFC3339Time,
+					},
+				},
+			},
+		},
+		"allowed": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the policy allows the request",
+		},
+		"access_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the access group which decided the request, or its index in the form #<index> if it has no name. Empty if no access group applies to the principal",
+		},
+		"matched_roles": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Roles of the policy the principal has",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"rule": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The rule which decided the request, e.g. `l7_access[0] resource \"!/admin/*\"` or `l4_access allow[1]`. Empty if the access group grants access to the whole service",
+		},
+		"reason": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Explanation of the decision",
+		},
+	}
+	return
+}
+
+func dataSourcePolicyEvaluation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Evaluates offline whether a policy allows a principal, an end user and their device, to access a resource. Useful to assert on the effect of policies with terraform test",
+		ReadContext: dataSourcePolicyEvaluationRead,
+		Schema:      dataSourcePolicyEvaluationSchema(),
+	}
+}
+
+func dataSourcePolicyEvaluationRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	var pol policy.Object
+	if id := d.Get("policy_id").(string); id != "" {
+		resp, err := c.Policy.Get(ctx, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		pol = resp.UnmarshalledPolicy
+	} else {
+		err := json.Unmarshal([]byte(d.Get("policy_json").(string)), &pol)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("invalid policy_json: %w", err))
+		}
+	}
+	roles, err := policyEvaluationRoles(ctx, c, pol, d.Get("roles_json").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	principal := expandPolicyEvaluationPrincipal(d.Get("principal").([]interface{}))
+	request, err := expandPolicyEvaluationRequest(d.Get("request").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// resolve the default here rather than in eval.Evaluate, so that the time is part of the ID
+	if request.Time.IsZero() {
+		request.Time = time.Now().UTC()
+	}
+	decision, err := eval.Evaluate(pol, roles, principal, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	key, err := json.Marshal([]interface{}{pol, roles, principal, request})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(key)))
+	err = d.Set("allowed", decision.Allowed)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("access_name", decision.AccessName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("matched_roles", decision.Roles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("rule", decision.Rule)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("reason", decision.Reason)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return
+}
+
+// policyEvaluationRoles returns the specifications of the roles used by the policy, from rolesJson or else from Banyan
+func policyEvaluationRoles(ctx context.Context, c *client.Holder, pol policy.Object, rolesJson map[string]interface{}) (roles map[string]role.Spec, err error) {
+	roles = make(map[string]role.Spec)
+	for name, raw := range rolesJson {
+		var spec role.Spec
+		err = json.Unmarshal([]byte(raw.(string)), &spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid roles_json for role %s: %w", name, err)
+		}
+		roles[name] = spec
+	}
+	missing := false
+	for _, access := range pol.Access {
+		for _, name := range access.Roles {
+			if _, ok := roles[name]; !ok && name != role.Any {
+				missing = true
+			}
+		}
+	}
+	if !missing {
+		return
+	}
+	all, err := c.Role.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, r := range all {
+		if _, ok := roles[r.Name]; ok {
+			continue
+		}
+		var spec role.CreateRole
+		err = json.Unmarshal([]byte(html.UnescapeString(r.Spec)), &spec)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode spec of role %s: %w", r.Name, err)
+		}
+		roles[r.Name] = spec.Spec
+	}
+	return
+}
+
+func expandPolicyEvaluationPrincipal(m []interface{}) (p eval.Principal) {
+	if len(m) == 0 || m[0] == nil {
+		return
+	}
+	data := m[0].(map[string]interface{})
+	return eval.Principal{
+		Email:           data["email"].(string),
+		Groups:          convertSchemaSetToStringSlice(data["groups"].(*schema.Set)),
+		DeviceOwnership: data["device_ownership"].(string),
+		Platform:        data["platform"].(string),
+		SerialNumber:    data["serial_number"].(string),
+		KnownDevice:     data["known_device"].(bool),
+		MDMPresent:      data["mdm_present"].(bool),
+		TrustLevel:      data["trust_level"].(string),
+	}
+}
+
+func expandPolicyEvaluationRequest(m []interface{}) (r eval.Request, err error) {
+	if len(m) == 0 || m[0] == nil {
+		return
+	}
+	data := m[0].(map[string]interface{})
+	r = eval.Request{
+		Path:     data["path"].(string),
+		Action:   data["action"].(string),
+		IP:       data["ip"].(string),
+		Port:     data["port"].(int),
+		Protocol: data["protocol"].(string),
+		FQDN:     data["fqdn"].(string),
+	}
+	if at := data["time"].(string); at != "" {
+		r.Time, err = time.Parse(time.RFC3339, at)
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_policyEvaluation(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	_, err := c.Role.Create(ctx, role.CreateRole{
+		Kind:     "BanyanRole",
+		Metadata: role.Metadata{Name: "engineers"},
+		Spec:     role.Spec{UserGroup: []string{"Engineering"}},
+	})
+	require.NoError(t, err)
+	srv.AddPolicy(policy.GetPolicy{
+		ID:   "policy-web",
+		Name: "web",
+		Spec: `{"kind":"BanyanPolicy","metadata":{"name":"web"},"spec":{"access":[{"name":"engineering","roles":["engineers"],"rules":{"l7_access":[{"resources":["!/admin/*"],"actions":["*"]},{"resources":["*"],"actions":["*"]}],"conditions":{"trust_level":"Medium"}}}]}}`,
+	})
+	r := dataSourcePolicyEvaluation()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id": "policy-web",
+		"principal": []interface{}{map[string]interface{}{
+			"email":       "jane@example.com",
+			"groups":      []interface{}{"Engineering"},
+			"trust_level": "High",
+		}},
+		"request": []interface{}{map[string]interface{}{
+			"path":   "/admin/users",
+			"action": "read",
+		}},
+	})
+	require.Empty(t, r.ReadContext(ctx, d, c))
+	assert.Equal(t, false, d.Get("allowed"))
+	assert.Equal(t, "engineering", d.Get("access_name"))
+	assert.Equal(t, `l7_access[0] resource "!/admin/*"`, d.Get("rule"))
+	assert.Equal(t, []interface{}{"engineers"}, d.Get("matched_roles"))
+	assert.NotEmpty(t, d.Id())
+
+	// the ID includes the request time
+	raw := map[string]interface{}{
+		"policy_id": "policy-web",
+		"request":   []interface{}{map[string]interface{}{"time": "2030-01-01T00:00:00Z"}},
+	}
+	at := schema.TestResourceDataRaw(t, r.Schema, raw)
+	require.Empty(t, r.ReadContext(ctx, at, c))
+	again := schema.TestResourceDataRaw(t, r.Schema, raw)
+	require.Empty(t, r.ReadContext(ctx, again, c))
+	assert.Equal(t, at.Id(), again.Id())
+	raw["request"] = []interface{}{map[string]interface{}{"time": "2031-01-01T00:00:00Z"}}
+	later := schema.TestResourceDataRaw(t, r.Schema, raw)
+	require.Empty(t, r.ReadContext(ctx, later, c))
+	assert.NotEqual(t, at.Id(), later.Id())
+
+	// roles_json overrides the role in Banyan
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_json": `{"spec":{"access":[{"roles":["engineers"],"rules":{"l7_access":[]}}]}}`,
+		"roles_json":  map[string]interface{}{"engineers": `{"email":["*@example.com"]}`},
+		"principal": []interface{}{map[string]interface{}{
+			"email": "jane@example.com",
+		}},
+	})
+	require.Empty(t, r.ReadContext(ctx, d, c))
+	assert.Equal(t, true, d.Get("allowed"))
+	assert.Equal(t, "#0", d.Get("access_name"))
+	assert.Equal(t, "access group #0 grants access to the whole service", d.Get("reason"))
+}
@@ -46,6 +46,45 @@ func dataSourcePolicyInfraSchema() (s map[string]*schema.Schema) {
 						Description: "The trust level of the end user device, must be one of: \"High\", \"Medium\", \"Low\", or \"\"",
 						Computed:    true,
 					},
+					"start_time": {
+						Type:        schema.TypeString,
+						Description: "Time from which the access group applies, in RFC3339 format",
+						Computed:    true,
+					},
+					"end_time": {
+						Type:        schema.TypeString,
+						Description: "Time until which the access group applies, in RFC3339 format",
+						Computed:    true,
+					},
+					"l7_access": {
+						Type:        schema.TypeList,
+						Description: "Application level access rules, set when the policy has an l7_protocol",
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"resources": {
+									Type: schema.TypeSet,
+									Description: `
+										Resources are a list of application level resources.
+										Each resource can have wildcard prefix or suffix, or both.
+										A resource can be prefixed with "!", meaning DENY.
+										Any DENY rule overrides any other rule that would allow the access.`,
+									Computed: true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+								"actions": {
+									Type:        schema.TypeSet,
+									Description: "Actions are a list of application-level actions: \"CREATE\", \"READ\", \"UPDATE\", \"DELETE\", \"WRITE\", \"*\"",
+									Computed:    true,
+									Elem: &schema.Schema{
+										Type: schema.TypeString,
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -65,7 +104,7 @@ func dataSourcePolicyInfra() *schema.Resource {
 func dataSourcePolicyInfraRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 
 	client := m.(*client.Holder)
-	infraPolicy, err := client.Policy.GetName(d.Get("name").(string))
+	infraPolicy, err := client.Policy.GetName(ctx, d.Get("name").(string))
 
 	if err != nil {
 		diagnostics = diag.FromErr(err)
@@ -78,8 +117,7 @@ func dataSourcePolicyInfraRead(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("name", infraPolicy.Name)
 	if err != nil {
@@ -89,7 +127,7 @@ func dataSourcePolicyInfraRead(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("access", flattenPolicyInfraAccess(infraPolicy.UnmarshalledPolicy.Spec.Access))
+	err = d.Set("access", flattenPolicyInfraAccess(infraPolicy.UnmarshalledPolicy.Spec.Access, infraPolicy.UnmarshalledPolicy.Spec.L7Protocol))
 	if err != nil {
 		return diag.FromErr(err)
 	}
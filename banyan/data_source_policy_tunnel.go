@@ -33,6 +33,16 @@ func dataSourcePolicyTunnelSchema() (s map[string]*schema.Schema) {
 			Description: "Access describes the access rights for a set of roles",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "access group name",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "access group description description",
+					},
 					"roles": {
 						Type:        schema.TypeSet,
 						Description: "Role names to include ",
@@ -44,7 +54,17 @@ func dataSourcePolicyTunnelSchema() (s map[string]*schema.Schema) {
 					"trust_level": {
 						Type:        schema.TypeString,
 						Description: "The trust level of the end user device, must be one of: \"High\", \"Medium\", \"Low\", or \"\"",
-						Required:    true,
+						Computed:    true,
+					},
+					"start_time": {
+						Type:        schema.TypeString,
+						Description: "Time from which the access group applies, in RFC3339 format",
+						Computed:    true,
+					},
+					"end_time": {
+						Type:        schema.TypeString,
+						Description: "Time until which the access group applies, in RFC3339 format",
+						Computed:    true,
 					},
 					"l4_access": {
 						Type:        schema.TypeList,
@@ -58,6 +78,11 @@ func dataSourcePolicyTunnelSchema() (s map[string]*schema.Schema) {
 									Computed:    true,
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
+											"description": {
+												Type:        schema.TypeString,
+												Computed:    true,
+												Description: "l4 policy description",
+											},
 											"cidrs": {
 												Type:        schema.TypeSet,
 												Description: "Allowed CIDRs through the service tunnel",
@@ -99,6 +124,11 @@ func dataSourcePolicyTunnelSchema() (s map[string]*schema.Schema) {
 									Computed:    true,
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
+											"description": {
+												Type:        schema.TypeString,
+												Computed:    true,
+												Description: "l4 policy description",
+											},
 											"cidrs": {
 												Type:        schema.TypeSet,
 												Description: "Denied CIDRs through the service tunnel",
@@ -156,7 +186,7 @@ func dataSourcePolicyTunnel() *schema.Resource {
 func dataSourcePolicyTunnelRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 
 	client := m.(*client.Holder)
-	tunnelPolicy, err := client.Policy.GetName(d.Get("name").(string))
+	tunnelPolicy, err := client.Policy.GetName(ctx, d.Get("name").(string))
 
 	if err != nil {
 		diagnostics = diag.FromErr(err)
@@ -169,8 +199,7 @@ func dataSourcePolicyTunnelRead(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("name", tunnelPolicy.Name)
 	if err != nil {
@@ -0,0 +1,51 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dataSourcePolicyTunnelRead(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourcePolicyTunnel()
+	created := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "tunnel-contractors",
+		"description": "time boxed tunnel access",
+		"access": []interface{}{map[string]interface{}{
+			"name":        "contractors",
+			"description": "contractor laptops",
+			"roles":       []interface{}{"ANY"},
+			"trust_level": "Medium",
+			"start_time":  "2030-01-01T09:00:00Z",
+			"end_time":    "2030-01-31T17:00:00Z",
+			"l4_access": []interface{}{map[string]interface{}{
+				"allow": []interface{}{map[string]interface{}{
+					"description": "private network",
+					"cidrs":       []interface{}{"10.10.0.0/16"},
+					"protocols":   []interface{}{"TCP"},
+					"ports":       []interface{}{"443"},
+				}},
+			}},
+		}},
+	})
+	require.Empty(t, r.CreateContext(ctx, created, c))
+
+	ds := Provider().DataSourcesMap["banyan_policy_tunnel"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"name": "tunnel-contractors"})
+	require.Empty(t, ds.ReadContext(ctx, d, c))
+	assert.Equal(t, created.Id(), d.Id())
+	assert.Equal(t, "contractors", d.Get("access.0.name"))
+	assert.Equal(t, "Medium", d.Get("access.0.trust_level"))
+	assert.Equal(t, "2030-01-01T09:00:00Z", d.Get("access.0.start_time"))
+	assert.Equal(t, "2030-01-31T17:00:00Z", d.Get("access.0.end_time"))
+	assert.Equal(t, "private network", d.Get("access.0.l4_access.0.allow.0.description"))
+	assert.Equal(t, []interface{}{"10.10.0.0/16"}, d.Get("access.0.l4_access.0.allow.0.cidrs").(*schema.Set).List())
+}
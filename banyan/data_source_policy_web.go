@@ -46,6 +46,16 @@ func dataSourcePolicyWebSchema() (s map[string]*schema.Schema) {
 						Description: "The trust level of the end user device, must be one of: \"High\", \"Medium\", \"Low\", or \"\"",
 						Computed:    true,
 					},
+					"start_time": {
+						Type:        schema.TypeString,
+						Description: "Time from which the access group applies, in RFC3339 format",
+						Computed:    true,
+					},
+					"end_time": {
+						Type:        schema.TypeString,
+						Description: "Time until which the access group applies, in RFC3339 format",
+						Computed:    true,
+					},
 					"l7_access": {
 						Type:        schema.TypeList,
 						Description: "Indicates whether the end user device is allowed to use L7",
@@ -94,7 +104,7 @@ func dataSourcePolicyWeb() *schema.Resource {
 func dataSourcePolicyWebRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 
 	client := m.(*client.Holder)
-	webPolicy, err := client.Policy.GetName(d.Get("name").(string))
+	webPolicy, err := client.Policy.GetName(ctx, d.Get("name").(string))
 
 	if err != nil {
 		diagnostics = diag.FromErr(err)
@@ -107,8 +117,7 @@ func dataSourcePolicyWebRead(ctx context.Context, d *schema.ResourceData, m inte
 	}
 
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("name", webPolicy.Name)
 	if err != nil {
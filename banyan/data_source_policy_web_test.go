@@ -0,0 +1,44 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dataSourcePolicyWebRead(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourcePolicyWeb()
+	created := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "web-contractors",
+		"description": "time boxed web access",
+		"access": []interface{}{map[string]interface{}{
+			"roles":       []interface{}{"ANY"},
+			"trust_level": "High",
+			"start_time":  "2030-01-01T09:00:00Z",
+			"end_time":    "2030-01-31T17:00:00Z",
+			"l7_access": []interface{}{map[string]interface{}{
+				"resources": []interface{}{"/admin*"},
+				"actions":   []interface{}{"READ"},
+			}},
+		}},
+	})
+	require.Empty(t, r.CreateContext(ctx, created, c))
+
+	ds := Provider().DataSourcesMap["banyan_policy_web"]
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"name": "web-contractors"})
+	require.Empty(t, ds.ReadContext(ctx, d, c))
+	assert.Equal(t, created.Id(), d.Id())
+	assert.Equal(t, "time boxed web access", d.Get("description"))
+	assert.Equal(t, "High", d.Get("access.0.trust_level"))
+	assert.Equal(t, "2030-01-01T09:00:00Z", d.Get("access.0.start_time"))
+	assert.Equal(t, "2030-01-31T17:00:00Z", d.Get("access.0.end_time"))
+	assert.Equal(t, []interface{}{"/admin*"}, d.Get("access.0.l7_access.0.resources").(*schema.Set).List())
+}
@@ -115,10 +115,9 @@ func dataSourceRole() *schema.Resource {
 
 func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Role.GetName(d.Get("name").(string))
+	resp, err := c.Role.GetName(ctx, d.Get("name").(string))
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	if resp.ID == "" {
 		err = errors.New("Could not find role with name: " + d.Get("name").(string))
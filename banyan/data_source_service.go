@@ -0,0 +1,199 @@
+package banyan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceServiceSchema() (s map[string]*schema.Schema) {
+	s = map[string]*schema.Schema{
+		"id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "ID of the service in Banyan. Exactly one of id or name must be set",
+			ExactlyOneOf: []string{"id", "name"},
+		},
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "Name of the service. Exactly one of id or name must be set",
+			ExactlyOneOf: []string{"id", "name"},
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the service",
+		},
+		"cluster": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Cluster the service belongs to",
+		},
+		"service_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of the service",
+		},
+		"domain": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Domain name of the service",
+		},
+		"port": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Port of the service",
+		},
+		"protocol": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Protocol of the service",
+		},
+		"user_facing": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the service is user facing",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the service is enabled",
+		},
+		"service_version": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Version of the service, incremented on every update",
+		},
+		"created_by": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "User or API key which created the service",
+		},
+		"created_at": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Unix timestamp of when the service was created",
+		},
+		"last_updated_by": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "User or API key which last updated the service",
+		},
+		"last_updated_at": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Unix timestamp of when the service was last updated",
+		},
+		"spec": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The full service spec, encoded as JSON",
+		},
+	}
+	return
+}
+
+func dataSourceService() *schema.Resource {
+	return &schema.Resource{
+		Description: "Obtains information describing a service from banyan, looked up by name or ID",
+		ReadContext: dataSourceServiceRead,
+		Schema:      dataSourceServiceSchema(),
+	}
+}
+
+func dataSourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	id := d.Get("id").(string)
+	if name := d.Get("name").(string); id == "" && name != "" {
+		ids, err := serviceIDsByName(ctx, c, name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		switch len(ids) {
+		case 0:
+			return diag.Errorf("could not find service with name: %s", name)
+		case 1:
+			id = ids[0]
+		default:
+			return diag.Errorf("service name %q is ambiguous, it matches %d services (%s); look it up by id instead", name, len(ids), strings.Join(ids, ", "))
+		}
+	}
+	svc, err := c.Service.Get(ctx, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	spec, err := json.Marshal(svc.CreateServiceSpec)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("could not encode spec of service %s: %w", svc.ServiceID, err))
+	}
+	d.SetId(svc.ServiceID)
+	err = d.Set("name", svc.ServiceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("description", svc.Description)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("cluster", svc.ClusterName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("service_type", svc.ServiceType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("domain", svc.Domain)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("port", svc.Port)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("protocol", svc.Protocol)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("user_facing", stringToBool(svc.UserFacing))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("enabled", stringToBool(svc.Enabled))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("service_version", svc.ServiceVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("created_by", svc.CreatedBy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("created_at", svc.CreatedAt)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("last_updated_by", svc.LastUpdatedBy)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("last_updated_at", svc.LastUpdatedAt)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", string(spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return
+}
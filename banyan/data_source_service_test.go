@@ -0,0 +1,71 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dataSourceServiceRead(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	srv.AddService(service.GetServicesJson{
+		ServiceID:   "web.cluster1.bnn",
+		ServiceName: "web",
+		ClusterName: "cluster1",
+		ServiceType: "WEB",
+		Domain:      "web.example.com",
+		Port:        443,
+		Enabled:     "TRUE",
+		ServiceSpec: `{"kind":"BanyanService","metadata":{"name":"web","cluster":"cluster1","tags":{"service_app_type":"WEB"}}}`,
+	})
+	ctx := context.Background()
+	r := dataSourceService()
+
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+		err  string
+	}{
+		{name: "by name", raw: map[string]interface{}{"name": "web"}},
+		{name: "by id", raw: map[string]interface{}{"id": "web.cluster1.bnn"}},
+		{name: "name not found", raw: map[string]interface{}{"name": "ssh"}, err: "could not find service with name: ssh"},
+		{name: "id not found", raw: map[string]interface{}{"id": "ssh.cluster1.bnn"}, err: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, tt.raw)
+			diags := r.ReadContext(ctx, d, c)
+			if tt.err != "" {
+				require.True(t, diags.HasError())
+				assert.Contains(t, diags[0].Summary, tt.err)
+				return
+			}
+			require.Empty(t, diags)
+			assert.Equal(t, "web.cluster1.bnn", d.Id())
+			assert.Equal(t, "web", d.Get("name"))
+			assert.Equal(t, "cluster1", d.Get("cluster"))
+			assert.Equal(t, "web.example.com", d.Get("domain"))
+			assert.Equal(t, 443, d.Get("port"))
+			assert.True(t, d.Get("enabled").(bool))
+			assert.Contains(t, d.Get("spec"), `"name":"web"`)
+		})
+	}
+}
+
+func Test_dataSourceServiceExactlyOneOf(t *testing.T) {
+	t.Parallel()
+	r := dataSourceService()
+	both := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{"id": "web.cluster1.bnn", "name": "web"}))
+	require.True(t, both.HasError())
+	assert.Contains(t, both[0].Detail, "only one of `id,name` can be specified")
+	neither := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	require.True(t, neither.HasError())
+	assert.Contains(t, neither[0].Detail, "one of `id,name` must be specified")
+}
@@ -0,0 +1,301 @@
+package banyan
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceServicesSchema() (s map[string]*schema.Schema) {
+	s = map[string]*schema.Schema{
+		"cluster": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only return services in this cluster",
+		},
+		"service_type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Only return services of this type, e.g. \"WEB\" or \"TCP\"",
+		},
+		"user_facing": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only return services which are (or are not) user facing",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Only return services which are (or are not) enabled",
+		},
+		"name_regex": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Only return services whose name matches this regular expression",
+			ValidateFunc: validation.StringIsValidRegExp,
+		},
+		"tags": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Only return services which have all of these tags set to the given values. Both the service metadata tags (e.g. template, protocol) and the service spec tags are matched",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"include_policy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Look up the policy attached to each matching service for policy_id, which takes one request per service",
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "IDs of the matching services, sorted",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"services": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching services, sorted by ID",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "ID of the service in Banyan",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Name of the service",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Description of the service",
+					},
+					"cluster": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Cluster the service belongs to",
+					},
+					"service_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Type of the service",
+					},
+					"domain": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Domain name of the service",
+					},
+					"port": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Port of the service",
+					},
+					"user_facing": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the service is user facing",
+					},
+					"enabled": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the service is enabled",
+					},
+					"policy_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "ID of the policy attached to the service, empty if there is none or include_policy is not set",
+					},
+				},
+			},
+		},
+	}
+	return
+}
+
+func dataSourceServices() *schema.Resource {
+	return &schema.Resource{
+		Description: "Obtains the services in banyan which match the given filters",
+		ReadContext: dataSourceServicesRead,
+		Schema:      dataSourceServicesSchema(),
+	}
+}
+
+// serviceFilter holds the filters of the banyan_services data source. Nil pointers match anything
+type serviceFilter struct {
+	cluster     string
+	serviceType string
+	userFacing  *bool
+	enabled     *bool
+	nameRegex   *regexp.Regexp
+	tags        map[string]string
+}
+
+func (f serviceFilter) matches(svc service.RegisteredServiceInfo) (ok bool, err error) {
+	if f.cluster != "" && svc.ClusterName != f.cluster {
+		return
+	}
+	if f.serviceType != "" && !strings.EqualFold(svc.ServiceType, f.serviceType) {
+		return
+	}
+	if f.userFacing != nil && stringToBool(svc.UserFacing) != *f.userFacing {
+		return
+	}
+	if f.enabled != nil && stringToBool(svc.Enabled) != *f.enabled {
+		return
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(svc.ServiceName) {
+		return
+	}
+	if len(f.tags) == 0 {
+		return true, nil
+	}
+	tags, err := serviceTags(svc)
+	if err != nil {
+		return
+	}
+	for k, v := range f.tags {
+		if tags[k] != v {
+			return
+		}
+	}
+	return true, nil
+}
+
+// serviceTags returns the metadata tags and spec tags of a service keyed by tag name
+func serviceTags(svc service.RegisteredServiceInfo) (tags map[string]string, err error) {
+	var spec service.CreateService
+	err = json.Unmarshal([]byte(html.UnescapeString(svc.ServiceSpec)), &spec)
+	if err != nil {
+		err = fmt.Errorf("could not decode spec of service %s: %w", svc.ServiceID, err)
+		return
+	}
+	tags = make(map[string]string)
+	metadataTags, err := json.Marshal(spec.Metadata.Tags)
+	if err != nil {
+		return
+	}
+	var raw map[string]interface{}
+	err = json.Unmarshal(metadataTags, &raw)
+	if err != nil {
+		return
+	}
+	for k, v := range raw {
+		if s, ok := v.(string); ok {
+			tags[k] = s
+		} else if b, ok := v.(bool); ok {
+			tags[k] = strconv.FormatBool(b)
+		}
+	}
+	for _, t := range spec.Spec.TagSlice {
+		tags[t.Name] = t.Value
+	}
+	return
+}
+
+func stringToBool(s string) bool {
+	b, _ := strconv.ParseBool(strings.ToLower(s))
+	return b
+}
+
+func dataSourceServicesFilter(d *schema.ResourceData) (f serviceFilter, err error) {
+	f.cluster = d.Get("cluster").(string)
+	f.serviceType = d.Get("service_type").(string)
+	raw := d.GetRawConfig()
+	if v := raw.GetAttr("user_facing"); !v.IsNull() {
+		userFacing := v.True()
+		f.userFacing = &userFacing
+	}
+	if v := raw.GetAttr("enabled"); !v.IsNull() {
+		enabled := v.True()
+		f.enabled = &enabled
+	}
+	if expr := d.Get("name_regex").(string); expr != "" {
+		f.nameRegex, err = regexp.Compile(expr)
+		if err != nil {
+			return
+		}
+	}
+	f.tags = make(map[string]string)
+	for k, v := range d.Get("tags").(map[string]interface{}) {
+		f.tags[k] = v.(string)
+	}
+	return
+}
+
+func dataSourceServicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	filter, err := dataSourceServicesFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	all, err := c.Service.GetAll(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var matched []service.RegisteredServiceInfo
+	for _, svc := range all {
+		ok, err := filter.matches(svc)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if ok {
+			matched = append(matched, svc)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ServiceID < matched[j].ServiceID })
+
+	includePolicy := d.Get("include_policy").(bool)
+	ids := make([]string, 0, len(matched))
+	services := make([]interface{}, 0, len(matched))
+	for _, svc := range matched {
+		var policyID string
+		if includePolicy {
+			attachment, err := c.PolicyAttachment.Get(ctx, svc.ServiceID, "service")
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			policyID = attachment.PolicyID
+		}
+		ids = append(ids, svc.ServiceID)
+		services = append(services, map[string]interface{}{
+			"id":           svc.ServiceID,
+			"name":         svc.ServiceName,
+			"description":  svc.Description,
+			"cluster":      svc.ClusterName,
+			"service_type": svc.ServiceType,
+			"domain":       svc.Domain,
+			"port":         int(svc.Port),
+			"user_facing":  stringToBool(svc.UserFacing),
+			"enabled":      stringToBool(svc.Enabled),
+			"policy_id":    policyID,
+		})
+	}
+	err = d.Set("ids", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("services", services)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(ids, ",")))))
+	return
+}
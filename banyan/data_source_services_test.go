@@ -0,0 +1,85 @@
+package banyan
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_serviceFilter_matches(t *testing.T) {
+	t.Parallel()
+	svc := service.RegisteredServiceInfo{
+		ServiceID:   "team-a-web.cluster1.bnn",
+		ServiceName: "team-a-web",
+		ClusterName: "cluster1",
+		ServiceType: "WEB",
+		UserFacing:  "TRUE",
+		Enabled:     "FALSE",
+		ServiceSpec: `{"metadata":{"name":"team-a-web","tags":{"template":"WEB_USER","protocol":"https"}},"spec":{"tags":[{"name":"owner","value":"team-a"}]}}`,
+	}
+	yes, no := true, false
+	tests := []struct {
+		name   string
+		filter serviceFilter
+		want   bool
+	}{
+		{"empty", serviceFilter{}, true},
+		{"cluster", serviceFilter{cluster: "cluster1"}, true},
+		{"other cluster", serviceFilter{cluster: "cluster2"}, false},
+		{"service type", serviceFilter{serviceType: "web"}, true},
+		{"user facing", serviceFilter{userFacing: &yes}, true},
+		{"not enabled", serviceFilter{enabled: &no}, true},
+		{"enabled", serviceFilter{enabled: &yes}, false},
+		{"name regex", serviceFilter{nameRegex: regexp.MustCompile("^team-a-")}, true},
+		{"other name regex", serviceFilter{nameRegex: regexp.MustCompile("^team-b-")}, false},
+		{"metadata and spec tags", serviceFilter{tags: map[string]string{"template": "WEB_USER", "owner": "team-a"}}, true},
+		{"other tag value", serviceFilter{tags: map[string]string{"owner": "team-b"}}, false},
+	}
+	for _, tt := range tests {
+		got, err := tt.filter.matches(svc)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+}
+
+func Test_dataSourceServicesIncludePolicy(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	srv.AddService(service.GetServicesJson{ServiceID: "web.cluster1.bnn", ServiceName: "web", ClusterName: "cluster1"})
+	srv.AddPolicy(policy.GetPolicy{ID: "policy-a", Name: "policy-a", Spec: `{"kind":"BanyanPolicy","metadata":{"name":"policy-a"}}`})
+	r := resourcePolicyAttachment()
+	require.Empty(t, r.CreateContext(ctx, schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id":        "policy-a",
+		"attached_to_type": "service",
+		"attached_to_id":   "web.cluster1.bnn",
+	}), c))
+
+	for _, includePolicy := range []bool{false, true} {
+		// the filters read the raw configuration, which Terraform sends with the state
+		d := dataSourceServices().Data(&terraform.InstanceState{
+			Attributes: map[string]string{"include_policy": strconv.FormatBool(includePolicy)},
+			RawConfig: cty.ObjectVal(map[string]cty.Value{
+				"user_facing": cty.NullVal(cty.Bool),
+				"enabled":     cty.NullVal(cty.Bool),
+			}),
+		})
+		require.Empty(t, dataSourceServicesRead(ctx, d, c))
+		assert.Equal(t, []interface{}{"web.cluster1.bnn"}, d.Get("ids"))
+		if includePolicy {
+			assert.Equal(t, "policy-a", d.Get("services.0.policy_id"))
+		} else {
+			assert.Empty(t, d.Get("services.0.policy_id"))
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package banyan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DriftKind is the kind of difference between the terraform state and the org
+type DriftKind string
+
+const (
+	// DriftUnmanaged is an object of the org which is in no terraform state
+	DriftUnmanaged DriftKind = "unmanaged"
+	// DriftDeleted is a resource in the state whose object was deleted from the org
+	DriftDeleted DriftKind = "deleted"
+	// DriftChanged is an attribute of a resource which was changed in the org
+	DriftChanged DriftKind = "changed"
+)
+
+// StateResource is a banyan_* resource instance in a terraform state
+type StateResource struct {
+	// Address of the resource instance, e.g. module.web.banyan_service_web.app
+	Address    string
+	Type       string
+	ID         string
+	Attributes map[string]interface{}
+}
+
+// Drift is a difference between the terraform state and the org
+type Drift struct {
+	Kind DriftKind `json:"kind"`
+	// Address of the resource in the state, empty for unmanaged objects
+	Address string `json:"address,omitempty"`
+	// Type of the resource which manages, or would manage, the object
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	// Name of an unmanaged object
+	Name string `json:"name,omitempty"`
+	// Field which changed, e.g. access.0.trust_level
+	Field string      `json:"field,omitempty"`
+	State interface{} `json:"state,omitempty"`
+	Live  interface{} `json:"live,omitempty"`
+}
+
+// DetectDrift compares the resources in the state with the org. Every resource is read like terraform refreshes it,
+// and the configurable attributes which are not sensitive are compared with the state. Objects of the org which
+// no resource in the state manages are reported as unmanaged.
+func DetectDrift(ctx context.Context, c *client.Holder, managed []StateResource) (drifts []Drift, diagnostics diag.Diagnostics) {
+	resources := Provider().ResourcesMap
+	inState := make(map[string]bool)
+	for _, sr := range managed {
+		inState[sr.ID] = true
+		r, ok := resources[sr.Type]
+		if !ok {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s has unknown resource type %s, skipped", sr.Address, sr.Type),
+			})
+			continue
+		}
+		d, err := resourceDataFromState(r, sr)
+		if err != nil {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("could not decode the state of %s, skipped", sr.Address),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		readDiags := r.ReadContext(ctx, d, c)
+		if readDiags.HasError() {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("could not read %s, skipped", sr.Address),
+				Detail:   diagnosticsDetail(readDiags),
+			})
+			continue
+		}
+		if d.Id() == "" {
+			drifts = append(drifts, Drift{Kind: DriftDeleted, Address: sr.Address, Type: sr.Type, ID: sr.ID})
+			continue
+		}
+		live := make(map[string]interface{})
+		for k := range r.Schema {
+			live[k] = d.Get(k)
+		}
+		for _, change := range driftChanges("", r.Schema, sr.Attributes, live) {
+			change.Address, change.Type, change.ID = sr.Address, sr.Type, sr.ID
+			drifts = append(drifts, change)
+		}
+	}
+
+	objects, listDiags := exportObjects(ctx, c)
+	diagnostics = append(diagnostics, listDiags...)
+	if listDiags.HasError() {
+		return
+	}
+	for _, o := range objects {
+		if !inState[o.id] {
+			drifts = append(drifts, Drift{Kind: DriftUnmanaged, Type: o.resourceType, ID: o.id, Name: o.name})
+		}
+	}
+	return
+}
+
+// resourceDataFromState builds the data of a resource from its attributes in the state, as terraform does before it
+// refreshes a resource, since Read may depend on the prior state and leaves attributes it cannot read as they are.
+// Attributes which the schema no longer has are dropped.
+func resourceDataFromState(r *schema.Resource, sr StateResource) (*schema.ResourceData, error) {
+	ty := r.CoreConfigSchema().ImpliedType()
+	attributes := make(map[string]interface{})
+	for k, v := range sr.Attributes {
+		if ty.HasAttribute(k) {
+			attributes[k] = v
+		}
+	}
+	raw, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+	value, err := ctyjson.Unmarshal(raw, ty)
+	if err != nil {
+		return nil, err
+	}
+	state, err := r.ShimInstanceStateFromValue(value)
+	if err != nil {
+		return nil, err
+	}
+	state.ID = sr.ID
+	return r.Data(state), nil
+}
+
+// driftChanges compares the configurable attributes which are not sensitive. Nested blocks in lists are compared
+// attribute by attribute when both sides have as many, sets and everything else as a whole.
+func driftChanges(prefix string, s map[string]*schema.Schema, state, live map[string]interface{}) (changes []Drift) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		attr := s[k]
+		if (!attr.Required && !attr.Optional) || attr.Sensitive || attr.Deprecated != "" {
+			continue
+		}
+		stateValue := normalizeDriftValue(attr, state[k])
+		liveValue := normalizeDriftValue(attr, live[k])
+		if reflect.DeepEqual(stateValue, liveValue) {
+			continue
+		}
+		elem, isBlock := attr.Elem.(*schema.Resource)
+		stateItems, _ := stateValue.([]interface{})
+		liveItems, _ := liveValue.([]interface{})
+		if isBlock && attr.Type == schema.TypeList && len(stateItems) == len(liveItems) {
+			for i := range stateItems {
+				stateItem, _ := stateItems[i].(map[string]interface{})
+				liveItem, _ := liveItems[i].(map[string]interface{})
+				changes = append(changes, driftChanges(fmt.Sprintf("%s%s.%d.", prefix, k, i), elem.Schema, stateItem, liveItem)...)
+			}
+			continue
+		}
+		changes = append(changes, Drift{Kind: DriftChanged, Field: prefix + k, State: stateValue, Live: liveValue})
+	}
+	return
+}
+
+// normalizeDriftValue turns a value from the state, decoded from JSON, or from the provider into the same form.
+// Numbers become float64, sets become lists sorted by their JSON encoding, and zero values become nil since the
+// state and the provider do not agree on when a value is null.
+func normalizeDriftValue(attr *schema.Schema, v interface{}) interface{} {
+	switch value := v.(type) {
+	case nil:
+		return nil
+	case string:
+		if value == "" {
+			return nil
+		}
+		return value
+	case bool:
+		if !value {
+			return nil
+		}
+		return value
+	case int:
+		return normalizeDriftValue(attr, float64(value))
+	case float64:
+		if value == 0 {
+			return nil
+		}
+		return value
+	case map[string]interface{}:
+		if len(value) == 0 {
+			return nil
+		}
+		normalized := make(map[string]interface{})
+		elem, isBlock := attr.Elem.(*schema.Resource)
+		for k, item := range value {
+			itemSchema := &schema.Schema{Type: schema.TypeString}
+			if isBlock {
+				if s, ok := elem.Schema[k]; ok {
+					itemSchema = s
+				}
+			} else if s, ok := attr.Elem.(*schema.Schema); ok {
+				itemSchema = s
+			}
+			if n := normalizeDriftValue(itemSchema, item); n != nil {
+				normalized[k] = n
+			}
+		}
+		if len(normalized) == 0 {
+			return nil
+		}
+		return normalized
+	case *schema.Set:
+		return normalizeDriftValue(attr, value.List())
+	case []interface{}:
+		if len(value) == 0 {
+			return nil
+		}
+		itemSchema := attr
+		if s, ok := attr.Elem.(*schema.Schema); ok {
+			itemSchema = s
+		}
+		normalized := make([]interface{}, len(value))
+		for i, item := range value {
+			normalized[i] = normalizeDriftValue(itemSchema, item)
+		}
+		if attr.Type == schema.TypeSet {
+			sort.Slice(normalized, func(i, j int) bool {
+				return driftSortKey(normalized[i]) < driftSortKey(normalized[j])
+			})
+		}
+		return normalized
+	}
+	return v
+}
+
+func driftSortKey(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// String describes the drift in a sentence
+func (d Drift) String() string {
+	switch d.Kind {
+	case DriftUnmanaged:
+		return fmt.Sprintf("%s %q (%s) is not managed by terraform", d.Type, d.Name, d.ID)
+	case DriftDeleted:
+		return fmt.Sprintf("%s (%s) was deleted outside of terraform", d.Address, d.ID)
+	}
+	return fmt.Sprintf("%s (%s) %s changed outside of terraform from %s to %s", d.Address, d.ID, d.Field, driftValueString(d.State), driftValueString(d.Live))
+}
+
+func driftValueString(v interface{}) string {
+	if v == nil {
+		return "null"
+	}
+	return strings.TrimSpace(driftSortKey(v))
+}
@@ -0,0 +1,101 @@
+package banyan
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_detectDrift(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	create := func(resourceType string, raw map[string]interface{}) StateResource {
+		r := Provider().ResourcesMap[resourceType]
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		require.Empty(t, r.CreateContext(ctx, d, c), resourceType)
+		return StateResource{Address: resourceType + ".test", Type: resourceType, ID: d.Id(), Attributes: stateAttributes(t, r, d)}
+	}
+	role := create("banyan_role", map[string]interface{}{
+		"name":        "Engineers",
+		"description": "engineering",
+		"user_group":  []interface{}{"Engineering", "Admins"},
+	})
+	policy := create("banyan_policy_web", map[string]interface{}{
+		"name":        "web-policy",
+		"description": "web",
+		"access": []interface{}{map[string]interface{}{
+			"roles":       []interface{}{"Engineers"},
+			"trust_level": "High",
+		}},
+	})
+	srv.AddService(service.GetServicesJson{ServiceID: "web.cluster1.bnn", ServiceName: "web", ClusterName: "cluster1",
+		ServiceSpec: `{"kind":"BanyanService","metadata":{"name":"web","cluster":"cluster1","tags":{"template":"WEB_USER","service_app_type":"WEB"}}}`})
+	attachment := create("banyan_policy_attachment", map[string]interface{}{
+		"policy_id":        policy.ID,
+		"attached_to_type": "service",
+		"attached_to_id":   "web.cluster1.bnn",
+	})
+	accessTier := create("banyan_accesstier", map[string]interface{}{
+		"name":       "us-west",
+		"address":    "*.us-west.example.com",
+		"api_key_id": "fake-api-key-id",
+	})
+	scim := create("banyan_scim", map[string]interface{}{"is_enabled": true})
+	// the token is only listed in token_info once terraform refreshed the resource
+	scimResource := Provider().ResourcesMap["banyan_scim"]
+	scimData := scimResource.Data(nil)
+	scimData.SetId(scim.ID)
+	require.NoError(t, scimData.Set("is_enabled", true))
+	require.Empty(t, scimResource.ReadContext(ctx, scimData, c))
+	scim.Attributes = stateAttributes(t, scimResource, scimData)
+	unmanaged := create("banyan_role", map[string]interface{}{
+		"name":       "Contractors",
+		"user_group": []interface{}{"Contractors"},
+	})
+
+	// the state as terraform writes it: sets in any order, numbers as float64, unset strings as null
+	role.Attributes["user_group"] = []interface{}{"Admins", "Engineering"}
+	role.Attributes["container_fqdn"] = nil
+	policy.Attributes["access"] = []interface{}{map[string]interface{}{
+		"roles":       []interface{}{"Engineers"},
+		"trust_level": "Medium",
+	}}
+	// attributes which only take effect on create are never read back
+	accessTier.Attributes["wait_for_netagents"] = float64(2)
+	accessTier.Attributes["wait_timeout"] = float64(60)
+	deleted := StateResource{Address: "banyan_role.gone", Type: "banyan_role", ID: "3f0ac4a4-1d4b-4bde-a5d2-5b8f0cf3e0a1"}
+
+	drifts, diags := DetectDrift(ctx, c, []StateResource{role, policy, attachment, accessTier, scim, deleted})
+	require.False(t, diags.HasError(), "%v", diags)
+	// every resource was read, none was skipped with a warning
+	assert.Empty(t, diags)
+	assert.Equal(t, []Drift{
+		{Kind: DriftChanged, Address: "banyan_policy_web.test", Type: "banyan_policy_web", ID: policy.ID,
+			Field: "access.0.trust_level", State: "Medium", Live: "High"},
+		{Kind: DriftDeleted, Address: "banyan_role.gone", Type: "banyan_role", ID: deleted.ID},
+		{Kind: DriftUnmanaged, Type: "banyan_role", ID: unmanaged.ID, Name: "Contractors"},
+		{Kind: DriftUnmanaged, Type: "banyan_service_web", ID: "web.cluster1.bnn", Name: "web"},
+	}, drifts)
+	assert.Equal(t, `banyan_policy_web.test (`+policy.ID+`) access.0.trust_level changed outside of terraform from "Medium" to "High"`, drifts[0].String())
+}
+
+// stateAttributes returns the attributes of the resource as terraform writes them to the state
+func stateAttributes(t *testing.T, r *schema.Resource, d *schema.ResourceData) map[string]interface{} {
+	ty := r.CoreConfigSchema().ImpliedType()
+	value, err := d.State().AttrsAsObjectValue(ty)
+	require.NoError(t, err)
+	raw, err := ctyjson.Marshal(value, ty)
+	require.NoError(t, err)
+	var attributes map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &attributes))
+	return attributes
+}
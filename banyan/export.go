@@ -0,0 +1,388 @@
+package banyan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// serviceResourceTypes maps the service_app_type tag of a service to the resource which manages it
+var serviceResourceTypes = map[string]string{
+	"WEB":      "banyan_service_web",
+	"SSH":      "banyan_service_ssh",
+	"RDP":      "banyan_service_rdp",
+	"K8S":      "banyan_service_k8s",
+	"DATABASE": "banyan_service_db",
+	"GENERIC":  "banyan_service_tcp",
+}
+
+// ExportedResource is an object of the org read into the state of the resource which manages it
+type ExportedResource struct {
+	// Type of the resource, e.g. banyan_service_web
+	Type string
+	// Name is the label of the resource in the configuration, unique per type
+	Name string
+	ID   string
+	data *schema.ResourceData
+}
+
+// exportObject is an object of the org found while enumerating it
+type exportObject struct {
+	resourceType string
+	id           string
+	name         string
+}
+
+// Export reads every object of the org which can be managed by a banyan_* resource. Objects which cannot be
+// exported, such as services of a type without a resource, are skipped with a warning.
+func Export(ctx context.Context, c *client.Holder) (exported []ExportedResource, diagnostics diag.Diagnostics) {
+	objects, diagnostics := exportObjects(ctx, c)
+	if diagnostics.HasError() {
+		return
+	}
+	resources := Provider().ResourcesMap
+	names := make(map[string]bool)
+	for _, o := range objects {
+		r := resources[o.resourceType]
+		d := r.Data(nil)
+		d.SetId(o.id)
+		readDiags := r.ReadContext(ctx, d, c)
+		if readDiags.HasError() || d.Id() == "" {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("could not read %s %s, skipped", o.resourceType, o.id),
+				Detail:   diagnosticsDetail(readDiags),
+			})
+			continue
+		}
+		name := exportName(o.name)
+		for i := 2; names[o.resourceType+"."+name]; i++ {
+			name = fmt.Sprintf("%s_%d", exportName(o.name), i)
+		}
+		names[o.resourceType+"."+name] = true
+		exported = append(exported, ExportedResource{Type: o.resourceType, Name: name, ID: o.id, data: d})
+	}
+	return
+}
+
+// exportObjects enumerates the org in dependency order, so that resources are written after the ones they refer to
+func exportObjects(ctx context.Context, c *client.Holder) (objects []exportObject, diagnostics diag.Diagnostics) {
+	roles, err := c.Role.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list roles: %s", err)
+	}
+	for _, r := range roles {
+		objects = append(objects, exportObject{"banyan_role", r.ID, r.Name})
+	}
+
+	tunnels, err := c.ServiceTunnel.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list service tunnels: %s", err)
+	}
+	tunnelPolicies := make(map[string]bool)
+	for _, t := range tunnels {
+		att, err := c.ServiceTunnel.GetPolicy(ctx, t.ID)
+		if err != nil {
+			return nil, diag.Errorf("could not get policy of service tunnel %s: %s", t.ID, err)
+		}
+		tunnelPolicies[att.PolicyID] = true
+	}
+	policies, err := c.Policy.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list policies: %s", err)
+	}
+	for _, p := range policies {
+		// the list of policies does not decode their specs
+		var spec policy.Object
+		err = json.Unmarshal([]byte(html.UnescapeString(p.Spec)), &spec)
+		if err != nil {
+			return nil, diag.Errorf("could not decode spec of policy %s: %s", p.ID, err)
+		}
+		resourceType := "banyan_policy_infra"
+		switch {
+		case tunnelPolicies[p.ID] || hasL4Access(spec.Spec.Access):
+			resourceType = "banyan_policy_tunnel"
+		case spec.IsWeb():
+			resourceType = "banyan_policy_web"
+		}
+		objects = append(objects, exportObject{resourceType, p.ID, p.Name})
+	}
+
+	atgs, err := c.AccessTierGroup.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list access tier groups: %s", err)
+	}
+	for _, atg := range atgs {
+		objects = append(objects, exportObject{"banyan_accesstier_group", atg.ID, atg.Name})
+	}
+	ats, err := c.AccessTier.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list access tiers: %s", err)
+	}
+	for _, at := range ats {
+		objects = append(objects, exportObject{"banyan_accesstier", at.ID, at.Name})
+	}
+	connectors, err := c.Satellite.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list connectors: %s", err)
+	}
+	for _, conn := range connectors {
+		objects = append(objects, exportObject{"banyan_connector", conn.ID, conn.Name})
+	}
+	domains, err := c.RegisteredDomain.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list registered domains: %s", err)
+	}
+	for _, rd := range domains {
+		objects = append(objects, exportObject{"banyan_registered_domain", rd.ID, rd.Name})
+	}
+
+	services, err := c.Service.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list services: %s", err)
+	}
+	for _, svc := range services {
+		if svc.IsDefault {
+			continue
+		}
+		tags, err := serviceTags(svc)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		resourceType, ok := serviceResourceTypes[tags["service_app_type"]]
+		if !ok {
+			diagnostics = append(diagnostics, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("service %s has type %q which no resource manages, skipped", svc.ServiceID, tags["service_app_type"]),
+			})
+			continue
+		}
+		objects = append(objects, exportObject{resourceType, svc.ServiceID, svc.ServiceName})
+	}
+	for _, t := range tunnels {
+		objects = append(objects, exportObject{"banyan_service_tunnel", t.ID, t.Name})
+	}
+
+	keys, err := c.ApiKey.GetAll(ctx)
+	if err != nil {
+		return nil, diag.Errorf("could not list API keys: %s", err)
+	}
+	for _, k := range keys {
+		objects = append(objects, exportObject{"banyan_api_key", k.ID, k.Name})
+	}
+
+	// keep the order of kinds above and sort by name within each kind
+	order := make(map[string]int)
+	for i, o := range objects {
+		if _, ok := order[o.resourceType]; !ok {
+			order[o.resourceType] = i
+		}
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].resourceType != objects[j].resourceType {
+			return order[objects[i].resourceType] < order[objects[j].resourceType]
+		}
+		return objects[i].name < objects[j].name
+	})
+	return
+}
+
+// WriteHCL writes the configuration of the exported resources followed by import blocks for them. Attributes which
+// hold the ID of another exported resource refer to it, sensitive attributes are left out with a comment.
+func WriteHCL(w io.Writer, exported []ExportedResource) (err error) {
+	refs := make(map[string]hcl.Traversal)
+	for _, e := range exported {
+		refs[e.ID] = hcl.Traversal{hcl.TraverseRoot{Name: e.Type}, hcl.TraverseAttr{Name: e.Name}, hcl.TraverseAttr{Name: "id"}}
+	}
+	resources := Provider().ResourcesMap
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+	for i, e := range exported {
+		if i > 0 {
+			root.AppendNewline()
+		}
+		block := root.AppendNewBlock("resource", []string{e.Type, e.Name})
+		values := make(map[string]interface{})
+		for k := range resources[e.Type].Schema {
+			values[k] = e.data.Get(k)
+		}
+		writeExportBody(block.Body(), resources[e.Type].Schema, values, refs, e.ID)
+	}
+	for _, e := range exported {
+		root.AppendNewline()
+		block := root.AppendNewBlock("import", nil)
+		block.Body().SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: e.Type}, hcl.TraverseAttr{Name: e.Name}})
+		block.Body().SetAttributeValue("id", cty.StringVal(e.ID))
+	}
+	_, err = w.Write(hclwrite.Format(f.Bytes()))
+	return
+}
+
+// writeExportBody writes the attributes and nested blocks of a resource, leaving out those which are not
+// configurable and optional ones which are not set
+func writeExportBody(body *hclwrite.Body, s map[string]*schema.Schema, values map[string]interface{}, refs map[string]hcl.Traversal, self string) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		// name reads best first
+		if keys[i] == "name" || keys[j] == "name" {
+			return keys[i] == "name"
+		}
+		return keys[i] < keys[j]
+	})
+	var blocks []string
+	for _, k := range keys {
+		attr := s[k]
+		v := values[k]
+		if (!attr.Required && !attr.Optional) || attr.Deprecated != "" || omitExportValue(attr, v) {
+			continue
+		}
+		if attr.Sensitive {
+			body.AppendUnstructuredTokens(hclwrite.Tokens{
+				{Type: hclsyntax.TokenComment, Bytes: []byte(fmt.Sprintf("# %s is sensitive and is not exported\n", k))},
+			})
+			continue
+		}
+		if _, ok := attr.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+			continue
+		}
+		body.SetAttributeRaw(k, exportTokens(attr, v, refs, self))
+	}
+	// nested blocks go after the attributes
+	for _, k := range blocks {
+		elem := s[k].Elem.(*schema.Resource)
+		for _, item := range exportList(values[k]) {
+			m, _ := item.(map[string]interface{})
+			block := body.AppendNewBlock(k, nil)
+			writeExportBody(block.Body(), elem.Schema, m, refs, self)
+			if len(block.Body().Attributes()) == 0 && len(block.Body().Blocks()) == 0 {
+				body.RemoveBlock(block)
+			}
+		}
+	}
+}
+
+// omitExportValue returns true for optional attributes which are unset or set to their default
+func omitExportValue(attr *schema.Schema, v interface{}) bool {
+	if attr.Required {
+		return false
+	}
+	if attr.Default != nil {
+		return reflect.DeepEqual(attr.Default, v)
+	}
+	// the provider decides the value of a computed bool which is not set, which need not be false
+	if attr.Computed && attr.Type == schema.TypeBool {
+		return false
+	}
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	case bool:
+		return !value
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return len(exportList(v)) == 0
+}
+
+func exportList(v interface{}) []interface{} {
+	switch value := v.(type) {
+	case *schema.Set:
+		return value.List()
+	case []interface{}:
+		return value
+	}
+	return nil
+}
+
+func exportTokens(attr *schema.Schema, v interface{}, refs map[string]hcl.Traversal, self string) hclwrite.Tokens {
+	switch value := v.(type) {
+	case string:
+		if ref, ok := refs[value]; ok && value != self {
+			return hclwrite.TokensForTraversal(ref)
+		}
+		return hclwrite.TokensForValue(cty.StringVal(value))
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(value)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(value))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(value))
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+		for _, k := range keys {
+			items = append(items, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(k)),
+				Value: exportTokens(attr, value[k], refs, self),
+			})
+		}
+		return hclwrite.TokensForObject(items)
+	}
+	var elems []hclwrite.Tokens
+	for _, item := range exportList(v) {
+		elems = append(elems, exportTokens(attr, item, refs, self))
+	}
+	return hclwrite.TokensForTuple(elems)
+}
+
+// exportName turns the name of an object into a label which is valid in terraform configuration
+func exportName(name string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '_'
+	}, name)
+	if label == "" || (label[0] >= '0' && label[0] <= '9') || label[0] == '-' {
+		label = "_" + label
+	}
+	return label
+}
+
+func hasL4Access(access []policy.Access) bool {
+	for _, a := range access {
+		if a.Rules.L4Access != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func diagnosticsDetail(diagnostics diag.Diagnostics) string {
+	var details []string
+	for _, d := range diagnostics {
+		details = append(details, d.Summary)
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,79 @@
+package banyan
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_exportRoundTrip(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	create := func(resourceType string, raw map[string]interface{}) string {
+		r := Provider().ResourcesMap[resourceType]
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		require.Empty(t, r.CreateContext(ctx, d, c), resourceType)
+		return d.Id()
+	}
+	create("banyan_role", map[string]interface{}{
+		"name":        "Engineers",
+		"description": "engineering",
+		"user_group":  []interface{}{"Engineering"},
+	})
+	policyID := create("banyan_policy_web", map[string]interface{}{
+		"name":        "web-policy",
+		"description": "web",
+		"access": []interface{}{map[string]interface{}{
+			"roles":       []interface{}{"Engineers"},
+			"trust_level": "High",
+		}},
+	})
+	create("banyan_service_web", map[string]interface{}{
+		"name":           "my web",
+		"access_tier":    "us-west1",
+		"domain":         "web.example.com",
+		"backend_domain": "10.0.0.1",
+		"backend_port":   8080,
+		"policy":         policyID,
+	})
+
+	exported, diags := Export(ctx, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, exported, 3)
+	assert.Equal(t, "banyan_role", exported[0].Type)
+	assert.Equal(t, "engineers", exported[0].Name)
+	assert.Equal(t, "banyan_policy_web", exported[1].Type)
+	assert.Equal(t, "banyan_service_web", exported[2].Type)
+	assert.Equal(t, "my_web", exported[2].Name)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteHCL(&buf, exported))
+	out := buf.String()
+	_, parseDiags := hclsyntax.ParseConfig(buf.Bytes(), "banyan.tf", hcl.InitialPos)
+	require.False(t, parseDiags.HasErrors(), "%s\n%s", parseDiags, out)
+
+	assert.Contains(t, out, `resource "banyan_service_web" "my_web" {`)
+	assert.Contains(t, out, "  policy         = banyan_policy_web.web-policy.id\n")
+	assert.Contains(t, out, "  backend_port   = 8080\n")
+	assert.Contains(t, out, "  user_group  = [\"Engineering\"]\n")
+	assert.Contains(t, out, "  description = \"web\"\n  access {\n    roles       = [\"Engineers\"]\n    trust_level = \"High\"\n  }\n")
+	assert.Contains(t, out, "import {\n  to = banyan_service_web.my_web\n  id = \""+exported[2].ID+"\"\n}")
+	assert.NotContains(t, out, "backend_tls", "unset optional attributes are left out")
+}
+
+func Test_exportName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "my_web-service", exportName("My Web-Service"))
+	assert.Equal(t, "_1password", exportName("1password"))
+	assert.Equal(t, "_", exportName(""))
+	assert.Equal(t, "a_example_com", exportName("a.example.com"))
+}
@@ -0,0 +1,137 @@
+package banyan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nameLookup returns the ID of every object of one resource type with the given name
+type nameLookup func(ctx context.Context, c *client.Holder, name string) (ids []string, err error)
+
+// importByName returns an importer which accepts either the ID of the object or name:<name>.
+// kind names the object in error messages. A name matching no object, or more than one, is an error.
+func importByName(kind string, lookup nameLookup) schema.StateContextFunc {
+	return func(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
+		inID := data.Id()
+		prefix, name, found := strings.Cut(inID, ":")
+		if !found {
+			return []*schema.ResourceData{data}, nil
+		}
+		if strings.ToLower(prefix) != "name" || name == "" {
+			return nil, fmt.Errorf("invalid ID (%s), expected <id> or name:<name of %s to import>", inID, kind)
+		}
+		c, ok := i.(*client.Holder)
+		if !ok {
+			return nil, fmt.Errorf("error occured during import %s", inID)
+		}
+		ids, err := lookup(ctx, c, name)
+		if err != nil && !restclient.IsNotFound(err) {
+			return nil, fmt.Errorf("could not look up %s %q: %w", kind, name, err)
+		}
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("no %s named %q was found", kind, name)
+		case 1:
+			data.SetId(ids[0])
+			return []*schema.ResourceData{data}, nil
+		default:
+			return nil, fmt.Errorf("%s name %q is ambiguous, it matches %d objects (%s); import by ID instead", kind, name, len(ids), strings.Join(ids, ", "))
+		}
+	}
+}
+
+func serviceIDsByName(ctx context.Context, c *client.Holder, name string) (ids []string, err error) {
+	services, err := c.Service.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, s := range services {
+		if s.ServiceName == name {
+			ids = append(ids, s.ServiceID)
+		}
+	}
+	return
+}
+
+func policyIDsByName(ctx context.Context, c *client.Holder, name string) (ids []string, err error) {
+	policies, err := c.Policy.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, p := range policies {
+		if p.Name == name {
+			ids = append(ids, p.ID)
+		}
+	}
+	return
+}
+
+func roleIDsByName(ctx context.Context, c *client.Holder, name string) (ids []string, err error) {
+	roles, err := c.Role.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, r := range roles {
+		if r.Name == name {
+			ids = append(ids, r.ID)
+		}
+	}
+	return
+}
+
+func connectorIDsByName(ctx context.Context, c *client.Holder, name string) (ids []string, err error) {
+	connectors, err := c.Satellite.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, s := range connectors {
+		if s.Name == name {
+			ids = append(ids, s.ID)
+		}
+	}
+	return
+}
+
+func serviceTunnelIDsByName(ctx context.Context, c *client.Holder, name string) (ids []string, err error) {
+	tunnels, err := c.ServiceTunnel.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, t := range tunnels {
+		if t.Name == name {
+			ids = append(ids, t.ID)
+		}
+	}
+	return
+}
+
+func accessTierIDsByName(ctx context.Context, c *client.Holder, name string) (ids []string, err error) {
+	ats, err := c.AccessTier.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, at := range ats {
+		if at.Name == name {
+			ids = append(ids, at.ID)
+		}
+	}
+	return
+}
+
+func accessTierGroupIDsByName(ctx context.Context, c *client.Holder, name string) (ids []string, err error) {
+	atgs, err := c.AccessTierGroup.GetAll(ctx)
+	if err != nil {
+		return
+	}
+	for _, atg := range atgs {
+		if atg.Name == name {
+			ids = append(ids, atg.ID)
+		}
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstiergroup"
+	"github.com/banyansecurity/terraform-banyan-provider/client/fakeapi"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_importByName(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	srv.AddService(service.GetServicesJson{ServiceID: "web.cluster1.bnn", ServiceName: "web", ClusterName: "cluster1"})
+	srv.AddService(service.GetServicesJson{ServiceID: "ssh.cluster1.bnn", ServiceName: "ssh", ClusterName: "cluster1"})
+	srv.AddService(service.GetServicesJson{ServiceID: "ssh.cluster2.bnn", ServiceName: "ssh", ClusterName: "cluster2"})
+
+	tests := []struct {
+		name   string
+		id     string
+		wantID string
+		err    string
+	}{
+		{name: "plain id", id: "9e23a0f4-3c5b-4c6e-8b55-1f0c6f6a3e21", wantID: "9e23a0f4-3c5b-4c6e-8b55-1f0c6f6a3e21"},
+		{name: "name with one match", id: "name:web", wantID: "web.cluster1.bnn"},
+		{name: "prefix in upper case", id: "NAME:web", wantID: "web.cluster1.bnn"},
+		{name: "no match", id: "name:rdp", err: `no service named "rdp" was found`},
+		{name: "ambiguous name", id: "name:ssh", err: `service name "ssh" is ambiguous, it matches 2 objects (ssh.cluster1.bnn, ssh.cluster2.bnn); import by ID instead`},
+		{name: "malformed prefix", id: "id:web", err: "invalid ID (id:web), expected <id> or name:<name of service to import>"},
+		{name: "empty name", id: "name:", err: "invalid ID (name:), expected <id> or name:<name of service to import>"},
+	}
+	importer := importByName("service", serviceIDsByName)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, WebSchema(), map[string]interface{}{})
+			d.SetId(tt.id)
+			imported, err := importer(context.Background(), d, c)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, imported, 1)
+			assert.Equal(t, tt.wantID, imported[0].Id())
+		})
+	}
+}
+
+func Test_importAccessTierByName(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	srv.AddAccessTier(accesstier.AccessTierInfo{ID: "at-1", Name: "edge"})
+	srv.AddAccessTier(accesstier.AccessTierInfo{ID: "at-2", Name: "edge"})
+	srv.AddAccessTier(accesstier.AccessTierInfo{ID: "at-3", Name: "core"})
+	var groupIDs []string
+	for i := 0; i < 2; i++ {
+		atg, err := c.AccessTierGroup.Create(ctx, accesstiergroup.AccessTierGroupPost{Name: "edge-group", ClusterName: fakeapi.DefaultCluster})
+		require.NoError(t, err)
+		groupIDs = append(groupIDs, atg.ID)
+	}
+
+	d := schema.TestResourceDataRaw(t, AccessTierSchema(), map[string]interface{}{})
+	d.SetId("name:core")
+	imported, err := importByName("access tier", accessTierIDsByName)(ctx, d, c)
+	require.NoError(t, err)
+	assert.Equal(t, "at-3", imported[0].Id())
+
+	d.SetId("name:edge")
+	_, err = importByName("access tier", accessTierIDsByName)(ctx, d, c)
+	assert.EqualError(t, err, `access tier name "edge" is ambiguous, it matches 2 objects (at-1, at-2); import by ID instead`)
+
+	d.SetId("name:edge-group")
+	_, err = importByName("access tier group", accessTierGroupIDsByName)(ctx, d, c)
+	assert.ErrorContains(t, err, `access tier group name "edge-group" is ambiguous, it matches 2 objects`)
+	for _, id := range groupIDs {
+		assert.ErrorContains(t, err, id)
+	}
+}
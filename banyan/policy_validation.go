@@ -0,0 +1,477 @@
+package banyan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// l7Actions are the application level actions which an l7_access rule may allow or deny, checked by the schema
+var l7Actions = []string{"CREATE", "READ", "UPDATE", "DELETE", "WRITE", "*"}
+
+// customizeDiffPolicy validates the access rules of banyan_policy_web, banyan_policy_tunnel and banyan_policy_infra
+// at plan time, so that mistakes do not surface halfway through an apply. Every problem is reported, each prefixed
+// with the path of the attribute it concerns.
+//
+// Roles which are known at plan time are checked against the roles of the org, including those of a new policy.
+// Roles which are only known at apply time are checked by Create and Update with checkPolicyRoles.
+func customizeDiffPolicy(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("access") {
+		return nil
+	}
+	// banyan_policy_web always uses http, banyan_policy_infra sets l7_protocol and then needs l7_access rules
+	l7Protocol := policy.L7_PROTOCOL_HTTP
+	protocol, hasProtocol := d.GetOk("l7_protocol")
+	if hasProtocol {
+		l7Protocol = protocol.(string)
+	}
+	var errs []error
+	access := d.Get("access").([]interface{})
+	for i, raw := range access {
+		data, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("access.%d", i)
+		for j := 0; j < i; j++ {
+			other, ok := access[j].(map[string]interface{})
+			if ok && other["trust_level"] == data["trust_level"] && data["roles"].(*schema.Set).Equal(other["roles"]) &&
+				other["start_time"] == data["start_time"] && other["end_time"] == data["end_time"] {
+				errs = append(errs, fmt.Errorf("%s: has the same roles, trust_level and time window as access.%d", path, j))
+				break
+			}
+		}
+		if d.NewValueKnown(path+".start_time") && d.NewValueKnown(path+".end_time") {
+			start, startErr := time.Parse(time.RFC3339, data["start_time"].(string))
+			end, endErr := time.Parse(time.RFC3339, data["end_time"].(string))
+			if startErr == nil && endErr == nil && !end.After(start) {
+				errs = append(errs, fmt.Errorf("%s.end_time: must be after start_time", path))
+			}
+		}
+		if l7, ok := data["l7_access"].([]interface{}); ok {
+			errs = append(errs, validateL7Access(d, path+".l7_access", l7, l7Protocol, !hasProtocol)...)
+		}
+		if l4, ok := data["l4_access"].([]interface{}); ok && len(l4) == 1 && l4[0] != nil {
+			errs = append(errs, validateL4Access(d, path+".l4_access.0", l4[0].(map[string]interface{}))...)
+		}
+	}
+
+	c, ok := m.(*client.Holder)
+	if ok && len(errs) == 0 {
+		known := make(map[string]bool)
+		for i, raw := range access {
+			data, ok := raw.(map[string]interface{})
+			if !ok || !d.NewValueKnown(fmt.Sprintf("access.%d.roles", i)) {
+				continue
+			}
+			for _, r := range convertSchemaSetToStringSlice(data["roles"].(*schema.Set)) {
+				known[r] = true
+			}
+		}
+		if len(known) > 0 {
+			err := checkPolicyRoles(ctx, c, access, known)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// customizeDiffPolicyOptions checks that the access groups of a banyan_policy_infra have l7_access rules exactly
+// when l7_protocol is set
+func customizeDiffPolicyOptions(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("l7_protocol") || !d.NewValueKnown("access") {
+		return nil
+	}
+	l7Protocol := d.Get("l7_protocol").(string)
+	var errs []error
+	for i, raw := range d.Get("access").([]interface{}) {
+		data, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		l7Access, _ := data["l7_access"].([]interface{})
+		if l7Protocol != "" && len(l7Access) == 0 {
+			errs = append(errs, fmt.Errorf("access.%d.l7_access: is required when l7_protocol is set", i))
+		}
+		if l7Protocol == "" && len(l7Access) > 0 {
+			errs = append(errs, fmt.Errorf("access.%d.l7_access: requires l7_protocol to be set", i))
+		}
+	}
+	if l7Protocol == policy.L7_PROTOCOL_HTTP && d.Get("disable_tls_client_authentication").(bool) {
+		errs = append(errs, errors.New("disable_tls_client_authentication: an http policy without TLS client authentication is a web policy, use banyan_policy_web"))
+	}
+	return errors.Join(errs...)
+}
+
+// checkPolicyRoles returns an error for every role of the access groups which does not exist in the org, other than
+// the built-in ANY role. If only is not nil, only the roles in it are looked up.
+func checkPolicyRoles(ctx context.Context, c *client.Holder, access []interface{}, only map[string]bool) error {
+	type reference struct {
+		path string
+		role string
+	}
+	var references []reference
+	for i, raw := range access {
+		data, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, r := range convertSchemaSetToStringSlice(data["roles"].(*schema.Set)) {
+			if only == nil || only[r] {
+				references = append(references, reference{fmt.Sprintf("access.%d.roles", i), r})
+			}
+		}
+	}
+	if len(references) == 0 {
+		return nil
+	}
+	all, err := c.Role.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list roles to check the roles of the policy: %w", err)
+	}
+	exists := map[string]bool{role.Any: true}
+	for _, r := range all {
+		exists[r.Name] = true
+	}
+	var errs []error
+	for _, ref := range references {
+		if !exists[ref.role] {
+			errs = append(errs, fmt.Errorf("%s: role %q does not exist", ref.path, ref.role))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateL7Access checks the resources of each l7_access rule, and that no rule repeats another, is
+// shadowed by a deny rule, or is shadowed by an earlier allow rule. A lone rule allowing everything is redundant
+// unless the rules are required.
+func validateL7Access(d *schema.ResourceDiff, path string, rules []interface{}, l7Protocol string, optional bool) (errs []error) {
+	type l7Rule struct {
+		resources []string
+		actions   []string
+	}
+	parsed := make([]*l7Rule, len(rules))
+	for i, raw := range rules {
+		rulePath := fmt.Sprintf("%s.%d", path, i)
+		data, ok := raw.(map[string]interface{})
+		if !ok || !d.NewValueKnown(rulePath+".resources") || !d.NewValueKnown(rulePath+".actions") {
+			continue
+		}
+		rule := &l7Rule{
+			resources: convertSchemaSetToStringSlice(data["resources"].(*schema.Set)),
+			actions:   convertSchemaSetToStringSlice(data["actions"].(*schema.Set)),
+		}
+		sort.Strings(rule.resources)
+		sort.Strings(rule.actions)
+		for _, r := range rule.resources {
+			err := validateL7Resource(r, l7Protocol)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s.resources: %q %w", rulePath, r, err))
+			}
+		}
+		// unset resources and actions default to *, see expandPolicyWebL7Access
+		if len(rule.resources) == 0 {
+			rule.resources = []string{"*"}
+		}
+		if len(rule.actions) == 0 {
+			rule.actions = []string{"*"}
+		}
+		parsed[i] = rule
+	}
+	if optional && len(parsed) == 1 && parsed[0] != nil && reflect.DeepEqual(*parsed[0], l7Rule{[]string{"*"}, []string{"*"}}) {
+		errs = append(errs, fmt.Errorf("%s: redundant l7_access block with allow_all rules; remove l7_access block entirely", path))
+	}
+
+	for i, rule := range parsed {
+		if rule == nil {
+			continue
+		}
+		rulePath := fmt.Sprintf("%s.%d", path, i)
+		for j, other := range parsed {
+			if other == nil || j == i {
+				continue
+			}
+			if j < i && reflect.DeepEqual(*other, *rule) {
+				errs = append(errs, fmt.Errorf("%s: duplicates %s.%d", rulePath, path, j))
+				break
+			}
+			if isDenyRule(rule.resources) {
+				continue
+			}
+			// a deny rule overrides the allow rules for the same resources wherever it is
+			if isDenyRule(other.resources) && actionsCover(other.actions, rule.actions) &&
+				resourcesCover(denied(other.resources), rule.resources) {
+				errs = append(errs, fmt.Errorf("%s: is shadowed by the deny rule %s.%d", rulePath, path, j))
+				break
+			}
+			if j < i && !isDenyRule(other.resources) && actionsCover(other.actions, rule.actions) &&
+				resourcesCover(other.resources, rule.resources) {
+				errs = append(errs, fmt.Errorf("%s: is shadowed by %s.%d, which allows the same resources and actions", rulePath, path, j))
+				break
+			}
+		}
+	}
+	return
+}
+
+// validateL7Resource checks that a resource, optionally prefixed with ! to deny it, is a path for http or a wildcard
+func validateL7Resource(r string, l7Protocol string) error {
+	resource := strings.TrimPrefix(r, "!")
+	switch {
+	case resource == "":
+		return errors.New("is empty")
+	case strings.HasPrefix(resource, "!"):
+		return errors.New("must use a single ! to deny a resource")
+	case strings.Contains(resource, "!"):
+		return errors.New("may only contain ! as its first character")
+	case l7Protocol == policy.L7_PROTOCOL_HTTP && !strings.HasPrefix(resource, "/") && !strings.HasPrefix(resource, "*"):
+		return errors.New("must start with / or *")
+	case strings.Contains(strings.Trim(resource, "*"), "*"):
+		return errors.New("may only have a wildcard at its start or end")
+	}
+	return nil
+}
+
+func isDenyRule(resources []string) bool {
+	for _, r := range resources {
+		if strings.HasPrefix(r, "!") {
+			return true
+		}
+	}
+	return false
+}
+
+func denied(resources []string) (patterns []string) {
+	for _, r := range resources {
+		if strings.HasPrefix(r, "!") {
+			patterns = append(patterns, strings.TrimPrefix(r, "!"))
+		}
+	}
+	return
+}
+
+func actionsCover(covering, covered []string) bool {
+	if contains(covering, "*") {
+		return true
+	}
+	for _, a := range covered {
+		if !contains(covering, a) {
+			return false
+		}
+	}
+	return true
+}
+
+// resourcesCover returns whether every resource matched by one of covered is matched by one of covering
+func resourcesCover(covering, covered []string) bool {
+	for _, r := range covered {
+		matched := false
+		for _, p := range covering {
+			if patternCovers(p, r) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// patternCovers returns whether every string matched by pattern q is matched by pattern p, where patterns may have a
+// wildcard at their start, their end, or both
+func patternCovers(p, q string) bool {
+	if p == "*" || p == q {
+		return true
+	}
+	pCore := strings.Trim(p, "*")
+	qCore := strings.Trim(q, "*")
+	pLeading, pTrailing := strings.HasPrefix(p, "*"), strings.HasSuffix(p, "*")
+	qLeading, qTrailing := strings.HasPrefix(q, "*"), strings.HasSuffix(q, "*")
+	switch {
+	case pLeading && pTrailing:
+		return strings.Contains(qCore, pCore)
+	case pTrailing:
+		return !qLeading && strings.HasPrefix(qCore, pCore)
+	case pLeading:
+		return !qTrailing && strings.HasSuffix(qCore, pCore)
+	}
+	return false
+}
+
+// validateL4Access checks the CIDRs and ports of the allow and deny rules, and that no allow rule repeats another
+// or is shadowed by a deny rule
+func validateL4Access(d *schema.ResourceDiff, path string, data map[string]interface{}) (errs []error) {
+	allow, _ := data["allow"].([]interface{})
+	deny, _ := data["deny"].([]interface{})
+	denyRules := validateL4Rules(d, path+".deny", deny, &errs)
+	allowRules := validateL4Rules(d, path+".allow", allow, &errs)
+	for i, rule := range allowRules {
+		if rule == nil {
+			continue
+		}
+		for j, other := range denyRules {
+			if other != nil && other.covers(*rule) {
+				errs = append(errs, fmt.Errorf("%s.allow.%d: is shadowed by the deny rule %s.deny.%d", path, i, path, j))
+				break
+			}
+		}
+	}
+	return
+}
+
+type l4Rule struct {
+	cidrs     []string
+	fqdns     []string
+	protocols []string
+	ports     []string
+}
+
+// validateL4Rules checks each rule, reporting into errs, and returns the rules with their defaults applied. Rules
+// with unknown values are nil.
+func validateL4Rules(d *schema.ResourceDiff, path string, rules []interface{}, errs *[]error) (parsed []*l4Rule) {
+	for i, raw := range rules {
+		rulePath := fmt.Sprintf("%s.%d", path, i)
+		data, ok := raw.(map[string]interface{})
+		if !ok || !d.NewValueKnown(rulePath) {
+			parsed = append(parsed, nil)
+			continue
+		}
+		rule := &l4Rule{
+			cidrs:     convertSchemaSetToStringSlice(data["cidrs"].(*schema.Set)),
+			fqdns:     convertSchemaSetToStringSlice(data["fqdns"].(*schema.Set)),
+			protocols: convertSchemaSetToStringSlice(data["protocols"].(*schema.Set)),
+			ports:     convertSchemaSetToStringSlice(data["ports"].(*schema.Set)),
+		}
+		for _, cidr := range rule.cidrs {
+			if cidr == "*" {
+				continue
+			}
+			_, _, err := net.ParseCIDR(cidr)
+			if err != nil {
+				*errs = append(*errs, fmt.Errorf("%s.cidrs: %q is not a CIDR", rulePath, cidr))
+			}
+		}
+		for _, port := range rule.ports {
+			_, _, err := parsePortRange(port)
+			if err != nil {
+				*errs = append(*errs, fmt.Errorf("%s.ports: %q %w", rulePath, port, err))
+			}
+		}
+		// the defaults of expandL4Rules
+		if rule.cidrs == nil && rule.fqdns == nil {
+			rule.cidrs = []string{"*"}
+		}
+		if rule.protocols == nil {
+			rule.protocols = []string{"ALL"}
+		}
+		if rule.ports == nil {
+			rule.ports = []string{"*"}
+		}
+		sort.Strings(rule.cidrs)
+		sort.Strings(rule.fqdns)
+		sort.Strings(rule.protocols)
+		sort.Strings(rule.ports)
+		for j, earlier := range parsed {
+			if earlier != nil && reflect.DeepEqual(*earlier, *rule) {
+				*errs = append(*errs, fmt.Errorf("%s: duplicates %s.%d", rulePath, path, j))
+				break
+			}
+		}
+		parsed = append(parsed, rule)
+	}
+	return
+}
+
+// parsePortRange parses a port, a range of ports like 8000-8080, or * for all ports
+func parsePortRange(port string) (from int, to int, err error) {
+	if port == "*" {
+		return 1, 65535, nil
+	}
+	fromString, toString, isRange := strings.Cut(port, "-")
+	from, err = strconv.Atoi(fromString)
+	if err != nil {
+		return 0, 0, errors.New("is not a port, a range of ports or *")
+	}
+	to = from
+	if isRange {
+		to, err = strconv.Atoi(toString)
+		if err != nil {
+			return 0, 0, errors.New("is not a port, a range of ports or *")
+		}
+	}
+	if from < 1 || to > 65535 || from > to {
+		return 0, 0, errors.New("must be between 1 and 65535, with the start of a range before its end")
+	}
+	return
+}
+
+// covers returns whether every connection matched by rule is matched by r
+func (r l4Rule) covers(rule l4Rule) bool {
+	if !contains(r.protocols, "ALL") {
+		for _, p := range rule.protocols {
+			if !contains(r.protocols, p) {
+				return false
+			}
+		}
+	}
+	for _, port := range rule.ports {
+		from, to, err := parsePortRange(port)
+		if err != nil {
+			return false
+		}
+		covered := false
+		for _, other := range r.ports {
+			otherFrom, otherTo, err := parsePortRange(other)
+			if err == nil && otherFrom <= from && to <= otherTo {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	for _, cidr := range rule.cidrs {
+		if !cidrsCover(r.cidrs, cidr) {
+			return false
+		}
+	}
+	return resourcesCover(r.fqdns, rule.fqdns)
+}
+
+func cidrsCover(covering []string, cidr string) bool {
+	if contains(covering, "*") {
+		return true
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false
+	}
+	ones, _ := network.Mask.Size()
+	for _, c := range covering {
+		_, other, err := net.ParseCIDR(c)
+		if err != nil {
+			continue
+		}
+		otherOnes, _ := other.Mask.Size()
+		if otherOnes <= ones && other.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
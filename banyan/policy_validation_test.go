@@ -0,0 +1,263 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_customizeDiffPolicy(t *testing.T) {
+	t.Parallel()
+	l7 := func(rules ...map[string]interface{}) map[string]interface{} {
+		l7Access := make([]interface{}, len(rules))
+		for i, r := range rules {
+			l7Access[i] = r
+		}
+		return map[string]interface{}{
+			"name":        "web",
+			"description": "web",
+			"access": []interface{}{map[string]interface{}{
+				"roles":       []interface{}{"Everyone"},
+				"trust_level": "High",
+				"l7_access":   l7Access,
+			}},
+		}
+	}
+	l4 := func(allow, deny []interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        "tunnel",
+			"description": "tunnel",
+			"access": []interface{}{map[string]interface{}{
+				"roles":       []interface{}{"Everyone"},
+				"trust_level": "High",
+				"l4_access":   []interface{}{map[string]interface{}{"allow": allow, "deny": deny}},
+			}},
+		}
+	}
+	rule := func(resources []interface{}, actions ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"resources": resources, "actions": actions}
+	}
+
+	tests := []struct {
+		name         string
+		resourceType string
+		raw          map[string]interface{}
+		errs         []string
+	}{
+		{
+			name:         "deny then allow all",
+			resourceType: "banyan_policy_web",
+			raw:          l7(rule([]interface{}{"!/wp-admin*"}, "*"), rule([]interface{}{"*"}, "*")),
+		},
+		{
+			name:         "invalid resources",
+			resourceType: "banyan_policy_web",
+			raw:          l7(rule([]interface{}{"api/*", "!!/admin", "/a*b"}, "READ")),
+			errs: []string{
+				`access.0.l7_access.0.resources: "api/*" must start with / or *`,
+				`access.0.l7_access.0.resources: "!!/admin" must use a single ! to deny a resource`,
+				`access.0.l7_access.0.resources: "/a*b" may only have a wildcard at its start or end`,
+			},
+		},
+		{
+			name:         "redundant allow all",
+			resourceType: "banyan_policy_web",
+			raw:          l7(rule([]interface{}{"*"}, "*")),
+			errs:         []string{"access.0.l7_access: redundant l7_access block with allow_all rules"},
+		},
+		{
+			name:         "shadowed rules",
+			resourceType: "banyan_policy_web",
+			raw: l7(
+				rule([]interface{}{"/api/*"}, "READ", "WRITE"),
+				rule([]interface{}{"/api/users"}, "READ"),
+				rule([]interface{}{"/admin/users*"}, "*"),
+				rule([]interface{}{"!/admin*"}, "*"),
+				rule([]interface{}{"/api/*"}, "WRITE", "READ"),
+			),
+			errs: []string{
+				"access.0.l7_access.1: is shadowed by access.0.l7_access.0, which allows the same resources and actions",
+				"access.0.l7_access.2: is shadowed by the deny rule access.0.l7_access.3",
+				"access.0.l7_access.4: duplicates access.0.l7_access.0",
+			},
+		},
+		{
+			name:         "l4 rules",
+			resourceType: "banyan_policy_tunnel",
+			raw: l4(
+				[]interface{}{
+					map[string]interface{}{"cidrs": []interface{}{"10.0.0.0/8"}, "ports": []interface{}{"443", "8000-8080"}, "protocols": []interface{}{"TCP"}},
+					map[string]interface{}{"cidrs": []interface{}{"10.1.2.0/24"}, "ports": []interface{}{"22"}},
+					map[string]interface{}{"cidrs": []interface{}{"10.0.0"}, "ports": []interface{}{"http", "0", "90-80"}},
+				},
+				[]interface{}{map[string]interface{}{"cidrs": []interface{}{"10.1.0.0/16"}, "ports": []interface{}{"1-1024"}}},
+			),
+			errs: []string{
+				`access.0.l4_access.0.allow.2.cidrs: "10.0.0" is not a CIDR`,
+				`access.0.l4_access.0.allow.2.ports: "0" must be between 1 and 65535`,
+				`access.0.l4_access.0.allow.2.ports: "90-80" must be between 1 and 65535`,
+				`access.0.l4_access.0.allow.2.ports: "http" is not a port, a range of ports or *`,
+				"access.0.l4_access.0.allow.1: is shadowed by the deny rule access.0.l4_access.0.deny.0",
+			},
+		},
+		{
+			name:         "overlapping access groups",
+			resourceType: "banyan_policy_infra",
+			raw: map[string]interface{}{
+				"name":        "infra",
+				"description": "infra",
+				"access": []interface{}{
+					map[string]interface{}{"roles": []interface{}{"A", "B"}, "trust_level": "High"},
+					map[string]interface{}{"roles": []interface{}{"B", "A"}, "trust_level": "High"},
+				},
+			},
+			errs: []string{"access.1: has the same roles, trust_level and time window as access.0"},
+		},
+		{
+			name:         "time windows",
+			resourceType: "banyan_policy_infra",
+			raw: map[string]interface{}{
+				"name":        "infra",
+				"description": "infra",
+				"access": []interface{}{
+					map[string]interface{}{"roles": []interface{}{"A"}, "trust_level": "High"},
+					map[string]interface{}{"roles": []interface{}{"A"}, "trust_level": "High",
+						"start_time": "2030-01-02T00:00:00Z", "end_time": "2030-01-01T00:00:00Z"},
+				},
+			},
+			errs: []string{"access.1.end_time: must be after start_time"},
+		},
+		{
+			name:         "kafka rules",
+			resourceType: "banyan_policy_infra",
+			raw: map[string]interface{}{
+				"name":        "kafka",
+				"description": "kafka",
+				"l7_protocol": "kafka",
+				"access": []interface{}{
+					map[string]interface{}{"roles": []interface{}{"A"}, "trust_level": "High",
+						"l7_access": []interface{}{rule([]interface{}{"orders*"}, "READ")}},
+					map[string]interface{}{"roles": []interface{}{"B"}, "trust_level": "High",
+						"l7_access": []interface{}{rule([]interface{}{"*"}, "*")}},
+				},
+			},
+		},
+		{
+			name:         "l7 rules and protocol",
+			resourceType: "banyan_policy_infra",
+			raw: map[string]interface{}{
+				"name":                              "infra",
+				"description":                       "infra",
+				"l7_protocol":                       "http",
+				"disable_tls_client_authentication": true,
+				"access": []interface{}{
+					map[string]interface{}{"roles": []interface{}{"A"}, "trust_level": "High"},
+				},
+			},
+			errs: []string{
+				"access.0.l7_access: is required when l7_protocol is set",
+				"disable_tls_client_authentication: an http policy without TLS client authentication is a web policy",
+			},
+		},
+		{
+			name:         "l7 rules without protocol",
+			resourceType: "banyan_policy_infra",
+			raw: map[string]interface{}{
+				"name":        "infra",
+				"description": "infra",
+				"access": []interface{}{
+					map[string]interface{}{"roles": []interface{}{"A"}, "trust_level": "High",
+						"l7_access": []interface{}{rule([]interface{}{"/api*"}, "READ")}},
+				},
+			},
+			errs: []string{"access.0.l7_access: requires l7_protocol to be set"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Provider().ResourcesMap[tt.resourceType]
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), nil)
+			if len(tt.errs) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, e := range tt.errs {
+				assert.Contains(t, err.Error(), e)
+			}
+		})
+	}
+}
+
+func Test_policyActionsValidation(t *testing.T) {
+	raw := map[string]interface{}{
+		"name":        "web",
+		"description": "web",
+		"access": []interface{}{
+			map[string]interface{}{
+				"roles":       []interface{}{"Everyone"},
+				"trust_level": "High",
+				"l7_access": []interface{}{
+					map[string]interface{}{"resources": []interface{}{"/api*"}, "actions": []interface{}{"WRITE", "PATCH"}},
+				},
+			},
+		},
+	}
+	diags := Provider().ResourcesMap["banyan_policy_web"].Validate(terraform.NewResourceConfigRaw(raw))
+	require.Len(t, diags, 1)
+	assert.Contains(t, diags[0].Summary, `expected access.0.l7_access.0.actions.1 to be one of`)
+	assert.Contains(t, diags[0].Summary, `got PATCH`)
+}
+
+func Test_checkPolicyRoles(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := Provider().ResourcesMap["banyan_role"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":       "Engineers",
+		"user_group": []interface{}{"Engineering"},
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	policy := Provider().ResourcesMap["banyan_policy_infra"]
+	d = schema.TestResourceDataRaw(t, policy.Schema, map[string]interface{}{
+		"name":        "infra",
+		"description": "infra",
+		"access": []interface{}{
+			map[string]interface{}{"roles": []interface{}{"Engineers", "Contractors", "ANY"}, "trust_level": "High"},
+		},
+	})
+	access := d.Get("access").([]interface{})
+
+	// a new policy is checked at plan time, except for roles which are not known yet
+	raw := map[string]interface{}{
+		"name":        "infra",
+		"description": "infra",
+		"access": []interface{}{
+			map[string]interface{}{"roles": []interface{}{"Engineers", "Contractors", "ANY"}, "trust_level": "High"},
+		},
+	}
+	_, err := policy.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), c)
+	assert.EqualError(t, err, `access.0.roles: role "Contractors" does not exist`)
+	// the value Terraform uses in a raw config for a value which is only known at apply time
+	raw["access"] = []interface{}{
+		map[string]interface{}{"roles": "74D93920-ED26-11E3-AC10-0800200C9A66", "trust_level": "High"},
+	}
+	_, err = policy.Diff(ctx, nil, terraform.NewResourceConfigRaw(raw), c)
+	assert.NoError(t, err)
+
+	err = checkPolicyRoles(ctx, c, access, nil)
+	assert.EqualError(t, err, `access.0.roles: role "Contractors" does not exist`)
+	assert.NoError(t, checkPolicyRoles(ctx, c, access, map[string]bool{"Engineers": true}))
+
+	diags := policy.CreateContext(ctx, d, c)
+	require.True(t, diags.HasError())
+	assert.Equal(t, `access.0.roles: role "Contractors" does not exist`, diags[0].Summary)
+}
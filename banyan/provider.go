@@ -4,12 +4,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	bnnClient "github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/credentials"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const defaultHost = "https://net.banyanops.com/"
+
 // Provider for Banyan
 func Provider() *schema.Provider {
 	var provider = schema.Provider{
@@ -17,19 +23,86 @@ func Provider() *schema.Provider {
 			"host": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "The Banyan Command Center API URL",
-				DefaultFunc: schema.EnvDefaultFunc("BANYAN_HOST", "https://net.banyanops.com/"),
+				Description: "The Banyan Command Center API URL. Defaults to the BANYAN_HOST environment variable, then the profile, then https://net.banyanops.com/",
+				DefaultFunc: schema.EnvDefaultFunc("BANYAN_HOST", nil),
 			},
 			"api_key": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "An admin scoped API key",
+				Description: "An admin scoped API key. Defaults to the BANYAN_API_KEY environment variable, then the profile",
 				DefaultFunc: schema.EnvDefaultFunc("BANYAN_API_KEY", nil),
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the profile in the credentials file to read host and api_key from. An explicit profile takes precedence over BANYAN_HOST and BANYAN_API_KEY unless both host and api_key are set in the provider block. Defaults to the BANYAN_PROFILE environment variable, then default",
+				DefaultFunc: schema.EnvDefaultFunc("BANYAN_PROFILE", ""),
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the credentials file holding the profiles. Defaults to the BANYAN_CONFIG_FILE environment variable, then ~/.banyan/credentials",
+				DefaultFunc: schema.EnvDefaultFunc("BANYAN_CONFIG_FILE", ""),
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of times a request is retried after a 429, 502, 503, 504 or connection error. Only idempotent requests are retried on errors other than 429. Set to 0 to disable retries. Defaults to the BANYAN_MAX_RETRIES environment variable, then 4",
+				DefaultFunc:  schema.EnvDefaultFunc("BANYAN_MAX_RETRIES", 4),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum time in seconds to wait before retrying a request, doubled on each subsequent retry. Defaults to the BANYAN_RETRY_WAIT_MIN environment variable, then 1",
+				DefaultFunc:  schema.EnvDefaultFunc("BANYAN_RETRY_WAIT_MIN", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum time in seconds to wait between two attempts, including waits requested by a Retry-After header. Defaults to the BANYAN_RETRY_WAIT_MAX environment variable, then 30",
+				DefaultFunc:  schema.EnvDefaultFunc("BANYAN_RETRY_WAIT_MAX", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Time limit in seconds for a single request to the Banyan API, including reading the response",
+				DefaultFunc:  schema.EnvDefaultFunc("BANYAN_REQUEST_TIMEOUT", 10),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of the proxy used to reach the Banyan API. Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables",
+				DefaultFunc:  schema.EnvDefaultFunc("BANYAN_HTTP_PROXY", ""),
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsURLWithScheme([]string{"http", "https", "socks5"})),
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM encoded bundle of certificate authorities to trust in addition to the system roots",
+				DefaultFunc:   schema.EnvDefaultFunc("BANYAN_CA_CERT_FILE", ""),
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM encoded bundle of certificate authorities to trust in addition to the system roots",
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip verification of the Banyan API TLS certificate. Only use this for testing",
+				DefaultFunc: schema.EnvDefaultFunc("BANYAN_INSECURE_SKIP_VERIFY", false),
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"banyan_service_ssh":                resourceServiceSsh(),
 			"banyan_service_rdp":                resourceServiceRdp(),
+			"banyan_service_raw":                resourceServiceRaw(),
 			"banyan_service_tcp":                resourceServiceTcp(),
 			"banyan_service_k8s":                resourceServiceK8s(),
 			"banyan_service_db":                 resourceServiceDb(),
@@ -47,13 +120,20 @@ func Provider() *schema.Provider {
 			"banyan_app_config":                 resourceAppConfig(),
 			"banyan_registered_domain":          resourceRegisteredDomain(),
 			"banyan_validate_registered_domain": resourceValidateRegisteredDomain(),
+			"banyan_org_idp_config":             resourceOrgIdpConfig(),
+			"banyan_policy_attachment":          resourcePolicyAttachment(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"banyan_oidc_settings": dataSourceOidcSettings(),
-			"banyan_policy_web":    dataSourcePolicyWeb(),
-			"banyan_policy_tunnel": dataSourcePolicyTunnel(),
-			"banyan_policy_infra":  dataSourcePolicyInfra(),
-			"banyan_role":          dataSourceRole(),
+			"banyan_access_tier":       dataSourceAccessTier(),
+			"banyan_access_tiers":      dataSourceAccessTiers(),
+			"banyan_oidc_settings":     dataSourceOidcSettings(),
+			"banyan_policy_evaluation": dataSourcePolicyEvaluation(),
+			"banyan_policy_web":        dataSourcePolicyWeb(),
+			"banyan_policy_tunnel":     dataSourcePolicyTunnel(),
+			"banyan_policy_infra":      dataSourcePolicyInfra(),
+			"banyan_role":              dataSourceRole(),
+			"banyan_service":           dataSourceService(),
+			"banyan_services":          dataSourceServices(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
@@ -62,17 +142,75 @@ func Provider() *schema.Provider {
 
 // Configures the Banyan provider with the given refresh / API token and host url
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (client interface{}, diagnostic diag.Diagnostics) {
-	host := d.Get("host").(string)
+	host, apiKey := d.Get("host").(string), d.Get("api_key").(string)
+	profile := d.Get("profile").(string)
+	if profile != "" {
+		// an explicit profile wins over BANYAN_HOST and BANYAN_API_KEY, so only pass on what the provider block sets
+		host, apiKey = configuredString(d, "host"), configuredString(d, "api_key")
+	}
+	host, apiKey, err := credentials.Resolve(host, apiKey, d.Get("config_file").(string), profile)
+	if err != nil {
+		diagnostic = append(diagnostic, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read Banyan credentials profile",
+			Detail:   err.Error(),
+		})
+		return
+	}
+	if host == "" {
+		host = defaultHost
+	}
 	if !strings.HasSuffix(host, "/") {
 		host = host + "/"
 	}
-	client, err := bnnClient.NewClientHolder(host, d.Get("api_key").(string))
+	opts := restclient.DefaultOptions()
+	opts.Retry = restclient.RetryPolicy{
+		MaxRetries: d.Get("max_retries").(int),
+		WaitMin:    time.Duration(d.Get("retry_wait_min").(int)) * time.Second,
+		WaitMax:    time.Duration(d.Get("retry_wait_max").(int)) * time.Second,
+	}
+	opts.Transport = restclient.TransportOptions{
+		Timeout:            time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		Proxy:              d.Get("http_proxy").(string),
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}
+	if opts.Retry.WaitMax < opts.Retry.WaitMin {
+		diagnostic = append(diagnostic, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid retry configuration",
+			Detail:   "retry_wait_max must be greater than or equal to retry_wait_min",
+		})
+		return
+	}
+	client, err = bnnClient.NewClientHolderWithOptions(host, apiKey, opts)
 	if err != nil {
 		diagnostic = append(diagnostic, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create Banyan client",
 			Detail:   "Unable to authenticate to the Banyan API" + fmt.Sprintf("%+v", err),
 		})
+		return
+	}
+	if opts.Transport.InsecureSkipVerify {
+		diagnostic = append(diagnostic, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "TLS certificate verification is disabled",
+			Detail:   "insecure_skip_verify is set, the Banyan API certificate will not be verified",
+		})
 	}
 	return
 }
+
+// configuredString returns the value of key set in the provider block, ignoring its environment default
+func configuredString(d *schema.ResourceData, key string) string {
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		return ""
+	}
+	if v := raw.GetAttr(key); !v.IsNull() && v.IsKnown() {
+		return v.AsString()
+	}
+	return ""
+}
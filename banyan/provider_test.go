@@ -4,8 +4,10 @@ import (
 	"github.com/banyansecurity/terraform-banyan-provider/client"
 	"github.com/banyansecurity/terraform-banyan-provider/client/testenv"
 	"log"
+	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -14,8 +16,11 @@ var testAccProvider *schema.Provider
 var testAccClient *client.Holder
 
 func init() {
-	testAccPreCheck()
-	testAccClient = NewAccClient()
+	// only acceptance tests run against a real org, the other tests use fakeapi
+	if os.Getenv(resource.EnvTfAcc) != "" {
+		testAccPreCheck()
+		testAccClient = NewAccClient()
+	}
 	testAccProvider = Provider()
 	testAccProviders = map[string]*schema.Provider{
 		"banyan": testAccProvider,
@@ -48,3 +53,13 @@ func testAccPreCheck() {
 		log.Fatal("BANYAN_HOST must be set for acceptance tests")
 	}
 }
+
+func TestProvider_retryEnvDefaults(t *testing.T) {
+	t.Setenv("BANYAN_MAX_RETRIES", "2")
+	t.Setenv("BANYAN_RETRY_WAIT_MIN", "3")
+	t.Setenv("BANYAN_RETRY_WAIT_MAX", "60")
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	if d.Get("max_retries") != 2 || d.Get("retry_wait_min") != 3 || d.Get("retry_wait_max") != 60 {
+		t.Fatalf("retry settings not read from the environment: %v, %v, %v", d.Get("max_retries"), d.Get("retry_wait_min"), d.Get("retry_wait_max"))
+	}
+}
@@ -2,14 +2,18 @@ package banyan
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client"
 	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -22,36 +26,18 @@ func resourceAccessTier() *schema.Resource {
 		ReadContext:   resourceAccessTierRead,
 		UpdateContext: resourceAccessTierUpdate,
 		DeleteContext: resourceAccessTierDelete,
+		CustomizeDiff: customdiff.All(customizeDiffLocalConfig, customizeDiffWaitTimeout),
 		Schema:        AccessTierSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			// leaves room for wait_for_netagents after the access tier is created
+			Create: schema.DefaultTimeout(defaultAccessTierCreateTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: resourceImporter,
+			StateContext: importByName("access tier", accessTierIDsByName),
 		},
 	}
 }
 
-func resourceImporter(ctx context.Context, data *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
-	inID := data.Id()
-	parts := strings.Split(inID, ":")
-	if len(parts) == 1 {
-		data.SetId(inID)
-		return []*schema.ResourceData{data}, nil
-	}
-	if len(parts) != 2 || strings.ToLower(parts[0]) != "name" {
-		return nil, fmt.Errorf("invalid ID (%s), expected name:<name of access tier to import>", parts)
-	}
-	name := parts[1]
-	accessTierClient, ok := i.(*client.Holder)
-	if !ok {
-		return nil, fmt.Errorf("error occured during import %s", inID)
-	}
-	accessTierInfo, err := accessTierClient.AccessTier.GetName(name)
-	if err != nil {
-		return nil, err
-	}
-	data.SetId(accessTierInfo.ID)
-	return []*schema.ResourceData{data}, nil
-}
-
 func AccessTierSchema() map[string]*schema.Schema {
 	s := map[string]*schema.Schema{
 		"name": {
@@ -162,6 +148,26 @@ func AccessTierSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Enable rate limiting of Access Event generation based on a credit-based rate control mechanism",
 		},
+		"access_event_credits_per_interval": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Number of access event credits to assign after each interval",
+		},
+		"access_event_credits_interval": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Interval in seconds after which access event credits are assigned",
+		},
+		"access_event_credits_max": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Maximum number of access event credits. One access event consumes one credit",
+		},
+		"access_event_key_expiration": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Interval in seconds after which another access event may be generated for the same access key",
+		},
 		"forward_trust_cookie": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -177,6 +183,69 @@ func AccessTierSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Timeout in seconds infrastructure sessions connected via the access tier",
 		},
+		"bad_actor": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Enable denial of service protection, which jails IP addresses making too many unauthorized requests",
+		},
+		"infraction_count": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Number of unauthorized requests before an offending IP address is jailed",
+		},
+		"sentence_time": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Time in seconds after which a jailed IP address is freed",
+		},
+		"host_tags": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Key-value pairs used for attribute matching on Netagent",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"listen_port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "TCP port on which Netagent listens for incoming connections, from 1024 to 65535",
+			ValidateFunc: validation.IntBetween(1024, 65535),
+		},
+		"listen_port_health": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "TCP port on which Netagent listens for health checks, from 1024 to 65535",
+			ValidateFunc: validation.IntBetween(1024, 65535),
+		},
+		"https_proxy": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "HTTP CONNECT proxy used for the control connection to Shield. Overrides the HTTPS_PROXY environment variable",
+		},
+		"public_ip_source": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "How Netagent determines its public IP. Must be one of \"AWS\", \"GCE\", \"default\", \"none\"",
+			ValidateFunc: validation.StringInSlice([]string{"AWS", "GCE", "default", "none"}, false),
+		},
+		"cpu_limit": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Maximum percentage of CPU core usage, from 1 to 100",
+			ValidateFunc: validation.IntBetween(1, 100),
+		},
+		"user_mode_tunnel": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Use a userspace WireGuard module instead of the kernel module",
+		},
+		"enduser_tunnel_cidr": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "CIDR range from which end user tunnel addresses are assigned, for source NAT",
+			ValidateFunc: validateCIDR(),
+		},
 		"debug_http_backend_log": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -298,58 +367,261 @@ func AccessTierSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "description of an access tier",
 		},
+		"wait_for_netagents": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Number of healthy, recently active netagents to wait for after the access tier is created, so that services are not registered before any netagent has checked in. Zero means not to wait. If they do not check in within wait_timeout the apply fails and the access tier is tainted, so the next apply replaces it and its tunnel keys",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"wait_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Timeout in seconds for wait_for_netagents. Defaults to 600, and must be shorter than the create timeout, which defaults to 30 minutes",
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"tunnel_satellite": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Tunnel configuration of the access tier for connectors, e.g. its wireguard public key and UDP port for firewall rules",
+			Elem:        accessTierTunnelDataSchema(),
+		},
+		"tunnel_enduser": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Tunnel configuration of the access tier for end users, e.g. its shared FQDN for DNS records",
+			Elem:        accessTierTunnelDataSchema(),
+		},
 	}
 	return s
 }
 
-func updateLocalConfig(d *schema.ResourceData, c *client.Holder, spec accesstier.AccessTierInfo) (diagnostics diag.Diagnostics) {
-	currentLc, err := c.AccessTier.GetLocalConfig(spec.Name)
+func updateLocalConfig(ctx context.Context, d *schema.ResourceData, c *client.Holder, spec accesstier.AccessTierInfo) (diagnostics diag.Diagnostics) {
+	currentLc, err := c.AccessTier.GetLocalConfig(ctx, spec.Name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	lc := accesstier.AccessTierLocalConfig{
-		BaseParameters: &accesstier.BaseParameters{
-			ShieldAddress: currentLc.ShieldAddress,
-			SiteAddress:   currentLc.SiteAddress,
-		},
-		LoggingParameters:               expandLogging(d),
-		EventParameters:                 expandEventParameters(d),
-		HostedWebServiceParameters:      expandHostedWebServices(d),
-		InfrastructureServiceParameters: expandInfrastructureService(d),
-		DebuggingParameters:             expandDebugging(d),
+	lc := expandAdvancedSettings(d)
+	lc.BaseParameters = &accesstier.BaseParameters{
+		ShieldAddress: currentLc.ShieldAddress,
+		SiteAddress:   currentLc.SiteAddress,
+	}
+	// access_tier is not configurable, keep the mode the netagents run in
+	if currentLc.MiscellaneousParameters != nil {
+		lc.MiscellaneousParameters.AccessTier = currentLc.MiscellaneousParameters.AccessTier
 	}
 	// Combining local config with accesstier facing config
-	_, err = c.AccessTier.UpdateLocalConfig(spec.ID, lc)
+	_, err = c.AccessTier.UpdateLocalConfig(ctx, spec.ID, lc)
 	if err != nil {
 		return diag.Errorf("failed to update local configuration for %s", spec.Name)
 	}
 	return
 }
 
+// localConfigAttributes maps the local config parameters which have a valid tag to the attributes they are set from
+var localConfigAttributes = map[string]string{
+	"logging.console_log_level":        "console_log_level",
+	"logging.file_log_level":           "file_log_level",
+	"miscellaneous.listen_port":        "listen_port",
+	"miscellaneous.listen_port_health": "listen_port_health",
+	"miscellaneous.public_ip_source":   "public_ip_source",
+	"miscellaneous.cpu_limit":          "cpu_limit",
+}
+
+// customizeDiffLocalConfig checks the local config settings against the constraints which Netagent enforces, so
+// that invalid values fail at plan time rather than on the Netagent hosts. Each error names the attribute it concerns.
+func customizeDiffLocalConfig(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	var errs []error
+	for _, violation := range expandAdvancedSettings(d).Violations() {
+		attribute, ok := localConfigAttributes[violation.Section+"."+violation.Parameter]
+		if !ok {
+			errs = append(errs, violation)
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", attribute, violation.Err))
+	}
+	return errors.Join(errs...)
+}
+
 func resourceAccessTierCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	clusterName, err := setAccessTierCluster(c, d)
+	clusterName, err := setAccessTierCluster(ctx, c, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	spec, err := c.AccessTier.Create(atFromState(d, clusterName))
+	spec, err := c.AccessTier.Create(ctx, atFromState(d, clusterName))
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	diagnostics = updateLocalConfig(d, c, spec)
+	diagnostics = updateLocalConfig(ctx, d, c, spec)
 	d.SetId(spec.ID)
+	// the tunnel details are generated on creation and are needed by the configuration which depends on them
+	err = flattenTunnelConfigSatellite(d, &spec)
+	if err != nil {
+		return append(diagnostics, diag.FromErr(err)...)
+	}
+	err = flattenTunnelConfigEndUser(d, &spec)
+	if err != nil {
+		return append(diagnostics, diag.FromErr(err)...)
+	}
+	if diagnostics.HasError() {
+		return
+	}
+	diagnostics = waitForNetagents(ctx, c, d)
 	return
 }
 
+// netagentActivityWindow is how recently a netagent must have been active to count as checked in
+const netagentActivityWindow = 5 * time.Minute
+
+// defaultNetagentsWaitTimeout is how long wait_for_netagents waits when wait_timeout is not set
+const defaultNetagentsWaitTimeout = 600 * time.Second
+
+// defaultAccessTierCreateTimeout is the create timeout when timeouts.create is not set
+const defaultAccessTierCreateTimeout = 30 * time.Minute
+
+// netagentsWaitTimeout returns the wait_timeout in seconds as a duration, or its default when it is not set
+func netagentsWaitTimeout(seconds int) time.Duration {
+	if seconds == 0 {
+		return defaultNetagentsWaitTimeout
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// customizeDiffWaitTimeout checks that wait_timeout is shorter than the create timeout that Create runs under, so
+// that the plan fails rather than the apply after the access tier has been created
+func customizeDiffWaitTimeout(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Get("wait_for_netagents").(int) == 0 || !d.NewValueKnown("wait_timeout") {
+		return nil
+	}
+	timeout := netagentsWaitTimeout(d.Get("wait_timeout").(int))
+	create, ok := configuredCreateTimeout(d)
+	if !ok {
+		return nil
+	}
+	if timeout >= create {
+		return fmt.Errorf("wait_timeout: %s must be shorter than the create timeout of %s, raise timeouts.create", timeout, create)
+	}
+	return nil
+}
+
+// configuredCreateTimeout returns timeouts.create from the configuration, which ResourceDiff does not expose, or
+// its default when it is not set. It is not ok if the timeout is not known yet.
+func configuredCreateTimeout(d *schema.ResourceDiff) (timeout time.Duration, ok bool) {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(schema.TimeoutsConfigKey) {
+		return defaultAccessTierCreateTimeout, true
+	}
+	timeouts := config.GetAttr(schema.TimeoutsConfigKey)
+	if timeouts.IsNull() {
+		return defaultAccessTierCreateTimeout, true
+	}
+	if !timeouts.IsKnown() {
+		return 0, false
+	}
+	create := timeouts.GetAttr(schema.TimeoutCreate)
+	if create.IsNull() {
+		return defaultAccessTierCreateTimeout, true
+	}
+	if !create.IsKnown() {
+		return 0, false
+	}
+	// the SDK reports a create timeout which does not parse
+	timeout, err := time.ParseDuration(create.AsString())
+	return timeout, err == nil
+}
+
+// waitForNetagents polls the access tier until it reports wait_for_netagents healthy, recently active netagents
+func waitForNetagents(ctx context.Context, c *client.Holder, d *schema.ResourceData) (diagnostics diag.Diagnostics) {
+	want := d.Get("wait_for_netagents").(int)
+	if want == 0 {
+		return
+	}
+	timeout := netagentsWaitTimeout(d.Get("wait_timeout").(int))
+	var at accesstier.AccessTierInfo
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		at, err = c.AccessTier.Get(ctx, d.Id())
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		healthy := countHealthyNetagents(at, time.Now())
+		if healthy < want {
+			return retry.RetryableError(fmt.Errorf("%d of %d netagents healthy", healthy, want))
+		}
+		return nil
+	})
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("access tier %s did not report %d healthy netagents within %s", d.Get("name").(string), want, timeout),
+			Detail:   describeNetagents(at, err),
+		}}
+	}
+	return
+}
+
+// countHealthyNetagents counts the netagents which are healthy and were active within netagentActivityWindow,
+// provided the access tier itself is healthy
+func countHealthyNetagents(at accesstier.AccessTierInfo, now time.Time) (healthy int) {
+	status := accesstier.AccessTierStatus(at.Status)
+	if status != accesstier.Healthy && status != accesstier.PartiallyHealthy {
+		return 0
+	}
+	for _, n := range at.Netagents {
+		if n.Status != string(accesstier.Healthy) {
+			continue
+		}
+		lastActivity, ok := parseActivityTime(n.LastActivityAt)
+		if ok && now.Sub(lastActivity) <= netagentActivityWindow {
+			healthy++
+		}
+	}
+	return
+}
+
+// parseActivityTime parses a netagent activity time, which is either RFC3339 or a unix time in seconds or milliseconds
+func parseActivityTime(s string) (t time.Time, ok bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, true
+	}
+	unix, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return t, false
+	}
+	if unix > 1e12 {
+		return time.UnixMilli(unix), true
+	}
+	return time.Unix(unix, 0), true
+}
+
+// describeNetagents lists what the access tier last reported, for the diagnostic when waiting fails
+func describeNetagents(at accesstier.AccessTierInfo, err error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Last error: %s\nAccess tier status: %q\n", err, at.Status)
+	if len(at.Netagents) == 0 {
+		b.WriteString("No netagents have registered with the access tier")
+		return b.String()
+	}
+	b.WriteString("Netagents:")
+	for _, n := range at.Netagents {
+		lastActivity := n.LastActivityAt
+		if lastActivity == "" {
+			lastActivity = "never"
+		}
+		fmt.Fprintf(&b, "\n  %s (version %q): status %q, last active %s", n.Hostname, n.Version, n.Status, lastActivity)
+	}
+	return b.String()
+}
+
 // automatically set the cluster unless it is specified
-func setAccessTierCluster(c *client.Holder, d *schema.ResourceData) (clusterName string, err error) {
+func setAccessTierCluster(ctx context.Context, c *client.Holder, d *schema.ResourceData) (clusterName string, err error) {
 	_, ok := d.GetOk("cluster")
 	if !ok {
-		clusterName, err = getFirstCluster(c)
+		clusterName, err = getFirstCluster(ctx, c)
 		return
 	}
 	clusterName = d.Get("cluster").(string)
-	clusters, err := c.Shield.GetAll()
+	clusters, err := c.Shield.GetAll(ctx)
 	if err != nil {
 		return
 	}
@@ -361,10 +633,9 @@ func setAccessTierCluster(c *client.Holder, d *schema.ResourceData) (clusterName
 
 func resourceAccessTierRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	at, err := c.AccessTier.Get(d.Id())
+	at, err := c.AccessTier.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	d.SetId(at.ID)
 	// we do not read the cluster
@@ -404,7 +675,7 @@ func resourceAccessTierRead(ctx context.Context, d *schema.ResourceData, m inter
 	}
 
 	// Now get the local config
-	atLocalConfig, err := c.AccessTier.GetLocalConfig(at.Name)
+	atLocalConfig, err := c.AccessTier.GetLocalConfig(ctx, at.Name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -424,10 +695,18 @@ func resourceAccessTierRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = flattenDoSProtectionParameters(d, atLocalConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = flattenDebuggingParameters(d, atLocalConfig)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = flattenMiscellaneousParameters(d, atLocalConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	return
 }
 
@@ -449,16 +728,16 @@ func atFromState(d *schema.ResourceData, clusterName string) (accessTier accesst
 
 func resourceAccessTierUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	clusterName, err := setAccessTierCluster(c, d)
+	clusterName, err := setAccessTierCluster(ctx, c, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	spec := atFromState(d, clusterName)
-	updated, err := c.AccessTier.Update(d.Id(), spec)
+	updated, err := c.AccessTier.Update(ctx, d.Id(), spec)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	diagnostics = updateLocalConfig(d, c, updated)
+	diagnostics = updateLocalConfig(ctx, d, c, updated)
 	d.SetId(updated.ID)
 	return
 }
@@ -466,9 +745,9 @@ func resourceAccessTierUpdate(ctx context.Context, d *schema.ResourceData, m int
 func resourceAccessTierDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 	err := retry.RetryContext(ctx, 180*time.Second, func() *retry.RetryError {
-		err := c.AccessTier.Delete(d.Id())
+		err := c.AccessTier.Delete(ctx, d.Id())
 		if err != nil {
-			if err.Error() == "access_tier not found" {
+			if restclient.IsNotFound(err) {
 				return nil
 			}
 			return retry.RetryableError(err)
@@ -505,7 +784,7 @@ func expandTunnelConfigEndUser(d *schema.ResourceData) (expanded *accesstier.Acc
 	return &e
 }
 
-func expandLogging(d *schema.ResourceData) (expanded *accesstier.LoggingParameters) {
+func expandLogging(d schemaGetter) (expanded *accesstier.LoggingParameters) {
 	var statsd *bool
 	statsdAddress := GetStringPtr(d, "statsd_address")
 	if statsdAddress != nil {
@@ -524,15 +803,19 @@ func expandLogging(d *schema.ResourceData) (expanded *accesstier.LoggingParamete
 	return &e
 }
 
-func expandEventParameters(d *schema.ResourceData) (expanded *accesstier.EventParameters) {
+func expandEventParameters(d schemaGetter) (expanded *accesstier.EventParameters) {
 	e := accesstier.EventParameters{
-		CreditsLimiting: GetBoolPtr(d, "events_rate_limiting"),
-		KeyLimiting:     GetBoolPtr(d, "event_key_rate_limiting"),
+		CreditsLimiting:    GetBoolPtr(d, "events_rate_limiting"),
+		CreditsPerInterval: GetIntPtr(d, "access_event_credits_per_interval"),
+		CreditsInterval:    GetIntPtr(d, "access_event_credits_interval"),
+		CreditsMax:         GetIntPtr(d, "access_event_credits_max"),
+		KeyLimiting:        GetBoolPtr(d, "event_key_rate_limiting"),
+		KeyExpiration:      GetIntPtr(d, "access_event_key_expiration"),
 	}
 	return &e
 }
 
-func expandHostedWebServices(d *schema.ResourceData) (expanded *accesstier.HostedWebServiceParameters) {
+func expandHostedWebServices(d schemaGetter) (expanded *accesstier.HostedWebServiceParameters) {
 	e := accesstier.HostedWebServiceParameters{
 		ForwardTrustCookie: GetBoolPtr(d, "forward_trust_cookie"),
 		DisableHSTS:        GetBoolPtr(d, "enable_hsts"),
@@ -540,14 +823,43 @@ func expandHostedWebServices(d *schema.ResourceData) (expanded *accesstier.Hoste
 	return &e
 }
 
-func expandInfrastructureService(d *schema.ResourceData) (expanded *accesstier.InfrastructureServiceParameters) {
+func expandInfrastructureService(d schemaGetter) (expanded *accesstier.InfrastructureServiceParameters) {
 	e := accesstier.InfrastructureServiceParameters{
 		MaximumSessionTimeout: GetIntPtr(d, "infra_maximum_session_timeout"),
 	}
 	return &e
 }
 
-func expandDebugging(d *schema.ResourceData) (expanded *accesstier.DebuggingParameters) {
+func expandDoSProtection(d schemaGetter) (expanded *accesstier.DoSProtectionParameters) {
+	e := accesstier.DoSProtectionParameters{
+		BadActor:        GetBoolPtr(d, "bad_actor"),
+		InfractionCount: GetIntPtr(d, "infraction_count"),
+		SentenceTime:    GetIntPtr(d, "sentence_time"),
+	}
+	return &e
+}
+
+func expandMiscellaneous(d schemaGetter) (expanded *accesstier.MiscellaneousParameters) {
+	e := accesstier.MiscellaneousParameters{
+		ListenPort:        GetIntPtr(d, "listen_port"),
+		ListenPortHealth:  GetIntPtr(d, "listen_port_health"),
+		HTTPSProxy:        GetStringPtr(d, "https_proxy"),
+		PublicIPSource:    GetStringPtr(d, "public_ip_source"),
+		CPULimit:          GetIntPtr(d, "cpu_limit"),
+		UserModeTunnel:    GetBoolPtr(d, "user_mode_tunnel"),
+		EnduserTunnelCIDR: GetStringPtr(d, "enduser_tunnel_cidr"),
+	}
+	hostTags := d.Get("host_tags").(map[string]interface{})
+	if len(hostTags) > 0 {
+		e.HostTags = make(map[string]string, len(hostTags))
+		for k, v := range hostTags {
+			e.HostTags[k] = v.(string)
+		}
+	}
+	return &e
+}
+
+func expandDebugging(d schemaGetter) (expanded *accesstier.DebuggingParameters) {
 	e := accesstier.DebuggingParameters{
 		HTTPBackendLog:      GetBoolPtr(d, "debug_http_backend_log"),
 		VisibilityOnly:      GetBoolPtr(d, "debug_visibility_only"),
@@ -683,6 +995,80 @@ func flattenEventParameters(d *schema.ResourceData, atLocalConfig accesstier.Acc
 	if err != nil {
 		return
 	}
+	err = d.Set("access_event_credits_per_interval", atLocalConfig.EventParameters.CreditsPerInterval)
+	if err != nil {
+		return
+	}
+	err = d.Set("access_event_credits_interval", atLocalConfig.EventParameters.CreditsInterval)
+	if err != nil {
+		return
+	}
+	err = d.Set("access_event_credits_max", atLocalConfig.EventParameters.CreditsMax)
+	if err != nil {
+		return
+	}
+	err = d.Set("access_event_key_expiration", atLocalConfig.EventParameters.KeyExpiration)
+	if err != nil {
+		return
+	}
+	return
+}
+
+func flattenDoSProtectionParameters(d *schema.ResourceData, atLocalConfig accesstier.AccessTierLocalConfig) (err error) {
+	if isNil(atLocalConfig.DoSProtectionParameters) {
+		return
+	}
+	err = d.Set("bad_actor", atLocalConfig.DoSProtectionParameters.BadActor)
+	if err != nil {
+		return
+	}
+	err = d.Set("infraction_count", atLocalConfig.DoSProtectionParameters.InfractionCount)
+	if err != nil {
+		return
+	}
+	err = d.Set("sentence_time", atLocalConfig.DoSProtectionParameters.SentenceTime)
+	if err != nil {
+		return
+	}
+	return
+}
+
+func flattenMiscellaneousParameters(d *schema.ResourceData, atLocalConfig accesstier.AccessTierLocalConfig) (err error) {
+	if isNil(atLocalConfig.MiscellaneousParameters) {
+		return
+	}
+	err = d.Set("host_tags", atLocalConfig.MiscellaneousParameters.HostTags)
+	if err != nil {
+		return
+	}
+	err = d.Set("listen_port", atLocalConfig.MiscellaneousParameters.ListenPort)
+	if err != nil {
+		return
+	}
+	err = d.Set("listen_port_health", atLocalConfig.MiscellaneousParameters.ListenPortHealth)
+	if err != nil {
+		return
+	}
+	err = d.Set("https_proxy", atLocalConfig.MiscellaneousParameters.HTTPSProxy)
+	if err != nil {
+		return
+	}
+	err = d.Set("public_ip_source", atLocalConfig.MiscellaneousParameters.PublicIPSource)
+	if err != nil {
+		return
+	}
+	err = d.Set("cpu_limit", atLocalConfig.MiscellaneousParameters.CPULimit)
+	if err != nil {
+		return
+	}
+	err = d.Set("user_mode_tunnel", atLocalConfig.MiscellaneousParameters.UserModeTunnel)
+	if err != nil {
+		return
+	}
+	err = d.Set("enduser_tunnel_cidr", atLocalConfig.MiscellaneousParameters.EnduserTunnelCIDR)
+	if err != nil {
+		return
+	}
 	return
 }
 
@@ -733,6 +1119,10 @@ func flattenLoggingParameters(d *schema.ResourceData, atLocalConfig accesstier.A
 }
 
 func flattenTunnelConfigSatellite(d *schema.ResourceData, at *accesstier.AccessTierInfo) (err error) {
+	err = d.Set("tunnel_satellite", flattenAccessTierTunnelData(at.TunnelSatellite))
+	if err != nil {
+		return
+	}
 	if isNil(at.TunnelSatellite) {
 		return
 	}
@@ -744,6 +1134,10 @@ func flattenTunnelConfigSatellite(d *schema.ResourceData, at *accesstier.AccessT
 }
 
 func flattenTunnelConfigEndUser(d *schema.ResourceData, at *accesstier.AccessTierInfo) (err error) {
+	err = d.Set("tunnel_enduser", flattenAccessTierTunnelData(at.TunnelEnduser))
+	if err != nil {
+		return
+	}
 	if isNil(at.TunnelEnduser) {
 		return
 	}
@@ -20,9 +20,10 @@ func resourceAccessTierGroup() *schema.Resource {
 		ReadContext:   resourceAccessTierGroupRead,
 		DeleteContext: resourceAccessTierGroupDelete,
 		UpdateContext: resourceAccessTierGroupUpdate,
+		CustomizeDiff: customizeDiffLocalConfig,
 		Schema:        AccessTierGroupSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("access tier group", accessTierGroupIDsByName),
 		},
 	}
 }
@@ -149,6 +150,26 @@ func AccessTierGroupSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Enable rate limiting of Access Event generation based on a credit-based rate control mechanism",
 		},
+		"access_event_credits_per_interval": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Number of access event credits to assign after each interval",
+		},
+		"access_event_credits_interval": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Interval in seconds after which access event credits are assigned",
+		},
+		"access_event_credits_max": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Maximum number of access event credits. One access event consumes one credit",
+		},
+		"access_event_key_expiration": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Interval in seconds after which another access event may be generated for the same access key",
+		},
 		"forward_trust_cookie": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -164,6 +185,69 @@ func AccessTierGroupSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Timeout in seconds infrastructure sessions connected via the access tier",
 		},
+		"bad_actor": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Enable denial of service protection, which jails IP addresses making too many unauthorized requests",
+		},
+		"infraction_count": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Number of unauthorized requests before an offending IP address is jailed",
+		},
+		"sentence_time": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Time in seconds after which a jailed IP address is freed",
+		},
+		"host_tags": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Key-value pairs used for attribute matching on Netagent",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"listen_port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "TCP port on which Netagent listens for incoming connections, from 1024 to 65535",
+			ValidateFunc: validation.IntBetween(1024, 65535),
+		},
+		"listen_port_health": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "TCP port on which Netagent listens for health checks, from 1024 to 65535",
+			ValidateFunc: validation.IntBetween(1024, 65535),
+		},
+		"https_proxy": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "HTTP CONNECT proxy used for the control connection to Shield. Overrides the HTTPS_PROXY environment variable",
+		},
+		"public_ip_source": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "How Netagent determines its public IP. Must be one of \"AWS\", \"GCE\", \"default\", \"none\"",
+			ValidateFunc: validation.StringInSlice([]string{"AWS", "GCE", "default", "none"}, false),
+		},
+		"cpu_limit": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Maximum percentage of CPU core usage, from 1 to 100",
+			ValidateFunc: validation.IntBetween(1, 100),
+		},
+		"user_mode_tunnel": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Use a userspace WireGuard module instead of the kernel module",
+		},
+		"enduser_tunnel_cidr": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "CIDR range from which end user tunnel addresses are assigned, for source NAT",
+			ValidateFunc: validateCIDR(),
+		},
 		"debug_http_backend_log": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -286,7 +370,7 @@ func AccessTierGroupSchema() map[string]*schema.Schema {
 
 func resourceAccessTierGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	atg, err := c.AccessTierGroup.Create(atgFromState(d))
+	atg, err := c.AccessTierGroup.Create(ctx, atgFromState(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -295,7 +379,7 @@ func resourceAccessTierGroupCreate(ctx context.Context, d *schema.ResourceData,
 
 	attachIDs := convertSchemaSetToStringSlice(d.Get("attach_access_tier_ids").(*schema.Set))
 	if len(attachIDs) != 0 {
-		err = attachAccessTiers(c, d.Get("id").(string), attachIDs)
+		err = attachAccessTiers(ctx, c, d.Get("id").(string), attachIDs)
 		if err != nil {
 			return
 		}
@@ -306,10 +390,9 @@ func resourceAccessTierGroupCreate(ctx context.Context, d *schema.ResourceData,
 
 func resourceAccessTierGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	key, err := c.AccessTierGroup.Get(d.Id())
+	key, err := c.AccessTierGroup.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	d.SetId(key.ID)
 	err = d.Set("name", key.Name)
@@ -368,24 +451,32 @@ func resourceAccessTierGroupRead(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = flattenDoSProtectionParameters(d, advancedSettings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = flattenDebuggingParameters(d, advancedSettings)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = flattenMiscellaneousParameters(d, advancedSettings)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return
 }
 
 func resourceAccessTierGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	_, err := c.AccessTierGroup.Update(d.Id(), atgFromState(d))
+	_, err := c.AccessTierGroup.Update(ctx, d.Id(), atgFromState(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	attachIDs := convertSchemaSetToStringSlice(d.Get("attach_access_tier_ids").(*schema.Set))
 	if len(attachIDs) != 0 {
-		err = attachAccessTiers(c, d.Get("id").(string), attachIDs)
+		err = attachAccessTiers(ctx, c, d.Get("id").(string), attachIDs)
 		if err != nil {
 			return
 		}
@@ -393,7 +484,7 @@ func resourceAccessTierGroupUpdate(ctx context.Context, d *schema.ResourceData,
 
 	detachIDs := convertSchemaSetToStringSlice(d.Get("detach_access_tier_ids").(*schema.Set))
 	if len(detachIDs) != 0 {
-		err = detachAccessTiers(c, d.Get("id").(string), detachIDs)
+		err = detachAccessTiers(ctx, c, d.Get("id").(string), detachIDs)
 		if err != nil {
 			return
 		}
@@ -404,7 +495,7 @@ func resourceAccessTierGroupUpdate(ctx context.Context, d *schema.ResourceData,
 
 func resourceAccessTierGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	err := c.AccessTierGroup.Delete(d.Id())
+	err := c.AccessTierGroup.Delete(ctx, d.Id())
 	if err != nil {
 		diagnostics = diag.FromErr(err)
 		return
@@ -439,12 +530,12 @@ func setATGTunnelConfigEndUserRequest(d *schema.ResourceData) (expanded *accesst
 	return &e
 }
 
-func attachAccessTiers(c *client.Holder, atgID string, atIDs []string) (err error) {
+func attachAccessTiers(ctx context.Context, c *client.Holder, atgID string, atIDs []string) (err error) {
 
 	attachReqBody := accesstiergroup.AccessTierList{
 		AccessTierIDs: atIDs,
 	}
-	_, err = c.AccessTierGroup.AttachAccessTiers(atgID, attachReqBody)
+	_, err = c.AccessTierGroup.AttachAccessTiers(ctx, atgID, attachReqBody)
 	if err != nil {
 		return
 	}
@@ -452,11 +543,11 @@ func attachAccessTiers(c *client.Holder, atgID string, atIDs []string) (err erro
 	return
 }
 
-func detachAccessTiers(c *client.Holder, atgID string, atIDs []string) (err error) {
+func detachAccessTiers(ctx context.Context, c *client.Holder, atgID string, atIDs []string) (err error) {
 	attachReqBody := accesstiergroup.AccessTierList{
 		AccessTierIDs: atIDs,
 	}
-	_, err = c.AccessTierGroup.DetachAccessTiers(atgID, attachReqBody)
+	_, err = c.AccessTierGroup.DetachAccessTiers(ctx, atgID, attachReqBody)
 	if err != nil {
 		return
 	}
@@ -464,13 +555,16 @@ func detachAccessTiers(c *client.Holder, atgID string, atIDs []string) (err erro
 	return
 }
 
-func expandAdvancedSettings(d *schema.ResourceData) accesstier.AccessTierLocalConfig {
+// expandAdvancedSettings builds the local config of an access tier or access tier group, without base parameters
+func expandAdvancedSettings(d schemaGetter) accesstier.AccessTierLocalConfig {
 	lc := accesstier.AccessTierLocalConfig{
 		LoggingParameters:               expandLogging(d),
 		EventParameters:                 expandEventParameters(d),
 		HostedWebServiceParameters:      expandHostedWebServices(d),
 		InfrastructureServiceParameters: expandInfrastructureService(d),
+		DoSProtectionParameters:         expandDoSProtection(d),
 		DebuggingParameters:             expandDebugging(d),
+		MiscellaneousParameters:         expandMiscellaneous(d),
 	}
 
 	return lc
@@ -1,11 +1,18 @@
 package banyan
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/fakeapi"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccAccessTierGroup_basic(t *testing.T) {
@@ -44,3 +51,35 @@ resource "banyan_accesstier_group" "example" {
 }
 `, name)
 }
+
+func Test_accessTierGroupHardeningSettings(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourceAccessTierGroup()
+	raw := map[string]interface{}{
+		"name":               "hardened",
+		"cluster":            fakeapi.DefaultCluster,
+		"dns_search_domains": "",
+		"cidrs":              []interface{}{"10.10.0.0/16"},
+		"udp_port_number":    51820,
+		"keepalive":          20,
+		"domains":            []interface{}{"corp.com"},
+		"shared_fqdn":        "hardened.corp.com",
+	}
+	for k, v := range hardeningSettings {
+		raw[k] = v
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId(d.Id())
+	require.Empty(t, r.ReadContext(ctx, imported, c))
+	atg, err := c.AccessTierGroup.Get(ctx, d.Id())
+	require.NoError(t, err)
+	var lc accesstier.AccessTierLocalConfig
+	require.NoError(t, json.Unmarshal([]byte(atg.AdvancedSettings), &lc))
+	assertHardeningSettings(t, lc, imported)
+}
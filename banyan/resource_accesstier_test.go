@@ -1,13 +1,21 @@
 package banyan
 
 import (
+	"context"
 	"fmt"
+	"testing"
+	"time"
+
 	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/fakeapi"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"github.com/stretchr/testify/require"
 )
 
 // The required test is used to test the lifecycle of a resource with only the required parameters set
@@ -158,7 +166,7 @@ func testAccCheckExistingAccessTier(resourceName string, bnnAccessTier *accessti
 	if !ok {
 		return fmt.Errorf("resource not found in state %s", resourceName)
 	}
-	resp, err := testAccClient.AccessTier.Get(rs.Primary.ID)
+	resp, err := testAccClient.AccessTier.Get(context.Background(), rs.Primary.ID)
 	if err != nil {
 		return fmt.Errorf("could not get resource from API %s id: %s", resourceName, rs.Primary.ID)
 	}
@@ -174,8 +182,229 @@ func testAccCheckAccessTierDestroy(t *testing.T, resourceName string) resource.T
 		if !ok {
 			return fmt.Errorf("resource not found in state %q", rs)
 		}
-		r, _ := testAccClient.AccessTier.Get(resourceName)
+		r, _ := testAccClient.AccessTier.Get(context.Background(), resourceName)
 		assert.Equal(t, r, emptyAccessTier)
 		return nil
 	}
 }
+
+// hardeningSettings are the denial of service, miscellaneous and event credit settings shared by the access tier
+// and access tier group resources
+var hardeningSettings = map[string]interface{}{
+	"bad_actor":                         true,
+	"infraction_count":                  5,
+	"sentence_time":                     1200,
+	"host_tags":                         map[string]interface{}{"env": "prod"},
+	"listen_port":                       8443,
+	"listen_port_health":                8444,
+	"https_proxy":                       "proxy.corp.com:3128",
+	"public_ip_source":                  "AWS",
+	"cpu_limit":                         50,
+	"user_mode_tunnel":                  true,
+	"enduser_tunnel_cidr":               "100.96.0.0/11",
+	"access_event_credits_per_interval": 10,
+	"access_event_credits_interval":     30,
+	"access_event_credits_max":          1000,
+	"access_event_key_expiration":       600,
+}
+
+func assertHardeningSettings(t *testing.T, lc accesstier.AccessTierLocalConfig, d *schema.ResourceData) {
+	require.NotNil(t, lc.DoSProtectionParameters)
+	assert.True(t, *lc.BadActor)
+	assert.Equal(t, 5, *lc.InfractionCount)
+	assert.Equal(t, 1200, *lc.SentenceTime)
+	require.NotNil(t, lc.MiscellaneousParameters)
+	assert.Equal(t, map[string]string{"env": "prod"}, lc.HostTags)
+	assert.Equal(t, 8443, *lc.ListenPort)
+	assert.Equal(t, 8444, *lc.ListenPortHealth)
+	assert.Equal(t, "proxy.corp.com:3128", *lc.HTTPSProxy)
+	assert.Equal(t, "AWS", *lc.PublicIPSource)
+	assert.Equal(t, 50, *lc.CPULimit)
+	assert.True(t, *lc.UserModeTunnel)
+	assert.Equal(t, "100.96.0.0/11", *lc.EnduserTunnelCIDR)
+	require.NotNil(t, lc.EventParameters)
+	assert.Equal(t, 10, *lc.CreditsPerInterval)
+	assert.Equal(t, 30, *lc.CreditsInterval)
+	assert.Equal(t, 1000, *lc.CreditsMax)
+	assert.Equal(t, 600, *lc.KeyExpiration)
+
+	for k, v := range hardeningSettings {
+		assert.Equal(t, v, d.Get(k), k)
+	}
+}
+
+func Test_accessTierHardeningSettings(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourceAccessTier()
+	raw := map[string]interface{}{
+		"name":       "hardened",
+		"address":    "*.example.com",
+		"api_key_id": "fake-api-key-id",
+	}
+	for k, v := range hardeningSettings {
+		raw[k] = v
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId(d.Id())
+	require.Empty(t, r.ReadContext(ctx, imported, c))
+	lc, err := c.AccessTier.GetLocalConfig(ctx, "hardened")
+	require.NoError(t, err)
+	assertHardeningSettings(t, lc, imported)
+	require.NotNil(t, lc.AccessTier, "the access tier mode is kept")
+	assert.True(t, *lc.AccessTier)
+}
+
+func Test_customizeDiffLocalConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  map[string]interface{}
+		err  string
+	}{
+		{name: "valid", raw: map[string]interface{}{"listen_port": 9999, "cpu_limit": 50}},
+		{name: "invalid log level", raw: map[string]interface{}{"console_log_level": "TRACE"}, err: "console_log_level: TRACE must be one of ERR, WARN, INFO, DEBUG"},
+		{name: "invalid port", raw: map[string]interface{}{"listen_port_health": 443}, err: "listen_port_health: 443 must be between 1024 and 65535"},
+		{name: "every attribute", raw: map[string]interface{}{
+			"console_log_level":  "TRACE",
+			"file_log_level":     "TRACE",
+			"listen_port":        80,
+			"listen_port_health": 80,
+			"public_ip_source":   "azure",
+			"cpu_limit":          200,
+		}, err: `console_log_level: TRACE must be one of ERR, WARN, INFO, DEBUG
+file_log_level: TRACE must be one of ERR, WARN, INFO, DEBUG
+listen_port: 80 must be between 1024 and 65535
+listen_port_health: 80 must be between 1024 and 65535
+public_ip_source: azure must be one of AWS, GCE, default, none
+cpu_limit: 200 must be between 1 and 100`},
+	}
+	for _, r := range []*schema.Resource{resourceAccessTier(), resourceAccessTierGroup()} {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), nil)
+				if tt.err == "" {
+					assert.NoError(t, err)
+					return
+				}
+				assert.EqualError(t, err, tt.err)
+			})
+		}
+	}
+}
+
+func Test_waitForNetagents(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourceAccessTier()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":               "waiting",
+		"address":            "*.example.com",
+		"api_key_id":         "fake-api-key-id",
+		"wait_for_netagents": 2,
+		"wait_timeout":       1,
+	})
+	at, err := c.AccessTier.Create(ctx, atFromState(d, fakeapi.DefaultCluster))
+	require.NoError(t, err)
+	d.SetId(at.ID)
+
+	recent := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	stale := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	require.NoError(t, srv.SetNetagents(at.ID, []accesstier.NetagentHostInfo{
+		{HostInfo: accesstier.HostInfo{Hostname: "netagent-1"}, Version: "2.5.0", Status: "Healthy", LastActivityAt: recent},
+		{HostInfo: accesstier.HostInfo{Hostname: "netagent-2"}, Version: "2.5.0", Status: "Healthy", LastActivityAt: stale},
+		{HostInfo: accesstier.HostInfo{Hostname: "netagent-3"}, Version: "2.5.0", Status: "Unhealthy", LastActivityAt: recent},
+	}))
+	diags := waitForNetagents(ctx, c, d)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "access tier waiting did not report 2 healthy netagents within 1s", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, `Access tier status: "PartiallyHealthy"`)
+	assert.Contains(t, diags[0].Detail, fmt.Sprintf(`netagent-2 (version "2.5.0"): status "Healthy", last active %s`, stale))
+	assert.Contains(t, diags[0].Detail, `netagent-3 (version "2.5.0"): status "Unhealthy"`)
+
+	require.NoError(t, srv.SetNetagents(at.ID, []accesstier.NetagentHostInfo{
+		{HostInfo: accesstier.HostInfo{Hostname: "netagent-1"}, Status: "Healthy", LastActivityAt: recent},
+		{HostInfo: accesstier.HostInfo{Hostname: "netagent-2"}, Status: "Healthy", LastActivityAt: fmt.Sprint(time.Now().UnixMilli())},
+	}))
+	assert.Empty(t, waitForNetagents(ctx, c, d))
+}
+
+func Test_waitTimeoutExceedsCreateTimeout(t *testing.T) {
+	r := resourceAccessTier()
+	raw := map[string]interface{}{
+		"name":               "waiting",
+		"address":            "*.example.com",
+		"api_key_id":         "fake-api-key-id",
+		"wait_for_netagents": 1,
+		"wait_timeout":       3600,
+	}
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	assert.EqualError(t, err, "wait_timeout: 1h0m0s must be shorter than the create timeout of 30m0s, raise timeouts.create")
+
+	// the create timeout is only available from the raw configuration, which Terraform sends with the state
+	state := &terraform.InstanceState{RawConfig: cty.ObjectVal(map[string]cty.Value{
+		"timeouts": cty.ObjectVal(map[string]cty.Value{"create": cty.StringVal("2h")}),
+	})}
+	_, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+	assert.NoError(t, err)
+
+	raw["wait_for_netagents"] = 0
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+	assert.NoError(t, err, "wait_timeout is only checked when waiting")
+}
+
+func Test_countHealthyNetagents(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	healthy := func(lastActivity string) accesstier.NetagentHostInfo {
+		return accesstier.NetagentHostInfo{Status: "Healthy", LastActivityAt: lastActivity}
+	}
+	at := accesstier.AccessTierInfo{Status: "Healthy", Netagents: []accesstier.NetagentHostInfo{
+		healthy("2026-01-01T11:58:00Z"),
+		healthy(fmt.Sprint(now.Add(-time.Minute).Unix())),
+		healthy(fmt.Sprint(now.Add(-time.Minute).UnixMilli())),
+		healthy("2026-01-01T11:00:00Z"),
+		healthy(""),
+		healthy("yesterday"),
+		{Status: "Unhealthy", LastActivityAt: "2026-01-01T11:59:00Z"},
+	}}
+	assert.Equal(t, 3, countHealthyNetagents(at, now))
+	at.Status = "Pending"
+	assert.Equal(t, 0, countHealthyNetagents(at, now))
+}
+
+func Test_waitForNetagentsDisabled(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAccessTier().Schema, map[string]interface{}{})
+	assert.Empty(t, waitForNetagents(context.Background(), nil, d))
+}
+
+func Test_accessTierTunnelDetails(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourceAccessTier()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                  "tunnels",
+		"address":               "*.example.com",
+		"api_key_id":            "fake-api-key-id",
+		"tunnel_connector_port": 39103,
+		"tunnel_cidrs":          []interface{}{"10.10.0.0/16"},
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	assert.Equal(t, 1, d.Get("tunnel_satellite.#"))
+	assert.Equal(t, 39103, d.Get("tunnel_satellite.0.udp_port_number"))
+	assert.Equal(t, "fakeapi-wireguard-public-key", d.Get("tunnel_satellite.0.wireguard_public_key"))
+	assert.Equal(t, 1, d.Get("tunnel_enduser.#"))
+	assert.Equal(t, 51820, d.Get("tunnel_enduser.0.udp_port_number"))
+	assert.Equal(t, []interface{}{"10.10.0.0/16"}, d.Get("tunnel_enduser.0.cidrs"))
+	for k, v := range d.State().Attributes {
+		assert.NotContains(t, v, "private-key", k)
+	}
+}
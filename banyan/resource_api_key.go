@@ -55,7 +55,7 @@ func resourceApiKey() *schema.Resource {
 
 func resourceApiKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	key, err := c.ApiKey.Create(apikey.Post{
+	key, err := c.ApiKey.Create(ctx, apikey.Post{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		Scope:       d.Get("scope").(string),
@@ -73,7 +73,7 @@ func resourceApiKeyCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceApiKeyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	_, err := c.ApiKey.Update(d.Id(), apikey.Post{
+	_, err := c.ApiKey.Update(ctx, d.Id(), apikey.Post{
 		Name:        d.Get("name").(string),
 		Description: d.Get("description").(string),
 		Scope:       d.Get("scope").(string),
@@ -86,10 +86,9 @@ func resourceApiKeyUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 
 func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	key, err := c.ApiKey.Get(d.Id())
+	key, err := c.ApiKey.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	d.SetId(key.ID)
 	err = d.Set("name", key.Name)
@@ -113,7 +112,7 @@ func resourceApiKeyRead(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceApiKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	err := c.ApiKey.Delete(d.Id())
+	err := c.ApiKey.Delete(ctx, d.Id())
 	if err != nil {
 		diagnostics = diag.FromErr(err)
 		return
@@ -42,7 +42,7 @@ func AppConfigSchema() map[string]*schema.Schema {
 
 func resourceAppConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	appConfig, err := c.AppConfig.Create(appConfigFromState(d))
+	appConfig, err := c.AppConfig.Create(ctx, appConfigFromState(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -54,10 +54,9 @@ func resourceAppConfigCreate(ctx context.Context, d *schema.ResourceData, m inte
 
 func resourceAppConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	key, err := c.AppConfig.Get(d.Id())
+	key, err := c.AppConfig.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 
 	d.SetId(key.Data.ID)
@@ -72,7 +71,7 @@ func resourceAppConfigRead(ctx context.Context, d *schema.ResourceData, m interf
 
 func resourceAppConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	_, err := c.AppConfig.Update(appConfigFromState(d))
+	_, err := c.AppConfig.Update(ctx, appConfigFromState(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/banyansecurity/terraform-banyan-provider/client/satellite"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -22,7 +23,7 @@ func resourceConnector() *schema.Resource {
 		UpdateContext: resourceConnectorUpdate,
 		DeleteContext: resourceConnectorDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("connector", connectorIDsByName),
 		},
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -90,6 +91,60 @@ func resourceConnector() *schema.Resource {
 				Optional:    true,
 				Description: "Enables support for public IP addresses and allows more than 100 connectors per organization",
 			},
+			"tunnel_ip_address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "IP address of the connector within the tunnel",
+			},
+			"wireguard_public_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Wireguard public key of the connector",
+			},
+			"keepalive": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Keepalive interval of the tunnel in seconds",
+			},
+			"tunnel_peers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Access tiers the connector has established a tunnel with",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_tier_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the access tier",
+						},
+						"access_tier_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the access tier",
+						},
+						"wireguard_public_key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Wireguard public key of the access tier",
+						},
+						"endpoint": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Endpoint of the access tier which the connector dials out to",
+						},
+						"allowed_ips": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IPs which the connector routes to the access tier",
+						},
+						"healthy": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the tunnel with the access tier is healthy",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -131,7 +186,7 @@ func connectorFromState(d *schema.ResourceData) (info satellite.Info) {
 
 func resourceConnectorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	created, err := c.Satellite.Create(connectorFromState(d))
+	created, err := c.Satellite.Create(ctx, connectorFromState(d))
 	if err != nil {
 		return diag.FromErr(errors.WithMessage(err, "couldn't create new connector"))
 	}
@@ -142,10 +197,9 @@ func resourceConnectorCreate(ctx context.Context, d *schema.ResourceData, m inte
 
 func resourceConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	sat, err := c.Satellite.Get(d.Id())
+	sat, err := c.Satellite.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	d.SetId(sat.ID)
 	err = d.Set("name", sat.Name)
@@ -178,12 +232,16 @@ func resourceConnectorRead(ctx context.Context, d *schema.ResourceData, m interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = flattenConnectorTunnel(d, sat)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	return
 }
 
 func resourceConnectorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	_, err := c.Satellite.Update(d.Id(), connectorFromState(d))
+	_, err := c.Satellite.Update(ctx, d.Id(), connectorFromState(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -194,9 +252,9 @@ func resourceConnectorUpdate(ctx context.Context, d *schema.ResourceData, m inte
 func resourceConnectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 	err := retry.RetryContext(ctx, 180*time.Second, func() *retry.RetryError {
-		err := c.Satellite.Delete(d.Id())
+		err := c.Satellite.Delete(ctx, d.Id())
 		if err != nil {
-			if err.Error() == "connector not found" {
+			if restclient.IsNotFound(err) {
 				return nil
 			}
 			return retry.RetryableError(err)
@@ -229,3 +287,33 @@ func setExtendedNetworkAccess(d *schema.ResourceData, spec string) error {
 	err = d.Set("extended_network_access", specs.ExtendedNetworkAccess)
 	return err
 }
+
+// flattenConnectorTunnel sets the tunnel details of the connector. The private key is never stored in the state.
+func flattenConnectorTunnel(d *schema.ResourceData, sat satellite.SatelliteTunnelConfig) (err error) {
+	sat.Sanitize()
+	err = d.Set("tunnel_ip_address", sat.TunnelIPAddress)
+	if err != nil {
+		return
+	}
+	err = d.Set("wireguard_public_key", sat.WireguardPublicKey)
+	if err != nil {
+		return
+	}
+	err = d.Set("keepalive", sat.Keepalive)
+	if err != nil {
+		return
+	}
+	peers := make([]interface{}, 0, len(sat.AccessTiers))
+	for _, at := range sat.AccessTiers {
+		peers = append(peers, map[string]interface{}{
+			"access_tier_id":       at.AccessTierID,
+			"access_tier_name":     at.AccessTierName,
+			"wireguard_public_key": at.WireguardPublicKey,
+			"endpoint":             at.Endpoint,
+			"allowed_ips":          at.AllowedIPs,
+			"healthy":              at.Healthy != nil && *at.Healthy,
+		})
+	}
+	err = d.Set("tunnel_peers", peers)
+	return
+}
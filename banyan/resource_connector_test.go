@@ -1,14 +1,18 @@
 package banyan
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/satellite"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Use the terraform plugin sdk testing framework for example testing connector lifecycle
@@ -104,7 +108,7 @@ func testAccCheckExistingConnector(resourceName string, bnnConnector *satellite.
 		if !ok {
 			return fmt.Errorf("resource not found in state %q", rs)
 		}
-		resp, err := testAccClient.Satellite.Get(rs.Primary.ID)
+		resp, err := testAccClient.Satellite.Get(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return err
 		}
@@ -124,7 +128,7 @@ func testAccCheckConnectorDestroy(t *testing.T, resourceName string) resource.Te
 		if !ok {
 			return fmt.Errorf("resource not found in state %q", rs)
 		}
-		r, _ := testAccClient.Satellite.Get(rs.Primary.ID)
+		r, _ := testAccClient.Satellite.Get(context.Background(), rs.Primary.ID)
 		assert.Equal(t, r, emptyConnector)
 		return nil
 	}
@@ -208,3 +212,61 @@ func TestAccConnected_with_extended_network(t *testing.T) {
 		},
 	})
 }
+
+func Test_connectorTunnelDetails(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourceConnector()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":       "tunnels",
+		"api_key_id": "fake-api-key-id",
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+	assert.Equal(t, "100.64.0.1", d.Get("tunnel_ip_address"))
+	assert.Equal(t, "fakeapi-wireguard-public-key", d.Get("wireguard_public_key"))
+	assert.Equal(t, 0, d.Get("tunnel_peers.#"))
+
+	// peers are reported once the connector has dialed out to the access tiers
+	sat, err := c.Satellite.Get(ctx, d.Id())
+	require.NoError(t, err)
+	healthy := true
+	sat.Keepalive = 20
+	sat.AccessTiers = []satellite.AccessTier{
+		{
+			AccessTierID:       "at-1",
+			AccessTierName:     "us-west",
+			WireguardPublicKey: "at-public-key",
+			Endpoint:           "203.0.113.10:51821",
+			AllowedIPs:         "100.64.0.0/10",
+			Healthy:            &healthy,
+		},
+		{AccessTierID: "at-2", AccessTierName: "us-east"},
+	}
+	srv.AddSatellite(sat)
+	require.Empty(t, r.ReadContext(ctx, d, c))
+
+	assert.Equal(t, 20, d.Get("keepalive"))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"access_tier_id":       "at-1",
+			"access_tier_name":     "us-west",
+			"wireguard_public_key": "at-public-key",
+			"endpoint":             "203.0.113.10:51821",
+			"allowed_ips":          "100.64.0.0/10",
+			"healthy":              true,
+		},
+		map[string]interface{}{
+			"access_tier_id":       "at-2",
+			"access_tier_name":     "us-east",
+			"wireguard_public_key": "",
+			"endpoint":             "",
+			"allowed_ips":          "",
+			"healthy":              false,
+		},
+	}, d.Get("tunnel_peers"))
+	for k, v := range d.State().Attributes {
+		assert.NotContains(t, v, "private-key", k)
+	}
+}
@@ -0,0 +1,161 @@
+package banyan
+
+import (
+	"context"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/admin/orgidpconfig"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// orgIdpConfigID is the ID of the banyan_org_idp_config resource, there is only one IdP configuration per org
+const orgIdpConfigID = "singleton"
+
+func resourceOrgIdpConfig() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The org IdP config resource manages the identity provider which end users of the org authenticate with. There is only one IdP configuration per org; destroying this resource removes it from the terraform state but leaves the configuration in Banyan unchanged.",
+		CreateContext: resourceOrgIdpConfigCreate,
+		ReadContext:   resourceOrgIdpConfigRead,
+		UpdateContext: resourceOrgIdpConfigUpdate,
+		DeleteContext: resourceOrgIdpConfigDelete,
+		Schema:        OrgIdpConfigSchema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOrgIdpConfigImport,
+		},
+	}
+}
+
+func OrgIdpConfigSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the org IdP config, always \"singleton\"",
+		},
+		"idp_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the identity provider, e.g. \"Okta\", \"AzureAD\" or \"Google\"",
+		},
+		"idp_protocol": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "OIDC",
+			Description:  "Protocol used to authenticate with the identity provider, only \"OIDC\" is supported",
+			ValidateFunc: validation.StringInSlice([]string{"OIDC"}, false),
+		},
+		"redirect_url": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Redirect URL registered with the identity provider for Banyan",
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+		"issuer_url": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Issuer URL of the identity provider",
+			ValidateFunc: validation.IsURLWithHTTPS,
+		},
+		"client_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Client ID of the Banyan application in the identity provider",
+		},
+		"client_secret": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+			Description: "Client secret of the Banyan application in the identity provider",
+		},
+	}
+	return s
+}
+
+func resourceOrgIdpConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	err := c.Admin.OrgIdpConfig.CreateOrUpdate(ctx, orgIdpConfigFromState(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(orgIdpConfigID)
+	return resourceOrgIdpConfigRead(ctx, d, m)
+}
+
+func resourceOrgIdpConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	idp, err := c.Admin.OrgIdpConfig.Get(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(orgIdpConfigID)
+	err = d.Set("idp_name", idp.IdpName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("idp_protocol", idp.IdpProtocol)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("redirect_url", idp.IdpConfig.RedirectUrl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("issuer_url", idp.IdpConfig.IssuerUrl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("client_id", idp.IdpConfig.ClientId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// the client secret is only compared when the API returns it
+	if idp.IdpConfig.ClientSecret != "" {
+		err = d.Set("client_secret", idp.IdpConfig.ClientSecret)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return
+}
+
+func resourceOrgIdpConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	err := c.Admin.OrgIdpConfig.CreateOrUpdate(ctx, orgIdpConfigFromState(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceOrgIdpConfigRead(ctx, d, m)
+}
+
+// resourceOrgIdpConfigDelete only removes the resource from state, the org always has an IdP configuration
+func resourceOrgIdpConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	d.SetId("")
+	diagnostics = append(diagnostics, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "org IdP config removed from state only",
+		Detail:   "The IdP configuration of the org cannot be deleted and is left unchanged in Banyan",
+	})
+	return
+}
+
+// resourceOrgIdpConfigImport accepts any ID since there is only one IdP configuration per org
+func resourceOrgIdpConfigImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId(orgIdpConfigID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// creates an org IdP config from the terraform state
+func orgIdpConfigFromState(d *schema.ResourceData) orgidpconfig.Spec {
+	return orgidpconfig.Spec{
+		IdpName:     d.Get("idp_name").(string),
+		IdpProtocol: d.Get("idp_protocol").(string),
+		IdpConfig: orgidpconfig.IdpConfig{
+			RedirectUrl:  d.Get("redirect_url").(string),
+			IssuerUrl:    d.Get("issuer_url").(string),
+			ClientId:     d.Get("client_id").(string),
+			ClientSecret: d.Get("client_secret").(string),
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_orgIdpConfigLifecycle(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourceOrgIdpConfig()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"idp_name":      "Okta",
+		"redirect_url":  "https://net.banyanops.com/v2/callback",
+		"issuer_url":    "https://example.okta.com",
+		"client_id":     "client-id",
+		"client_secret": "client-secret",
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+	assert.Equal(t, orgIdpConfigID, d.Id())
+
+	idp, err := c.Admin.OrgIdpConfig.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Okta", idp.IdpName)
+	assert.Equal(t, "OIDC", idp.IdpProtocol)
+	assert.Equal(t, "client-secret", idp.IdpConfig.ClientSecret)
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId("anything")
+	states, err := r.Importer.StateContext(ctx, imported, c)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	require.Empty(t, r.ReadContext(ctx, states[0], c))
+	for _, k := range []string{"idp_name", "idp_protocol", "redirect_url", "issuer_url", "client_id", "client_secret"} {
+		assert.Equal(t, d.Get(k), states[0].Get(k), k)
+	}
+
+	require.NoError(t, d.Set("issuer_url", "https://other.okta.com"))
+	require.NoError(t, d.Set("client_secret", "rotated-secret"))
+	require.Empty(t, r.UpdateContext(ctx, d, c))
+	idp, err = c.Admin.OrgIdpConfig.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "https://other.okta.com", idp.IdpConfig.IssuerUrl)
+	assert.Equal(t, "rotated-secret", idp.IdpConfig.ClientSecret)
+	assert.Equal(t, "https://other.okta.com", d.Get("issuer_url"))
+}
+
+func Test_orgIdpConfigCreateError(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+
+	r := resourceOrgIdpConfig()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"redirect_url":  "https://net.banyanops.com/v2/callback",
+		"issuer_url":    "https://example.okta.com",
+		"client_id":     "client-id",
+		"client_secret": "client-secret",
+	})
+	diags := r.CreateContext(context.Background(), d, c)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "IDPName is required")
+	assert.NotContains(t, diags[0].Summary, "fakeapi-key")
+	assert.Empty(t, d.Id())
+}
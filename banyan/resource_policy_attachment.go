@@ -0,0 +1,273 @@
+package banyan
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policyattachment"
+	"github.com/banyansecurity/terraform-banyan-provider/client/servicetunnel"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	attachedToService       = "service"
+	attachedToServiceTunnel = "service_tunnel"
+)
+
+func resourcePolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Description:   "The policy attachment resource attaches a policy to a service or service tunnel which is managed elsewhere, for example in another workspace or module. A service or service tunnel can only have one policy attached, so set external_policy_attachment instead of policy on the service or service tunnel it attaches to.",
+		CreateContext: resourcePolicyAttachmentCreate,
+		ReadContext:   resourcePolicyAttachmentRead,
+		UpdateContext: resourcePolicyAttachmentUpdate,
+		DeleteContext: resourcePolicyAttachmentDelete,
+		CustomizeDiff: customizeDiffPolicyAttachment,
+		Schema:        PolicyAttachmentSchema(),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePolicyAttachmentImport,
+		},
+	}
+}
+
+func PolicyAttachmentSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the policy attachment, in the form <attached_to_type>/<attached_to_id>",
+		},
+		"policy_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the policy to attach",
+		},
+		"attached_to_type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "Type of the object the policy is attached to, must be one of \"service\" or \"service_tunnel\"",
+			ValidateFunc: validation.StringInSlice([]string{attachedToService, attachedToServiceTunnel}, false),
+		},
+		"attached_to_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "ID of the service or service tunnel the policy is attached to",
+		},
+		"enforcing": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "If true the policy is enforced, otherwise it is in permissive mode",
+		},
+	}
+	return s
+}
+
+// policyAttachmentID returns the ID of the attachment of a policy to the given object, which only ever has one policy attached
+func policyAttachmentID(attachedToType, attachedToID string) string {
+	return attachedToType + "/" + attachedToID
+}
+
+// attachedPolicy returns the ID and enforcing mode of the policy currently attached to the object. policyID is empty if there is none
+func attachedPolicy(ctx context.Context, c *client.Holder, attachedToType, attachedToID string) (policyID string, enforcing bool, err error) {
+	switch attachedToType {
+	case attachedToService:
+		var att policyattachment.GetBody
+		att, err = c.PolicyAttachment.Get(ctx, attachedToID, attachedToService)
+		if err != nil {
+			return
+		}
+		return att.PolicyID, strings.EqualFold("TRUE", att.Enabled), nil
+	case attachedToServiceTunnel:
+		var att servicetunnel.GetPolicyAttachmentInfo
+		att, err = c.ServiceTunnel.GetPolicy(ctx, attachedToID)
+		if err != nil {
+			return
+		}
+		return att.PolicyID, strings.EqualFold("TRUE", att.Enabled), nil
+	}
+	err = fmt.Errorf("unsupported attached_to_type %q", attachedToType)
+	return
+}
+
+// customizeDiffPolicyAttachment rejects attaching a policy to an object which already has another policy attached,
+// through the policy attribute of its resource or another banyan_policy_attachment
+func customizeDiffPolicyAttachment(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChanges("policy_id", "attached_to_type", "attached_to_id") {
+		return nil
+	}
+	for _, k := range []string{"policy_id", "attached_to_type", "attached_to_id"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+	policyID := d.Get("policy_id").(string)
+	attachedToType := d.Get("attached_to_type").(string)
+	attachedToID := d.Get("attached_to_id").(string)
+	current, _, err := attachedPolicy(ctx, m.(*client.Holder), attachedToType, attachedToID)
+	if err != nil {
+		return err
+	}
+	// the policy attached now is the one this resource attached, which it replaces
+	if old, _ := d.GetChange("policy_id"); d.Id() != "" && !d.HasChanges("attached_to_type", "attached_to_id") && current == old.(string) {
+		return nil
+	}
+	if current != "" && current != policyID {
+		return fmt.Errorf("%s %s already has policy %s attached, either through the policy attribute of its resource or another banyan_policy_attachment; remove that attachment first", attachedToType, attachedToID, current)
+	}
+	return nil
+}
+
+func resourcePolicyAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	policyID := d.Get("policy_id").(string)
+	attachedToType := d.Get("attached_to_type").(string)
+	attachedToID := d.Get("attached_to_id").(string)
+	current, _, err := attachedPolicy(ctx, c, attachedToType, attachedToID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if current != "" && current != policyID {
+		return diag.Errorf("%s %s already has policy %s attached, either through the policy attribute of its resource or another banyan_policy_attachment; remove that attachment first", attachedToType, attachedToID, current)
+	}
+	err = attachPolicyTo(ctx, c, d, current)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(policyAttachmentID(attachedToType, attachedToID))
+	return resourcePolicyAttachmentRead(ctx, d, m)
+}
+
+func resourcePolicyAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	attachedToType := d.Get("attached_to_type").(string)
+	attachedToID := d.Get("attached_to_id").(string)
+	policyID, enforcing, err := attachedPolicy(ctx, c, attachedToType, attachedToID)
+	if err != nil {
+		return handleNotFoundError(d, err)
+	}
+	if policyID == "" {
+		log.Printf("[WARN] No policy attached to %s %s, removing from state", attachedToType, attachedToID)
+		d.SetId("")
+		return
+	}
+	err = d.Set("policy_id", policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("enforcing", enforcing)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return
+}
+
+func resourcePolicyAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	err := attachPolicyTo(ctx, c, d, d.Get("policy_id").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourcePolicyAttachmentRead(ctx, d, m)
+}
+
+func resourcePolicyAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	policyID := d.Get("policy_id").(string)
+	attachedToType := d.Get("attached_to_type").(string)
+	attachedToID := d.Get("attached_to_id").(string)
+	current, _, err := attachedPolicy(ctx, c, attachedToType, attachedToID)
+	if err != nil {
+		return handleNotFoundError(d, err)
+	}
+	// a different policy has been attached since, leave it alone
+	if current != policyID {
+		d.SetId("")
+		return
+	}
+	err = detachPolicyFrom(ctx, c, attachedToType, attachedToID, policyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return
+}
+
+// resourcePolicyAttachmentImport accepts an ID in the form <attached_to_type>/<attached_to_id>
+func resourcePolicyAttachmentImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	attachedToType, attachedToID, found := strings.Cut(d.Id(), "/")
+	if !found || attachedToID == "" || (attachedToType != attachedToService && attachedToType != attachedToServiceTunnel) {
+		return nil, fmt.Errorf("invalid ID (%s), expected service/<service id> or service_tunnel/<service tunnel id>", d.Id())
+	}
+	err := d.Set("attached_to_type", attachedToType)
+	if err != nil {
+		return nil, err
+	}
+	err = d.Set("attached_to_id", attachedToID)
+	if err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// attachPolicyTo attaches the policy in the state, replacing current which is the ID of the policy attached now
+func attachPolicyTo(ctx context.Context, c *client.Holder, d *schema.ResourceData, current string) (err error) {
+	policyID := d.Get("policy_id").(string)
+	attachedToType := d.Get("attached_to_type").(string)
+	attachedToID := d.Get("attached_to_id").(string)
+	enforcing := d.Get("enforcing").(bool)
+	pol, err := c.Policy.Get(ctx, policyID)
+	if err != nil {
+		return
+	}
+	if pol.ID == "" {
+		return fmt.Errorf("policy with id %s not found", policyID)
+	}
+	switch attachedToType {
+	case attachedToService:
+		if current != "" {
+			err = c.PolicyAttachment.DeleteServiceAttachment(ctx, current, attachedToID)
+			if err != nil {
+				return
+			}
+		}
+		_, err = c.PolicyAttachment.Create(ctx, policyID, policyattachment.CreateBody{
+			AttachedToID:   attachedToID,
+			AttachedToType: attachedToService,
+			Enabled:        boolToString(enforcing),
+			IsEnabled:      enforcing,
+		})
+	case attachedToServiceTunnel:
+		// AttachPolicy replaces the policy attached now
+		_, err = c.ServiceTunnel.AttachPolicy(ctx, attachedToID, servicetunnel.PolicyAttachmentPost{
+			PolicyID: policyID,
+			Enabled:  enforcing,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to attach policy %s to %s %s: %w", policyID, attachedToType, attachedToID, err)
+	}
+	log.Printf("[INFO] Attached policy %s to %s %s", policyID, attachedToType, attachedToID)
+	return
+}
+
+func detachPolicyFrom(ctx context.Context, c *client.Holder, attachedToType, attachedToID, policyID string) (err error) {
+	switch attachedToType {
+	case attachedToService:
+		err = c.PolicyAttachment.DeleteServiceAttachment(ctx, policyID, attachedToID)
+	case attachedToServiceTunnel:
+		err = c.ServiceTunnel.DeletePolicy(ctx, attachedToID, policyID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to detach policy %s from %s %s: %w", policyID, attachedToType, attachedToID, err)
+	}
+	log.Printf("[INFO] Detached policy %s from %s %s", policyID, attachedToType, attachedToID)
+	return
+}
@@ -0,0 +1,156 @@
+package banyan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_policyAttachmentLifecycle(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	srv.AddService(service.GetServicesJson{ServiceID: "web.cluster1.bnn", ServiceName: "web", ClusterName: "cluster1"})
+	for _, id := range []string{"policy-a", "policy-b"} {
+		srv.AddPolicy(policy.GetPolicy{ID: id, Name: id, Spec: `{"kind":"BanyanPolicy","metadata":{"name":"` + id + `"}}`})
+	}
+	ctx := context.Background()
+	r := resourcePolicyAttachment()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id":        "policy-a",
+		"attached_to_type": "service",
+		"attached_to_id":   "web.cluster1.bnn",
+		"enforcing":        false,
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+	assert.Equal(t, "service/web.cluster1.bnn", d.Id())
+	assert.Equal(t, false, d.Get("enforcing"))
+
+	conflicting := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"policy_id":        "policy-b",
+		"attached_to_type": "service",
+		"attached_to_id":   "web.cluster1.bnn",
+	})
+	diags := r.CreateContext(ctx, conflicting, c)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "already has policy policy-a attached")
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId("service/web.cluster1.bnn")
+	_, err := r.Importer.StateContext(ctx, imported, c)
+	require.NoError(t, err)
+	require.Empty(t, r.ReadContext(ctx, imported, c))
+	assert.Equal(t, "policy-a", imported.Get("policy_id"))
+
+	require.Empty(t, r.DeleteContext(ctx, d, c))
+	policyID, _, err := attachedPolicy(ctx, c, "service", "web.cluster1.bnn")
+	require.NoError(t, err)
+	assert.Empty(t, policyID)
+}
+
+func Test_policyAttachmentPlanConflict(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	for _, id := range []string{"policy-a", "policy-b"} {
+		srv.AddPolicy(policy.GetPolicy{ID: id, Name: id, Spec: `{"kind":"BanyanPolicy","metadata":{"name":"` + id + `"}}`})
+	}
+	ctx := context.Background()
+
+	webRaw := map[string]interface{}{
+		"name":                       "web",
+		"access_tier":                "us-west1",
+		"domain":                     "web.example.com",
+		"backend_domain":             "10.0.0.1",
+		"backend_port":               8080,
+		"external_policy_attachment": true,
+	}
+	web := resourceServiceWeb()
+	svc := schema.TestResourceDataRaw(t, web.Schema, webRaw)
+	require.Empty(t, web.CreateContext(ctx, svc, c))
+
+	r := resourcePolicyAttachment()
+	attachmentRaw := map[string]interface{}{
+		"policy_id":        "policy-a",
+		"attached_to_type": "service",
+		"attached_to_id":   svc.Id(),
+	}
+	_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(attachmentRaw), c)
+	require.NoError(t, err)
+	d := schema.TestResourceDataRaw(t, r.Schema, attachmentRaw)
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	// a second attachment is rejected at plan time
+	_, err = r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"policy_id":        "policy-b",
+		"attached_to_type": "service",
+		"attached_to_id":   svc.Id(),
+	}), c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has policy policy-a attached")
+
+	// the service leaves the attached policy alone while external_policy_attachment is set
+	require.Empty(t, web.UpdateContext(ctx, svc, c))
+	policyID, _, err := attachedPolicy(ctx, c, "service", svc.Id())
+	require.NoError(t, err)
+	assert.Equal(t, "policy-a", policyID)
+
+	// and rejects a policy of its own at plan time
+	webRaw["policy"] = "policy-b"
+	delete(webRaw, "external_policy_attachment")
+	_, err = web.Diff(ctx, svc.State(), terraform.NewResourceConfigRaw(webRaw), c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has policy policy-a attached")
+
+	webRaw["external_policy_attachment"] = true
+	diags := web.Validate(terraform.NewResourceConfigRaw(webRaw))
+	require.True(t, diags.HasError(), "policy conflicts with external_policy_attachment")
+}
+
+func Test_servicePolicyDetachedOnRemoval(t *testing.T) {
+	t.Parallel()
+	srv, c := testutil.NewFakeClient(t)
+	srv.AddPolicy(policy.GetPolicy{ID: "policy-a", Name: "policy-a", Spec: `{"kind":"BanyanPolicy","metadata":{"name":"policy-a"}}`})
+	ctx := context.Background()
+
+	raw := map[string]interface{}{
+		"name":           "web",
+		"access_tier":    "us-west1",
+		"domain":         "web.example.com",
+		"backend_domain": "10.0.0.1",
+		"backend_port":   8080,
+		"policy":         "policy-a",
+	}
+	web := resourceServiceWeb()
+	d := schema.TestResourceDataRaw(t, web.Schema, raw)
+	require.Empty(t, web.CreateContext(ctx, d, c))
+	policyID, enforcing, err := attachedPolicy(ctx, c, "service", d.Id())
+	require.NoError(t, err)
+	assert.Equal(t, "policy-a", policyID)
+	assert.True(t, enforcing)
+
+	delete(raw, "policy")
+	removed := schema.TestResourceDataRaw(t, web.Schema, raw)
+	removed.SetId(d.Id())
+	require.Empty(t, web.UpdateContext(ctx, removed, c))
+	policyID, _, err = attachedPolicy(ctx, c, "service", d.Id())
+	require.NoError(t, err)
+	assert.Empty(t, policyID)
+
+	tunnel := resourceServiceTunnel()
+	_, err = tunnel.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "tunnel",
+		"access_tier": "us-west1",
+		"network_settings": []interface{}{map[string]interface{}{
+			"cluster": "cluster1",
+		}},
+	}), c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "policy: required unless external_policy_attachment is true")
+}
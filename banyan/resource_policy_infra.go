@@ -6,7 +6,9 @@ import (
 	"github.com/banyansecurity/terraform-banyan-provider/client"
 	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 )
 
@@ -17,8 +19,9 @@ func resourcePolicyInfra() *schema.Resource {
 		ReadContext:   resourcePolicyInfraRead,
 		UpdateContext: resourcePolicyInfraUpdate,
 		DeleteContext: resourcePolicyInfraDelete,
+		CustomizeDiff: customdiff.All(customizeDiffPolicy, customizeDiffPolicyOptions),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("policy", policyIDsByName),
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -37,6 +40,31 @@ func resourcePolicyInfra() *schema.Resource {
 				Required:    true,
 				Description: "Description of the policy",
 			},
+			"l7_protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Application level protocol of the services the policy is attached to, \"http\" or \"kafka\". Every access group needs l7_access rules when it is set",
+				ValidateFunc: validation.StringInSlice([]string{policy.L7_PROTOCOL_HTTP, policy.L7_PROTOCOL_KAFKA}, false),
+			},
+			"disable_tls_client_authentication": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Do not ask for a client TLS certificate when connecting to the services the policy is attached to",
+			},
+			"mixed_users_and_workloads": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Allow both end users and workloads to connect, instead of either",
+			},
+			"exception_src_addr": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "CIDRs of source addresses which can connect without TLS, such as workloads which cannot use a Banyan client",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateCIDR(),
+				},
+			},
 			"access": {
 				Type:        schema.TypeList,
 				MinItems:    1,
@@ -59,6 +87,50 @@ func resourcePolicyInfra() *schema.Resource {
 							Required:     true,
 							ValidateFunc: validateTrustLevel(),
 						},
+						"start_time": {
+							Type:             schema.TypeString,
+							Description:      "Time from which the access group applies, in RFC3339 format, e.g. \"2026-01-01T09:00:00Z\"",
+							Optional:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppressEquivalentTimes,
+						},
+						"end_time": {
+							Type:             schema.TypeString,
+							Description:      "Time until which the access group applies, in RFC3339 format, e.g. \"2026-01-31T17:00:00Z\"",
+							Optional:         true,
+							ValidateFunc:     validateEndTime(),
+							DiffSuppressFunc: suppressEquivalentTimes,
+						},
+						"l7_access": {
+							Type:        schema.TypeList,
+							Description: "Application level access rules, required when l7_protocol is set",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"resources": {
+										Type: schema.TypeSet,
+										Description: `
+											Resources are a list of application level resources: paths for http and topics for kafka.
+											Each resource can have wildcard prefix or suffix, or both.
+											A resource can be prefixed with "!", meaning DENY.
+											Any DENY rule overrides any other rule that would allow the access.`,
+										Optional: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"actions": {
+										Type:        schema.TypeSet,
+										Description: "Actions are a list of application-level actions: \"CREATE\", \"READ\", \"UPDATE\", \"DELETE\", \"WRITE\", \"*\"",
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringInSlice(l7Actions, false),
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -79,13 +151,12 @@ func policyInfraFromState(d *schema.ResourceData) (pol policy.Object) {
 		},
 		Type: "USER",
 		Spec: policy.Spec{
-			Access: expandPolicyInfraAccess(d.Get("access").([]interface{})),
-			Exception: policy.Exception{
-				SrcAddr: []string{},
-			},
+			Access:    expandPolicyInfraAccess(d.Get("access").([]interface{}), d.Get("l7_protocol").(string)),
+			Exception: policyExceptionFromState(d),
 			Options: policy.Options{
-				DisableTLSClientAuthentication: false,
-				L7Protocol:                     "",
+				DisableTLSClientAuthentication: d.Get("disable_tls_client_authentication").(bool),
+				L7Protocol:                     d.Get("l7_protocol").(string),
+				MixedUsersAndWorkloads:         d.Get("mixed_users_and_workloads").(bool),
 			},
 		},
 	}
@@ -94,7 +165,11 @@ func policyInfraFromState(d *schema.ResourceData) (pol policy.Object) {
 
 func resourcePolicyInfraCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Policy.Create(policyInfraFromState(d))
+	err := checkPolicyRoles(ctx, c, d.Get("access").([]interface{}), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := c.Policy.Create(ctx, policyInfraFromState(d))
 	if err != nil {
 		return diag.FromErr(errors.WithMessage(err, "couldn't create new infra policy"))
 	}
@@ -105,10 +180,9 @@ func resourcePolicyInfraCreate(ctx context.Context, d *schema.ResourceData, m in
 
 func resourcePolicyInfraRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Policy.Get(d.Id())
+	resp, err := c.Policy.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("name", resp.Name)
 	if err != nil {
@@ -118,7 +192,23 @@ func resourcePolicyInfraRead(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("access", flattenPolicyInfraAccess(resp.UnmarshalledPolicy.Spec.Access))
+	err = d.Set("access", flattenPolicyInfraAccess(resp.UnmarshalledPolicy.Spec.Access, resp.UnmarshalledPolicy.Spec.L7Protocol))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("l7_protocol", resp.UnmarshalledPolicy.Spec.L7Protocol)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("disable_tls_client_authentication", resp.UnmarshalledPolicy.Spec.DisableTLSClientAuthentication)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("mixed_users_and_workloads", resp.UnmarshalledPolicy.Spec.MixedUsersAndWorkloads)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("exception_src_addr", resp.UnmarshalledPolicy.Spec.SrcAddr)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -128,7 +218,11 @@ func resourcePolicyInfraRead(ctx context.Context, d *schema.ResourceData, m inte
 
 func resourcePolicyInfraUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Policy.Update(policyInfraFromState(d))
+	err := checkPolicyRoles(ctx, c, d.Get("access").([]interface{}), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := c.Policy.Update(ctx, policyInfraFromState(d))
 	if err != nil {
 		return diag.FromErr(errors.WithMessage(err, "couldn't create new infra policy"))
 	}
@@ -140,17 +234,16 @@ func resourcePolicyInfraUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 func resourcePolicyInfraDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Policy.Get(d.Id())
+	resp, err := c.Policy.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
-	err = c.Policy.Detach(c.PolicyAttachment, resp.ID)
+	err = c.Policy.Detach(ctx, c.PolicyAttachment, resp.ID)
 	if err != nil {
 		diagnostics = diag.FromErr(err)
 		return
 	}
-	err = c.Policy.Delete(resp.ID)
+	err = c.Policy.Delete(ctx, resp.ID)
 	if err != nil {
 		diagnostics = diag.FromErr(err)
 		return
@@ -159,26 +252,49 @@ func resourcePolicyInfraDelete(ctx context.Context, d *schema.ResourceData, m in
 	return
 }
 
-func expandPolicyInfraAccess(m []interface{}) (access []policy.Access) {
+func expandPolicyInfraAccess(m []interface{}, l7Protocol string) (access []policy.Access) {
 	for _, raw := range m {
 		data := raw.(map[string]interface{})
 		a := policy.Access{
 			Roles: convertSchemaSetToStringSlice(data["roles"].(*schema.Set)),
 		}
 		a.Rules.Conditions.TrustLevel = data["trust_level"].(string)
+		a.Rules.Conditions.StartTime = data["start_time"].(string)
+		a.Rules.Conditions.EndTime = data["end_time"].(string)
 		a.Rules.L7Access = []policy.L7Access{}
+		if l7Protocol != "" {
+			a.Rules.L7Access = expandPolicyWebL7Access(data["l7_access"].([]interface{}))
+		}
 		access = append(access, a)
 	}
 	return
 }
 
-func flattenPolicyInfraAccess(toFlatten []policy.Access) (flattened []interface{}) {
+// flattenPolicyInfraAccess flattens the access groups of an infra policy. A lone rule allowing everything is kept when
+// l7Protocol is set, since l7_access is required then.
+func flattenPolicyInfraAccess(toFlatten []policy.Access, l7Protocol string) (flattened []interface{}) {
 	flattened = make([]interface{}, len(toFlatten))
 	for idx, accessItem := range toFlatten {
 		ai := make(map[string]interface{})
 		ai["roles"] = accessItem.Roles
 		ai["trust_level"] = accessItem.Rules.Conditions.TrustLevel
+		ai["start_time"] = accessItem.Rules.Conditions.StartTime
+		ai["end_time"] = accessItem.Rules.Conditions.EndTime
+		if l7Protocol != "" {
+			ai["l7_access"] = flattenL7Rules(accessItem.L7Access)
+		} else {
+			ai["l7_access"] = flattenPolicyWebL7Access(accessItem.L7Access)
+		}
 		flattened[idx] = ai
 	}
 	return
 }
+
+// policyExceptionFromState returns the source addresses which are exempt from TLS, as an empty list if there are none
+func policyExceptionFromState(d *schema.ResourceData) policy.Exception {
+	srcAddr := convertSchemaSetToStringSlice(d.Get("exception_src_addr").(*schema.Set))
+	if srcAddr == nil {
+		srcAddr = []string{}
+	}
+	return policy.Exception{SrcAddr: srcAddr}
+}
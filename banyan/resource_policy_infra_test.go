@@ -1,11 +1,18 @@
 package banyan
 
 import (
+	"context"
 	"fmt"
+	"testing"
+
 	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
-	"testing"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccPolicy_infrastructure(t *testing.T) {
@@ -82,3 +89,114 @@ func testAccPolicy_infrastructure_create_json(name string) string {
 }
 `, name)
 }
+
+func Test_policyInfraTimeWindow(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := Provider().ResourcesMap["banyan_role"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":       "Contractors",
+		"user_group": []interface{}{"Contractors"},
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	r = resourcePolicyInfra()
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "contractor-access",
+		"description": "expires on its own",
+		"access": []interface{}{map[string]interface{}{
+			"roles":       []interface{}{"Contractors"},
+			"trust_level": "Medium",
+			"start_time":  "2030-01-01T09:00:00Z",
+			"end_time":    "2030-01-31T17:00:00Z",
+		}},
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	pol, err := c.Policy.Get(ctx, d.Id())
+	require.NoError(t, err)
+	conditions := pol.UnmarshalledPolicy.Spec.Access[0].Rules.Conditions
+	assert.Equal(t, "2030-01-01T09:00:00Z", conditions.StartTime)
+	assert.Equal(t, "2030-01-31T17:00:00Z", conditions.EndTime)
+	assert.Equal(t, "2030-01-01T09:00:00Z", d.Get("access.0.start_time"))
+	assert.Equal(t, "2030-01-31T17:00:00Z", d.Get("access.0.end_time"))
+}
+
+func Test_policyInfraOptions(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	r := resourcePolicyInfra()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                      "kafka-workloads",
+		"description":               "workload to workload kafka access",
+		"l7_protocol":               "kafka",
+		"mixed_users_and_workloads": true,
+		"exception_src_addr":        []interface{}{"10.0.0.0/24"},
+		"access": []interface{}{map[string]interface{}{
+			"roles":       []interface{}{"ANY"},
+			"trust_level": "",
+			"l7_access": []interface{}{map[string]interface{}{
+				"resources": []interface{}{"orders*"},
+				"actions":   []interface{}{"READ", "WRITE"},
+			}},
+		}},
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+
+	pol, err := c.Policy.Get(ctx, d.Id())
+	require.NoError(t, err)
+	spec := pol.UnmarshalledPolicy.Spec
+	assert.Equal(t, "kafka", spec.L7Protocol)
+	assert.False(t, spec.DisableTLSClientAuthentication)
+	assert.True(t, spec.MixedUsersAndWorkloads)
+	assert.Equal(t, []string{"10.0.0.0/24"}, spec.SrcAddr)
+	assert.Equal(t, []string{"orders*"}, spec.Access[0].L7Access[0].Resources)
+
+	assert.Equal(t, "kafka", d.Get("l7_protocol"))
+	assert.True(t, d.Get("mixed_users_and_workloads").(bool))
+	assert.Equal(t, []interface{}{"10.0.0.0/24"}, d.Get("exception_src_addr").(*schema.Set).List())
+	assert.Equal(t, "orders*", d.Get("access.0.l7_access.0.resources").(*schema.Set).List()[0])
+}
+
+func Test_policyInfraAllowAllPlan(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+
+	raw := map[string]interface{}{
+		"name":        "kafka-allow-all",
+		"description": "kafka access with an allow all group",
+		"l7_protocol": "kafka",
+		"access": []interface{}{
+			map[string]interface{}{
+				"roles":       []interface{}{"ANY"},
+				"trust_level": "High",
+				"l7_access": []interface{}{map[string]interface{}{
+					"resources": []interface{}{"*"},
+					"actions":   []interface{}{"*"},
+				}},
+			},
+			map[string]interface{}{
+				"roles":       []interface{}{"ANY"},
+				"trust_level": "Medium",
+				"l7_access": []interface{}{map[string]interface{}{
+					"resources": []interface{}{"orders*"},
+					"actions":   []interface{}{"READ"},
+				}},
+			},
+		},
+	}
+	r := resourcePolicyInfra()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	require.Empty(t, r.CreateContext(ctx, d, c))
+	require.Empty(t, r.ReadContext(ctx, d, c))
+	assert.Equal(t, 1, d.Get("access.0.l7_access.#"))
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), c)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "unexpected diff: %v", diff)
+}
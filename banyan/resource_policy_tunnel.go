@@ -19,9 +19,10 @@ func resourcePolicyTunnel() *schema.Resource {
 		ReadContext:   resourcePolicyTunnelRead,
 		UpdateContext: resourcePolicyTunnelUpdate,
 		DeleteContext: resourcePolicyTunnelDelete,
+		CustomizeDiff: customizeDiffPolicy,
 		Schema:        PolicyTunnelSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("policy", policyIDsByName),
 		},
 	}
 }
@@ -76,6 +77,20 @@ func PolicyTunnelSchema() (s map[string]*schema.Schema) {
 						Required:     true,
 						ValidateFunc: validateTrustLevel(),
 					},
+					"start_time": {
+						Type:             schema.TypeString,
+						Description:      "Time from which the access group applies, in RFC3339 format, e.g. \"2026-01-01T09:00:00Z\"",
+						Optional:         true,
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppressEquivalentTimes,
+					},
+					"end_time": {
+						Type:             schema.TypeString,
+						Description:      "Time until which the access group applies, in RFC3339 format, e.g. \"2026-01-31T17:00:00Z\"",
+						Optional:         true,
+						ValidateFunc:     validateEndTime(),
+						DiffSuppressFunc: suppressEquivalentTimes,
+					},
 					"l4_access": {
 						Type:        schema.TypeList,
 						MaxItems:    1,
@@ -210,7 +225,11 @@ func policyTunnelFromState(d *schema.ResourceData) (pol policy.Object) {
 
 func resourcePolicyTunnelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	createdPolicy, err := c.Policy.Create(policyTunnelFromState(d))
+	err := checkPolicyRoles(ctx, c, d.Get("access").([]interface{}), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	createdPolicy, err := c.Policy.Create(ctx, policyTunnelFromState(d))
 	if err != nil {
 		return diag.FromErr(errors.WithMessage(err, "couldn't create new tunnel policy"))
 	}
@@ -221,7 +240,11 @@ func resourcePolicyTunnelCreate(ctx context.Context, d *schema.ResourceData, m i
 
 func resourcePolicyTunnelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	createdPolicy, err := c.Policy.Update(policyTunnelFromState(d))
+	err := checkPolicyRoles(ctx, c, d.Get("access").([]interface{}), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	createdPolicy, err := c.Policy.Update(ctx, policyTunnelFromState(d))
 	if err != nil {
 		return diag.FromErr(errors.WithMessage(err, "couldn't create new tunnel policy"))
 	}
@@ -233,10 +256,9 @@ func resourcePolicyTunnelUpdate(ctx context.Context, d *schema.ResourceData, m i
 func resourcePolicyTunnelRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 	id := d.Id()
-	resp, err := c.Policy.Get(id)
+	resp, err := c.Policy.Get(ctx, id)
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("name", resp.Name)
 	if err != nil {
@@ -272,6 +294,8 @@ func expandPolicyTunnelAccess(m []interface{}) (access []policy.Access) {
 			},
 		}
 		a.Rules.Conditions.TrustLevel = data["trust_level"].(string)
+		a.Rules.Conditions.StartTime = data["start_time"].(string)
+		a.Rules.Conditions.EndTime = data["end_time"].(string)
 
 		name := data["name"]
 		if name != nil {
@@ -358,6 +382,8 @@ func flattenPolicyTunnelAccess(toFlatten []policy.Access) (flattened []interface
 		ai["description"] = accessItem.Description
 		ai["roles"] = accessItem.Roles
 		ai["trust_level"] = accessItem.Rules.Conditions.TrustLevel
+		ai["start_time"] = accessItem.Rules.Conditions.StartTime
+		ai["end_time"] = accessItem.Rules.Conditions.EndTime
 		ai["l4_access"] = flattenL4Access(accessItem.L4Access)
 		flattened[idx] = ai
 	}
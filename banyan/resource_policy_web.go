@@ -19,9 +19,10 @@ func resourcePolicyWeb() *schema.Resource {
 		ReadContext:   resourcePolicyWebRead,
 		UpdateContext: resourcePolicyWebUpdate,
 		DeleteContext: resourcePolicyWebDelete,
+		CustomizeDiff: customizeDiffPolicy,
 		Schema:        PolicyWebSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("policy", policyIDsByName),
 		},
 	}
 }
@@ -44,6 +45,20 @@ func PolicyWebSchema() (s map[string]*schema.Schema) {
 			Required:    true,
 			Description: "Description of the policy",
 		},
+		"mixed_users_and_workloads": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Allow both end users and workloads to connect, instead of either",
+		},
+		"exception_src_addr": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "CIDRs of source addresses which can connect without TLS, such as workloads which cannot use a Banyan client",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validateCIDR(),
+			},
+		},
 		"access": {
 			Type:        schema.TypeList,
 			MinItems:    1,
@@ -66,6 +81,20 @@ func PolicyWebSchema() (s map[string]*schema.Schema) {
 						Required:     true,
 						ValidateFunc: validateTrustLevel(),
 					},
+					"start_time": {
+						Type:             schema.TypeString,
+						Description:      "Time from which the access group applies, in RFC3339 format, e.g. \"2026-01-01T09:00:00Z\"",
+						Optional:         true,
+						ValidateFunc:     validation.IsRFC3339Time,
+						DiffSuppressFunc: suppressEquivalentTimes,
+					},
+					"end_time": {
+						Type:             schema.TypeString,
+						Description:      "Time until which the access group applies, in RFC3339 format, e.g. \"2026-01-31T17:00:00Z\"",
+						Optional:         true,
+						ValidateFunc:     validateEndTime(),
+						DiffSuppressFunc: suppressEquivalentTimes,
+					},
 					"l7_access": {
 						Type:        schema.TypeList,
 						Description: "Indicates whether the end user device is allowed to use L7",
@@ -86,11 +115,11 @@ func PolicyWebSchema() (s map[string]*schema.Schema) {
 								},
 								"actions": {
 									Type:        schema.TypeSet,
-									Description: "Actions are a list of application-level actions: \"CREATE\", \"READ\", \"UPDATE\", \"DELETE\", \"*\"",
+									Description: "Actions are a list of application-level actions: \"CREATE\", \"READ\", \"UPDATE\", \"DELETE\", \"WRITE\", \"*\"",
 									Optional:    true,
 									Elem: &schema.Schema{
 										Type:         schema.TypeString,
-										ValidateFunc: validation.StringInSlice([]string{"CREATE", "READ", "UPDATE", "DELETE", "*"}, false),
+										ValidateFunc: validation.StringInSlice(l7Actions, false),
 									},
 								},
 							},
@@ -116,13 +145,12 @@ func policyWebFromState(d *schema.ResourceData) (pol policy.Object) {
 		},
 		Type: "USER",
 		Spec: policy.Spec{
-			Access: expandPolicyWebAccess(d.Get("access").([]interface{})),
-			Exception: policy.Exception{
-				SrcAddr: []string{},
-			},
+			Access:    expandPolicyWebAccess(d.Get("access").([]interface{})),
+			Exception: policyExceptionFromState(d),
 			Options: policy.Options{
 				DisableTLSClientAuthentication: true,
 				L7Protocol:                     "http",
+				MixedUsersAndWorkloads:         d.Get("mixed_users_and_workloads").(bool),
 			},
 		},
 	}
@@ -132,13 +160,12 @@ func policyWebFromState(d *schema.ResourceData) (pol policy.Object) {
 func resourcePolicyWebCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 
-	// 	ValidateFunc is not supported on lists or sets, so use this method instead
-	err := invalidL7AccessRules(d)
+	err := checkPolicyRoles(ctx, c, d.Get("access").([]interface{}), nil)
 	if err != nil {
-		return diag.FromErr(errors.WithMessage(err, "invalid l7_access block"))
+		return diag.FromErr(err)
 	}
 
-	createdPolicy, err := c.Policy.Create(policyWebFromState(d))
+	createdPolicy, err := c.Policy.Create(ctx, policyWebFromState(d))
 	if err != nil {
 		return diag.FromErr(errors.WithMessage(err, "couldn't create new web policy"))
 	}
@@ -150,12 +177,11 @@ func resourcePolicyWebCreate(ctx context.Context, d *schema.ResourceData, m inte
 func resourcePolicyWebUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 
-	// 	ValidateFunc is not supported on lists or sets, so use this method instead
-	err := invalidL7AccessRules(d)
+	err := checkPolicyRoles(ctx, c, d.Get("access").([]interface{}), nil)
 	if err != nil {
-		return diag.FromErr(errors.WithMessage(err, "invalid l7_access block"))
+		return diag.FromErr(err)
 	}
-	updatedPolicy, err := c.Policy.Update(policyWebFromState(d))
+	updatedPolicy, err := c.Policy.Update(ctx, policyWebFromState(d))
 	if err != nil {
 		return diag.FromErr(errors.WithMessage(err, "couldn't create new web policy"))
 	}
@@ -167,10 +193,9 @@ func resourcePolicyWebUpdate(ctx context.Context, d *schema.ResourceData, m inte
 func resourcePolicyWebRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 	id := d.Id()
-	resp, err := c.Policy.Get(id)
+	resp, err := c.Policy.Get(ctx, id)
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("name", resp.Name)
 	if err != nil {
@@ -184,6 +209,14 @@ func resourcePolicyWebRead(ctx context.Context, d *schema.ResourceData, m interf
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = d.Set("mixed_users_and_workloads", resp.UnmarshalledPolicy.Spec.MixedUsersAndWorkloads)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("exception_src_addr", resp.UnmarshalledPolicy.Spec.SrcAddr)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	d.SetId(resp.ID)
 	return
 }
@@ -193,28 +226,6 @@ func resourcePolicyWebDelete(ctx context.Context, d *schema.ResourceData, m inte
 	return
 }
 
-func invalidL7AccessRules(d *schema.ResourceData) error {
-	allowAll := policy.L7Access{Resources: []string{"*"}, Actions: []string{"*"}}
-
-	m := d.Get("access").([]interface{})
-	for _, raw := range m {
-		data := raw.(map[string]interface{})
-		l7Access := data["l7_access"].([]interface{})
-		if len(l7Access) == 0 || l7Access[0] == nil || len(l7Access) != 1 {
-			continue
-		}
-		l7Rules := l7Access[0].(map[string]interface{})
-		actions := convertSchemaSetToStringSlice(l7Rules["actions"].(*schema.Set))
-		resources := convertSchemaSetToStringSlice(l7Rules["resources"].(*schema.Set))
-		allowL7 := policy.L7Access{Resources: resources, Actions: actions}
-		if reflect.DeepEqual(allowL7, allowAll) {
-			return errors.New("redundant l7_access block with allow_all rules; remove l7_access block entirely")
-		}
-	}
-
-	return nil
-}
-
 func expandPolicyWebAccess(m []interface{}) (access []policy.Access) {
 	for _, raw := range m {
 		data := raw.(map[string]interface{})
@@ -222,6 +233,8 @@ func expandPolicyWebAccess(m []interface{}) (access []policy.Access) {
 			Roles: convertSchemaSetToStringSlice(data["roles"].(*schema.Set)),
 		}
 		a.Rules.Conditions.TrustLevel = data["trust_level"].(string)
+		a.Rules.Conditions.StartTime = data["start_time"].(string)
+		a.Rules.Conditions.EndTime = data["end_time"].(string)
 		a.Rules.L7Access = expandPolicyWebL7Access(data["l7_access"].([]interface{}))
 		access = append(access, a)
 	}
@@ -258,6 +271,8 @@ func flattenPolicyWebAccess(toFlatten []policy.Access) (flattened []interface{})
 		ai := make(map[string]interface{})
 		ai["roles"] = accessItem.Roles
 		ai["trust_level"] = accessItem.Rules.Conditions.TrustLevel
+		ai["start_time"] = accessItem.Rules.Conditions.StartTime
+		ai["end_time"] = accessItem.Rules.Conditions.EndTime
 		ai["l7_access"] = flattenPolicyWebL7Access(accessItem.L7Access)
 		flattened = append(flattened, ai)
 	}
@@ -293,7 +308,11 @@ func flattenPolicyWebL7Access(toFlatten []policy.L7Access) (flattened []interfac
 
 		return
 	}
+	return flattenL7Rules(toFlatten)
+}
 
+// flattenL7Rules flattens l7_access rules as they are, including a lone rule allowing everything
+func flattenL7Rules(toFlatten []policy.L7Access) (flattened []interface{}) {
 	flattened = make([]interface{}, len(toFlatten))
 	for idx, l7access := range toFlatten {
 		l7 := make(map[string]interface{})
@@ -1,6 +1,7 @@
 package banyan
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -185,7 +186,7 @@ func testAccCheckExistingPolicy(resourceName string, bnnPolicy *policy.GetPolicy
 		if !ok {
 			return fmt.Errorf("resource not found %q", rs)
 		}
-		resp, err := testAccClient.Policy.Get(rs.Primary.ID)
+		resp, err := testAccClient.Policy.Get(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return err
 		}
@@ -200,7 +201,7 @@ func testAccCheckExistingPolicy(resourceName string, bnnPolicy *policy.GetPolicy
 // Uses the API to check that the policy was destroyed
 func testAccCheckPolicy_destroy(t *testing.T, id *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		r, _ := testAccClient.Policy.Get(*id)
+		r, _ := testAccClient.Policy.Get(context.Background(), *id)
 		assert.Equal(t, r.ID, "")
 		return nil
 	}
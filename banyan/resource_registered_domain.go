@@ -97,7 +97,7 @@ func resourceRegisteredDomainCreate(ctx context.Context, d *schema.ResourceData,
 	// if org is global edge create domain challenge first
 	if rdReqBody.ClusterName == constants.GlobalEdgeCluster {
 
-		challengeID, err := c.RegisteredDomain.CreateRDChallenge(registereddomain.RegisteredDomainChallengeRequest{
+		challengeID, err := c.RegisteredDomain.CreateRDChallenge(ctx, registereddomain.RegisteredDomainChallengeRequest{
 			RegisteredDomainName: rdReqBody.Name,
 		})
 		if err != nil {
@@ -107,12 +107,12 @@ func resourceRegisteredDomainCreate(ctx context.Context, d *schema.ResourceData,
 		rdReqBody.RegisteredDomainChallengeID = &challengeID
 	}
 
-	rd, err := c.RegisteredDomain.Create(rdReqBody)
+	rd, err := c.RegisteredDomain.Create(ctx, rdReqBody)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	dnsSettings, err := flattenDnsSettings(d, c, rd)
+	dnsSettings, err := flattenDnsSettings(ctx, d, c, rd)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -143,7 +143,7 @@ func resourceRegisteredDomainRead(ctx context.Context, d *schema.ResourceData, m
 
 	id := d.Get("id").(string)
 	c := m.(*client.Holder)
-	resp, err := c.RegisteredDomain.Get(id)
+	resp, err := c.RegisteredDomain.Get(ctx, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -168,7 +168,7 @@ func resourceRegisteredDomainRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	dnsSettings, err := flattenDnsSettings(d, c, resp)
+	dnsSettings, err := flattenDnsSettings(ctx, d, c, resp)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -186,7 +186,7 @@ func resourceRegisteredDomainDelete(ctx context.Context, d *schema.ResourceData,
 	id := d.Get("id").(string)
 	c := m.(*client.Holder)
 
-	err := c.RegisteredDomain.Delete(id)
+	err := c.RegisteredDomain.Delete(ctx, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -196,7 +196,7 @@ func resourceRegisteredDomainDelete(ctx context.Context, d *schema.ResourceData,
 	return
 }
 
-func flattenDnsSettings(d *schema.ResourceData, c *client.Holder, resp registereddomain.RegisteredDomainInfo) (dnsSettings []interface{}, err error) {
+func flattenDnsSettings(ctx context.Context, d *schema.ResourceData, c *client.Holder, resp registereddomain.RegisteredDomainInfo) (dnsSettings []interface{}, err error) {
 
 	// cname acme is only created for wildcard domains
 	if strings.HasPrefix(resp.Name, "*.") {
@@ -214,7 +214,7 @@ func flattenDnsSettings(d *schema.ResourceData, c *client.Holder, resp registere
 	if resp.ClusterName == constants.GlobalEdgeCluster {
 
 		var challengeInfo registereddomain.RegisteredDomainChallengeInfo
-		challengeInfo, err = c.RegisteredDomain.GetRDChallenge(*resp.RegisteredDomainChallengeID)
+		challengeInfo, err = c.RegisteredDomain.GetRDChallenge(ctx, *resp.RegisteredDomainChallengeID)
 		if err != nil {
 			return
 		}
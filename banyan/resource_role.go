@@ -20,7 +20,7 @@ func resourceRole() *schema.Resource {
 		DeleteContext: resourceRoleDelete,
 		Schema:        RoleSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("role", roleIDsByName),
 		},
 	}
 }
@@ -164,7 +164,7 @@ func RoleFromState(d *schema.ResourceData) (r role.CreateRole) {
 
 func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Role.Create(RoleFromState(d))
+	resp, err := c.Role.Create(ctx, RoleFromState(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -174,7 +174,7 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Role.Update(RoleFromState(d))
+	resp, err := c.Role.Update(ctx, RoleFromState(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -184,10 +184,9 @@ func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	resp, err := c.Role.Get(d.Id())
+	resp, err := c.Role.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	d.SetId(resp.ID)
 	err = d.Set("name", resp.Name)
@@ -243,7 +242,7 @@ func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	err := c.Role.Delete(d.Id())
+	err := c.Role.Delete(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -1,6 +1,7 @@
 package banyan
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -126,7 +127,7 @@ func testAccCheckExistingRole(resourceName string, bnnRole *role.GetRole) resour
 		if !ok {
 			return fmt.Errorf("resource not found %q", rs)
 		}
-		resp, err := testAccClient.Role.Get(rs.Primary.ID)
+		resp, err := testAccClient.Role.Get(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return err
 		}
@@ -151,7 +152,7 @@ func testAccCheckRoleGroupsUpdated(t *testing.T, bnnRole *role.GetRole, group []
 // Uses the API to check that the role was destroyed
 func testAccCheckRoleDestroy(t *testing.T, id *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		r, _ := testAccClient.Role.Get(*id)
+		r, _ := testAccClient.Role.Get(context.Background(), *id)
 		assert.Equal(t, r.ID, "")
 		return nil
 	}
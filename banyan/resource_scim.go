@@ -77,7 +77,7 @@ func resourceSCIMCreate(ctx context.Context, d *schema.ResourceData, m interface
 		IsEnabled: isEnabled,
 	}
 
-	err = c.SCIM.ProvisionSCIM(post)
+	err = c.SCIM.ProvisionSCIM(ctx, post)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -87,7 +87,7 @@ func resourceSCIMCreate(ctx context.Context, d *schema.ResourceData, m interface
 		return
 	}
 
-	key, err := c.SCIM.Create()
+	key, err := c.SCIM.Create(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -118,7 +118,7 @@ func resourceSCIMUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		IsEnabled: d.Get("is_enabled").(bool),
 	}
 
-	err := c.SCIM.Update(post, expandTokenInfo(d))
+	err := c.SCIM.Update(ctx, post, expandTokenInfo(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -128,10 +128,9 @@ func resourceSCIMUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 func resourceSCIMRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	key, err := c.SCIM.Get()
+	key, err := c.SCIM.Get(ctx)
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 
 	d.SetId(d.Get("id").(string))
@@ -155,7 +154,7 @@ func resourceSCIMRead(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceSCIMDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	err := c.SCIM.Delete(expandTokenInfo(d))
+	err := c.SCIM.Delete(ctx, expandTokenInfo(d))
 	if err != nil {
 		diagnostics = diag.FromErr(err)
 		return
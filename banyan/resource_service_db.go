@@ -17,9 +17,10 @@ func resourceServiceDb() *schema.Resource {
 		ReadContext:   resourceServiceInfraDbRead,
 		UpdateContext: resourceServiceInfraDbUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customizeDiffServicePolicy(attachedToService, true),
 		Schema:        DbSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("service", serviceIDsByName),
 		},
 	}
 }
@@ -201,9 +202,10 @@ func DbSchema() map[string]*schema.Schema {
 			},
 		},
 		"policy": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "Policy ID to be attached to this service",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"external_policy_attachment"},
+			Description:   "Policy ID to be attached to this service. Required unless external_policy_attachment is true",
 		},
 		"end_user_override": {
 			Type:        schema.TypeBool,
@@ -217,16 +219,21 @@ func DbSchema() map[string]*schema.Schema {
 			Default:     true,
 			Description: "mode in which policy should be. If this is true policy is in enforcing mode else policy is in Permissive mode",
 		},
+		"external_policy_attachment": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Leave the policy attached to this service alone, so that it can be attached by a banyan_policy_attachment resource, for example in another workspace",
+		},
 	}
 }
 
 func resourceServiceInfraDbCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
-	err := setCluster(d, m)
+	err := setCluster(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	svc := DbFromState(d)
-	diagnostics = resourceServiceCreate(svc, d, m)
+	diagnostics = resourceServiceCreate(ctx, svc, d, m)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
@@ -235,10 +242,9 @@ func resourceServiceInfraDbCreate(ctx context.Context, d *schema.ResourceData, m
 
 func resourceServiceInfraDbRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	svc, err := c.Service.Get(d.Id())
+	svc, err := c.Service.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("client_banyanproxy_allowed_domains", svc.CreateServiceSpec.Metadata.Tags.IncludeDomains)
 	if err != nil {
@@ -272,13 +278,13 @@ func resourceServiceInfraDbRead(ctx context.Context, d *schema.ResourceData, m i
 			return diag.FromErr(err)
 		}
 	}
-	diagnostics = resourceServiceInfraCommonRead(svc, d, m)
+	diagnostics = resourceServiceInfraCommonRead(ctx, svc, d, m)
 	return
 }
 
 func resourceServiceInfraDbUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	svc := DbFromState(d)
-	return resourceServiceUpdate(svc, d, m)
+	return resourceServiceUpdate(ctx, svc, d, m)
 }
 
 func DbFromState(d *schema.ResourceData) (svc service.CreateService) {
@@ -17,9 +17,10 @@ func resourceServiceK8s() *schema.Resource {
 		ReadContext:   resourceServiceInfraK8sRead,
 		UpdateContext: resourceServiceInfraK8sUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customizeDiffServicePolicy(attachedToService, false),
 		Schema:        K8sSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("service", serviceIDsByName),
 		},
 	}
 }
@@ -112,9 +113,10 @@ func K8sSchema() map[string]*schema.Schema {
 			ForceNew:    true,
 		},
 		"policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Policy ID to be attached to this service",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"external_policy_attachment"},
+			Description:   "Policy ID to be attached to this service. Removing it detaches the attached policy, unless external_policy_attachment is true",
 		},
 		"client_banyanproxy_listen_port": {
 			Type:        schema.TypeString,
@@ -148,16 +150,21 @@ func K8sSchema() map[string]*schema.Schema {
 			Default:     true,
 			Description: "mode in which policy should be. If this is true policy is in enforcing mode else policy is in Permissive mode",
 		},
+		"external_policy_attachment": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Leave the policy attached to this service alone, so that it can be attached by a banyan_policy_attachment resource, for example in another workspace",
+		},
 	}
 }
 
 func resourceServiceInfraK8sCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
-	err := setCluster(d, m)
+	err := setCluster(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	svc := K8sFromState(d)
-	diagnostics = resourceServiceCreate(svc, d, m)
+	diagnostics = resourceServiceCreate(ctx, svc, d, m)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
@@ -166,10 +173,9 @@ func resourceServiceInfraK8sCreate(ctx context.Context, d *schema.ResourceData,
 
 func resourceServiceInfraK8sRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	svc, err := c.Service.Get(d.Id())
+	svc, err := c.Service.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	domain := *svc.CreateServiceSpec.Metadata.Tags.Domain
 	override := svc.CreateServiceSpec.Spec.Backend.BackendDNSOverrides[domain]
@@ -189,12 +195,12 @@ func resourceServiceInfraK8sRead(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return resourceServiceInfraCommonRead(svc, d, m)
+	return resourceServiceInfraCommonRead(ctx, svc, d, m)
 }
 
 func resourceServiceInfraK8sUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	svc := K8sFromState(d)
-	return resourceServiceUpdate(svc, d, m)
+	return resourceServiceUpdate(ctx, svc, d, m)
 }
 
 func K8sFromState(d *schema.ResourceData) (svc service.CreateService) {
@@ -0,0 +1,270 @@
+package banyan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Schema for the raw service resource, which manages a service of any type from its full specification
+func resourceServiceRaw() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Resource used for lifecycle management of a service of any type from its full JSON or TOML specification. Use it for the settings which the other service resources do not support yet. For more information on Banyan services see the [documentation](https://docs.banyansecurity.io/docs/feature-guides/)",
+		CreateContext: resourceServiceRawCreate,
+		ReadContext:   resourceServiceRawRead,
+		UpdateContext: resourceServiceRawUpdate,
+		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customdiff.All(customizeDiffServiceRaw, customizeDiffServicePolicy(attachedToService, false)),
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Description: "Id of the service in Banyan",
+				Computed:    true,
+			},
+			"spec_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Specification of the service in JSON, in the format of the Banyan API. The cluster is looked up when metadata.cluster is not set. Changing metadata.name creates a new service",
+				ExactlyOneOf:     []string{"spec_json", "spec_toml"},
+				ValidateFunc:     validateServiceSpec("spec_json"),
+				DiffSuppressFunc: suppressEquivalentServiceSpecs,
+			},
+			"spec_toml": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Specification of the service in TOML, as in netagent configs and banyanctl exports. Otherwise the same as spec_json. Imported services are read into spec_json",
+				ExactlyOneOf:     []string{"spec_json", "spec_toml"},
+				ValidateFunc:     validateServiceSpec("spec_toml"),
+				DiffSuppressFunc: suppressEquivalentServiceSpecs,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the service, from metadata.name of the specification",
+				Computed:    true,
+			},
+			"policy": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"external_policy_attachment"},
+				Description:   "Policy ID to be attached to this service. Removing it detaches the attached policy, unless external_policy_attachment is true",
+			},
+			"policy_enforcing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "mode in which policy should be. If this is true policy is in enforcing mode else policy is in Permissive mode",
+			},
+			"external_policy_attachment": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Leave the policy attached to this service alone, so that it can be attached by a banyan_policy_attachment resource, for example in another workspace",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importByName("service", serviceIDsByName),
+		},
+	}
+}
+
+func resourceServiceRawCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	svc, err := serviceRawFromState(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	diagnostics = resourceServiceCreate(ctx, svc, d, m)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+	return resourceServiceRawRead(ctx, d, m)
+}
+
+func resourceServiceRawUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	svc, err := serviceRawFromState(ctx, d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	diagnostics = resourceServiceUpdate(ctx, svc, d, m)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+	return resourceServiceRawRead(ctx, d, m)
+}
+
+func resourceServiceRawRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+	c := m.(*client.Holder)
+	svc, err := c.Service.Get(ctx, d.Id())
+	if err != nil {
+		return handleNotFoundError(d, err)
+	}
+	key := serviceSpecKey(d)
+	spec, err := encodeServiceSpec(key, svc.CreateServiceSpec)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// keep the configured document while it is equivalent, so that its formatting is not replaced
+	if !serviceSpecsEqual(key, d.Get(key).(string), spec) {
+		err = d.Set(key, spec)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	err = d.Set("name", svc.CreateServiceSpec.Metadata.Name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(svc.ServiceID)
+	// the policy is managed by a banyan_policy_attachment
+	if d.Get("external_policy_attachment").(bool) {
+		return
+	}
+	policyInfo, err := c.PolicyAttachment.Get(ctx, svc.ServiceID, "service")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("policy", policyInfo.PolicyID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("policy_enforcing", strings.EqualFold("TRUE", policyInfo.Enabled))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return
+}
+
+// serviceSpecKey returns the attribute holding the specification, spec_json unless spec_toml is used
+func serviceSpecKey(d *schema.ResourceData) string {
+	if _, ok := d.GetOk("spec_toml"); ok {
+		return "spec_toml"
+	}
+	return "spec_json"
+}
+
+// serviceRawFromState decodes the specification, setting the cluster when the document leaves it out
+func serviceRawFromState(ctx context.Context, d *schema.ResourceData, m interface{}) (svc service.CreateService, err error) {
+	key := serviceSpecKey(d)
+	svc, err = decodeServiceSpec(key, d.Get(key).(string))
+	if err != nil {
+		return
+	}
+	if svc.Metadata.ClusterName == "" {
+		svc.Metadata.ClusterName, err = getFirstCluster(ctx, m.(*client.Holder))
+	}
+	return
+}
+
+// decodeServiceSpec decodes and checks the service specification held by key. Unknown fields are rejected, since
+// they would otherwise be dropped without notice.
+func decodeServiceSpec(key string, spec string) (svc service.CreateService, err error) {
+	if key == "spec_toml" {
+		svc, err = service.UnmarshalTOML([]byte(spec))
+		if err != nil {
+			return svc, fmt.Errorf("invalid service spec: %w", err)
+		}
+	} else {
+		dec := json.NewDecoder(strings.NewReader(spec))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&svc)
+		if err != nil {
+			return svc, fmt.Errorf("invalid service spec: %w", err)
+		}
+		if dec.More() {
+			return svc, errors.New("invalid service spec: unexpected data after the document")
+		}
+	}
+	switch {
+	case svc.Kind != "BanyanService":
+		err = errors.New(`invalid service spec: kind must be "BanyanService"`)
+	case svc.Metadata.Name == "":
+		err = errors.New("invalid service spec: metadata.name is required")
+	}
+	return
+}
+
+// encodeServiceSpec encodes a service specification in the format of the attribute key
+func encodeServiceSpec(key string, svc service.CreateService) (string, error) {
+	var spec []byte
+	var err error
+	if key == "spec_toml" {
+		spec, err = service.MarshalTOML(svc)
+	} else {
+		spec, err = json.Marshal(svc)
+	}
+	return string(spec), err
+}
+
+func validateServiceSpec(key string) func(val interface{}, key string) (warns []string, errs []error) {
+	return func(val interface{}, k string) (warns []string, errs []error) {
+		_, err := decodeServiceSpec(key, val.(string))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", k, err))
+		}
+		return
+	}
+}
+
+// serviceSpecsEqual returns whether two service specifications held by key are equivalent once decoded, so that
+// documents which differ only in formatting, key order or fields left at their defaults are equal. A document
+// without a cluster matches any cluster, since one is looked up when it is left out.
+func serviceSpecsEqual(key string, a, b string) bool {
+	svcA, err := decodeServiceSpec(key, a)
+	if err != nil {
+		return false
+	}
+	svcB, err := decodeServiceSpec(key, b)
+	if err != nil {
+		return false
+	}
+	if svcA.Metadata.ClusterName == "" {
+		svcA.Metadata.ClusterName = svcB.Metadata.ClusterName
+	}
+	if svcB.Metadata.ClusterName == "" {
+		svcB.Metadata.ClusterName = svcA.Metadata.ClusterName
+	}
+	normalizedA, err := json.Marshal(svcA)
+	if err != nil {
+		return false
+	}
+	normalizedB, err := json.Marshal(svcB)
+	if err != nil {
+		return false
+	}
+	return string(normalizedA) == string(normalizedB)
+}
+
+func suppressEquivalentServiceSpecs(k, old, new string, d *schema.ResourceData) bool {
+	return serviceSpecsEqual(k, old, new)
+}
+
+// customizeDiffServiceRaw replaces the service when its name changes, since the name is part of its ID. This also
+// covers switching between spec_json and spec_toml.
+func customizeDiffServiceRaw(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	var oldName, newName, changed string
+	for _, key := range []string{"spec_json", "spec_toml"} {
+		o, n := d.GetChange(key)
+		if svc, err := decodeServiceSpec(key, o.(string)); err == nil {
+			oldName = svc.Metadata.Name
+		}
+		if svc, err := decodeServiceSpec(key, n.(string)); err == nil {
+			newName = svc.Metadata.Name
+		}
+		if d.HasChange(key) && n.(string) != "" {
+			changed = key
+		}
+	}
+	if changed == "" || oldName == newName {
+		return nil
+	}
+	return d.ForceNew(changed)
+}
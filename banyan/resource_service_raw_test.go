@@ -0,0 +1,180 @@
+package banyan
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/fakeapi"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testServiceRawSpec = `{
+  "kind": "BanyanService",
+  "apiVersion": "rbac.banyanops.com/v1",
+  "type": "origin",
+  "metadata": {
+    "name": "raw-tcp",
+    "description": "tcp service from a raw spec",
+    "tags": {"template": "TCP_USER", "user_facing": "true", "protocol": "tcp", "domain": "raw-tcp.corp.com", "port": "8443"}
+  },
+  "spec": {
+    "attributes": {"tls_sni": ["raw-tcp.corp.com"], "frontend_addresses": [{"cidr": "", "port": "8443"}]},
+    "backend": {"target": {"name": "10.0.0.1", "port": "22"}}
+  }
+}`
+
+const testServiceRawSpecTOML = `kind = "BanyanService"
+apiVersion = "rbac.banyanops.com/v1"
+type = "origin"
+
+[metadata]
+name = "raw-toml"
+description = "tcp service from a toml spec"
+
+[metadata.tags]
+template = "TCP_USER"
+protocol = "tcp"
+
+[spec.attributes]
+tls_sni = ["raw-toml.corp.com"]
+
+[[spec.attributes.frontend_addresses]]
+cidr = ""
+port = "8443"
+
+[spec.backend.target]
+name = "10.0.0.2"
+port = "22"
+`
+
+func Test_serviceRawLifecycle(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	r := resourceServiceRaw()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"spec_json": testServiceRawSpec,
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+	assert.Equal(t, "raw-tcp", d.Get("name"))
+	assert.Equal(t, testServiceRawSpec, d.Get("spec_json"), "an equivalent document should be kept as written")
+
+	svc, err := c.Service.Get(ctx, d.Id())
+	require.NoError(t, err)
+	assert.Equal(t, fakeapi.DefaultCluster, svc.CreateServiceSpec.Metadata.ClusterName)
+	assert.Equal(t, "10.0.0.1", svc.CreateServiceSpec.Spec.Backend.BackendTarget.Name)
+
+	imported := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	imported.SetId(d.Id())
+	require.Empty(t, r.ReadContext(ctx, imported, c))
+	assert.True(t, serviceSpecsEqual("spec_json", testServiceRawSpec, imported.Get("spec_json").(string)))
+	assert.Contains(t, imported.Get("spec_json"), `"cluster":"cluster1"`)
+	assert.Equal(t, "raw-tcp", imported.Get("name"))
+
+	require.Empty(t, r.DeleteContext(ctx, d, c))
+	_, err = c.Service.Get(ctx, svc.ServiceID)
+	assert.Error(t, err)
+}
+
+func Test_serviceRawTOML(t *testing.T) {
+	t.Parallel()
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	r := resourceServiceRaw()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"spec_toml": testServiceRawSpecTOML,
+	})
+	require.Empty(t, r.CreateContext(ctx, d, c))
+	assert.Equal(t, "raw-toml", d.Get("name"))
+	assert.Equal(t, testServiceRawSpecTOML, d.Get("spec_toml"))
+	assert.Empty(t, d.Get("spec_json"))
+
+	svc, err := c.Service.Get(ctx, d.Id())
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", svc.CreateServiceSpec.Spec.Backend.BackendTarget.Name)
+	assert.Equal(t, []string{"raw-toml.corp.com"}, svc.CreateServiceSpec.Spec.Attributes.TLSSNI)
+}
+
+func Test_customizeDiffServiceRaw(t *testing.T) {
+	r := resourceServiceRaw()
+	state := &terraform.InstanceState{ID: "raw-tcp.cluster1.bnn", Attributes: map[string]string{
+		"id":        "raw-tcp.cluster1.bnn",
+		"spec_json": testServiceRawSpec,
+		"name":      "raw-tcp",
+	}}
+	renamed := strings.Replace(testServiceRawSpec, `"name": "raw-tcp"`, `"name": "raw-tcp2"`, 1)
+	moved := strings.Replace(testServiceRawSpec, `"10.0.0.1"`, `"10.0.0.3"`, 1)
+	tests := []struct {
+		name        string
+		raw         map[string]interface{}
+		requiresNew bool
+	}{
+		{name: "rename", raw: map[string]interface{}{"spec_json": renamed}, requiresNew: true},
+		{name: "update", raw: map[string]interface{}{"spec_json": moved}},
+		{name: "switch to toml", raw: map[string]interface{}{"spec_toml": strings.Replace(testServiceRawSpecTOML, "raw-toml", "raw-tcp", 1)}},
+		{name: "switch to toml and rename", raw: map[string]interface{}{"spec_toml": testServiceRawSpecTOML}, requiresNew: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tt.raw), nil)
+			require.NoError(t, err)
+			require.NotNil(t, diff)
+			assert.Equal(t, tt.requiresNew, diff.RequiresNew())
+		})
+	}
+}
+
+func Test_decodeServiceSpec(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		spec string
+		err  string
+	}{
+		{name: "valid", spec: testServiceRawSpec},
+		{name: "valid toml", key: "spec_toml", spec: testServiceRawSpecTOML},
+		{name: "unknown toml key", key: "spec_toml", spec: "kind = \"BanyanService\"\n[metadata]\nname = \"a\"\nclustr = \"b\"\n", err: "unknown keys metadata.clustr"},
+		{name: "toml without name", key: "spec_toml", spec: "kind = \"BanyanService\"\n", err: "metadata.name is required"},
+		{name: "not json", spec: `kind: BanyanService`, err: "invalid service spec"},
+		{name: "unknown field", spec: `{"kind": "BanyanService", "metadata": {"name": "a"}, "spec": {"backend": {"targt": {}}}}`, err: `unknown field "targt"`},
+		{name: "wrong kind", spec: `{"kind": "BanyanPolicy", "metadata": {"name": "a"}}`, err: `kind must be "BanyanService"`},
+		{name: "no name", spec: `{"kind": "BanyanService", "metadata": {}}`, err: "metadata.name is required"},
+		{name: "trailing data", spec: `{"kind": "BanyanService", "metadata": {"name": "a"}} {}`, err: "unexpected data after the document"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := tt.key
+			if key == "" {
+				key = "spec_json"
+			}
+			_, err := decodeServiceSpec(key, tt.spec)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func Test_suppressEquivalentServiceSpecs(t *testing.T) {
+	compact := `{"kind":"BanyanService","metadata":{"name":"a","description":"","cluster":"cluster1"},"spec":{"backend":{"target":{"name":"10.0.0.1","port":"22"}}}}`
+	reordered := `{
+  "metadata": {"cluster": "cluster1", "name": "a"},
+  "spec": {"backend": {"target": {"port": "22", "name": "10.0.0.1"}}},
+  "kind": "BanyanService"
+}`
+	assert.True(t, suppressEquivalentServiceSpecs("spec_json", compact, reordered, nil))
+	changed := `{"kind":"BanyanService","metadata":{"name":"a","cluster":"cluster1"},"spec":{"backend":{"target":{"name":"10.0.0.2","port":"22"}}}}`
+	assert.False(t, suppressEquivalentServiceSpecs("spec_json", compact, changed, nil))
+	assert.False(t, suppressEquivalentServiceSpecs("spec_json", "", compact, nil))
+	noCluster := `{"kind":"BanyanService","metadata":{"name":"a"},"spec":{"backend":{"target":{"name":"10.0.0.1","port":"22"}}}}`
+	assert.True(t, suppressEquivalentServiceSpecs("spec_json", compact, noCluster, nil))
+}
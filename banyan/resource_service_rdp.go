@@ -17,9 +17,10 @@ func resourceServiceRdp() *schema.Resource {
 		ReadContext:   resourceServiceInfraRdpRead,
 		UpdateContext: resourceServiceInfraRdpUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customizeDiffServicePolicy(attachedToService, false),
 		Schema:        RdpSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("service", serviceIDsByName),
 		},
 	}
 }
@@ -114,9 +115,10 @@ func RdpSchema() map[string]*schema.Schema {
 			Description: "By default, Private DNS Override will be set to true i.e disable_private_dns is false. On the device, the domain name will resolve over the service tunnel to the correct Access Tier's public IP address. If you turn off Private DNS Override i.e. disable_private_dns is set to true, you need to explicitly set a private DNS entry for the service domain name.",
 		},
 		"policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Policy ID to be attached to this service",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"external_policy_attachment"},
+			Description:   "Policy ID to be attached to this service. Removing it detaches the attached policy, unless external_policy_attachment is true",
 		},
 		"cluster": {
 			Type:        schema.TypeString,
@@ -217,16 +219,21 @@ func RdpSchema() map[string]*schema.Schema {
 			Default:     true,
 			Description: "mode in which policy should be. If this is true policy is in enforcing mode else policy is in Permissive mode",
 		},
+		"external_policy_attachment": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Leave the policy attached to this service alone, so that it can be attached by a banyan_policy_attachment resource, for example in another workspace",
+		},
 	}
 }
 
 func resourceServiceInfraRdpCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
-	err := setCluster(d, m)
+	err := setCluster(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	svc := RdpFromState(d)
-	diagnostics = resourceServiceCreate(svc, d, m)
+	diagnostics = resourceServiceCreate(ctx, svc, d, m)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
@@ -235,10 +242,9 @@ func resourceServiceInfraRdpCreate(ctx context.Context, d *schema.ResourceData,
 
 func resourceServiceInfraRdpRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	svc, err := c.Service.Get(d.Id())
+	svc, err := c.Service.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("end_user_override", svc.CreateServiceSpec.Metadata.Tags.AllowUserOverride)
 	if err != nil {
@@ -274,12 +280,12 @@ func resourceServiceInfraRdpRead(ctx context.Context, d *schema.ResourceData, m
 		d.Set("rdp_settings", rdpSettings)
 	}
 
-	return resourceServiceInfraCommonRead(svc, d, m)
+	return resourceServiceInfraCommonRead(ctx, svc, d, m)
 }
 
 func resourceServiceInfraRdpUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	svc := RdpFromState(d)
-	return resourceServiceUpdate(svc, d, m)
+	return resourceServiceUpdate(ctx, svc, d, m)
 }
 
 func RdpFromState(d *schema.ResourceData) (svc service.CreateService) {
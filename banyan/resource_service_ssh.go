@@ -19,9 +19,10 @@ func resourceServiceSsh() *schema.Resource {
 		ReadContext:   resourceServiceInfraSshRead,
 		UpdateContext: resourceServiceInfraSshUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customizeDiffServicePolicy(attachedToService, false),
 		Schema:        SshSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("service", serviceIDsByName),
 		},
 	}
 }
@@ -116,9 +117,10 @@ func SshSchema() map[string]*schema.Schema {
 			Description: "By default, Private DNS Override will be set to true i.e disable_private_dns is false. On the device, the domain name will resolve over the service tunnel to the correct Access Tier's public IP address. If you turn off Private DNS Override i.e. disable_private_dns is set to true, you need to explicitly set a private DNS entry for the service domain name.",
 		},
 		"policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Policy ID to be attached to this service",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"external_policy_attachment"},
+			Description:   "Policy ID to be attached to this service. Removing it detaches the attached policy, unless external_policy_attachment is true",
 		},
 		"cluster": {
 			Type:        schema.TypeString,
@@ -158,6 +160,11 @@ func SshSchema() map[string]*schema.Schema {
 			Default:     true,
 			Description: "mode in which policy should be. If this is true policy is in enforcing mode else policy is in Permissive mode",
 		},
+		"external_policy_attachment": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Leave the policy attached to this service alone, so that it can be attached by a banyan_policy_attachment resource, for example in another workspace",
+		},
 		"allow_patterns": {
 			Type:     schema.TypeSet,
 			MaxItems: 1,
@@ -218,12 +225,12 @@ func SshSchema() map[string]*schema.Schema {
 }
 
 func resourceServiceInfraSshCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
-	err := setCluster(d, m)
+	err := setCluster(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	svc := SshFromState(d)
-	diagnostics = resourceServiceCreate(svc, d, m)
+	diagnostics = resourceServiceCreate(ctx, svc, d, m)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
@@ -233,10 +240,9 @@ func resourceServiceInfraSshCreate(ctx context.Context, d *schema.ResourceData,
 func resourceServiceInfraSshRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 	id := d.Id()
-	svc, err := c.Service.Get(id)
+	svc, err := c.Service.Get(ctx, id)
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	err = d.Set("client_ssh_auth", svc.CreateServiceSpec.Metadata.Tags.SSHServiceType)
 	if err != nil {
@@ -271,13 +277,13 @@ func resourceServiceInfraSshRead(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	diagnostics = resourceServiceInfraCommonRead(svc, d, m)
+	diagnostics = resourceServiceInfraCommonRead(ctx, svc, d, m)
 	return
 }
 
 func resourceServiceInfraSshUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	svc := SshFromState(d)
-	return resourceServiceUpdate(svc, d, m)
+	return resourceServiceUpdate(ctx, svc, d, m)
 }
 
 func SshFromState(d *schema.ResourceData) (svc service.CreateService) {
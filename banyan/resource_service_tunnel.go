@@ -19,9 +19,10 @@ func resourceServiceTunnel() *schema.Resource {
 		ReadContext:   resourceServiceTunnelRead,
 		UpdateContext: resourceServiceTunnelUpdate,
 		DeleteContext: resourceServiceTunnelDelete,
+		CustomizeDiff: customizeDiffServicePolicy(attachedToServiceTunnel, true),
 		Schema:        TunnelSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("service tunnel", serviceTunnelIDsByName),
 		},
 	}
 }
@@ -189,9 +190,10 @@ func TunnelSchema() (s map[string]*schema.Schema) {
 			},
 		},
 		"policy": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "Policy ID to be attached to this service tunnel",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"external_policy_attachment"},
+			Description:   "Policy ID to be attached to this service tunnel. Required unless external_policy_attachment is true",
 		},
 		"policy_enforcing": {
 			Type:        schema.TypeBool,
@@ -199,6 +201,11 @@ func TunnelSchema() (s map[string]*schema.Schema) {
 			Default:     true,
 			Description: "Policy Enforcing / Permissive",
 		},
+		"external_policy_attachment": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Leave the policy attached to this service tunnel alone, so that it can be attached by a banyan_policy_attachment resource, for example in another workspace",
+		},
 	}
 	return
 }
@@ -235,12 +242,12 @@ func resourceServiceTunnelCreate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	tun, err := c.ServiceTunnel.Create(state)
+	tun, err := c.ServiceTunnel.Create(ctx, state)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(tun.ID)
-	err = attachPolicy(c, d)
+	err = attachPolicy(ctx, c, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -253,31 +260,34 @@ func resourceServiceTunnelUpdate(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	tun, err := c.ServiceTunnel.Update(d.Id(), state)
+	tun, err := c.ServiceTunnel.Update(ctx, d.Id(), state)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(tun.ID)
-	err = attachPolicy(c, d)
+	err = attachPolicy(ctx, c, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	return
 }
 
-func attachPolicy(c *client.Holder, d *schema.ResourceData) (err error) {
-	policy := d.Get("policy")
-	if policy == nil {
+func attachPolicy(ctx context.Context, c *client.Holder, d *schema.ResourceData) (err error) {
+	if d.Get("external_policy_attachment").(bool) {
 		return
 	}
-	policyEnforcing := d.Get("policy_enforcing")
-	if policyEnforcing == nil {
-		return
+	policy := d.Get("policy").(string)
+	if policy == "" {
+		current, err := c.ServiceTunnel.GetPolicy(ctx, d.Id())
+		if err != nil || current.PolicyID == "" {
+			return err
+		}
+		return detachPolicyFrom(ctx, c, attachedToServiceTunnel, d.Id(), current.PolicyID)
 	}
 
-	_, err = c.ServiceTunnel.AttachPolicy(d.Id(), servicetunnel.PolicyAttachmentPost{
-		PolicyID: policy.(string),
-		Enabled:  policyEnforcing.(bool),
+	_, err = c.ServiceTunnel.AttachPolicy(ctx, d.Id(), servicetunnel.PolicyAttachmentPost{
+		PolicyID: policy,
+		Enabled:  d.Get("policy_enforcing").(bool),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to attach policy to service tunnel: %s", err)
@@ -287,10 +297,9 @@ func attachPolicy(c *client.Holder, d *schema.ResourceData) (err error) {
 
 func resourceServiceTunnelRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	tun, err := c.ServiceTunnel.Get(d.Id())
+	tun, err := c.ServiceTunnel.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
 	d.SetId(tun.ID)
 	err = d.Set("name", tun.Name)
@@ -305,7 +314,11 @@ func resourceServiceTunnelRead(ctx context.Context, d *schema.ResourceData, m in
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	policy, err := c.ServiceTunnel.GetPolicy(tun.ID)
+	// the policy is managed by a banyan_policy_attachment
+	if d.Get("external_policy_attachment").(bool) {
+		return
+	}
+	policy, err := c.ServiceTunnel.GetPolicy(ctx, tun.ID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -327,11 +340,11 @@ func resourceServiceTunnelRead(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceServiceTunnelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	err := resourceServiceTunnelDetachPolicy(d, c)
+	err := resourceServiceTunnelDetachPolicy(ctx, d, c)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = c.ServiceTunnel.Delete(d.Id())
+	err = c.ServiceTunnel.Delete(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -339,20 +352,20 @@ func resourceServiceTunnelDelete(ctx context.Context, d *schema.ResourceData, m
 	return
 }
 
-func resourceServiceTunnelDetachPolicy(d *schema.ResourceData, c *client.Holder) (err error) {
+func resourceServiceTunnelDetachPolicy(ctx context.Context, d *schema.ResourceData, c *client.Holder) (err error) {
 	_, ok := d.GetOk("policy")
 	if !ok {
 		return nil
 	}
-	attachedPolicy, err := c.ServiceTunnel.GetPolicy(d.Id())
+	attachedPolicy, err := c.ServiceTunnel.GetPolicy(ctx, d.Id())
 	if err != nil {
 		return err
 	}
-	err = c.ServiceTunnel.DeletePolicy(d.Id(), attachedPolicy.PolicyID)
+	err = c.ServiceTunnel.DeletePolicy(ctx, d.Id(), attachedPolicy.PolicyID)
 	if err != nil {
 		return
 	}
-	err = c.PolicyAttachment.Delete(attachedPolicy.PolicyID)
+	err = c.PolicyAttachment.Delete(ctx, attachedPolicy.PolicyID)
 	if err != nil {
 		return
 	}
@@ -22,9 +22,10 @@ func resourceServiceWeb() *schema.Resource {
 		ReadContext:   resourceServiceWebRead,
 		UpdateContext: resourceServiceWebUpdate,
 		DeleteContext: resourceServiceDelete,
+		CustomizeDiff: customizeDiffServicePolicy(attachedToService, false),
 		Schema:        WebSchema(),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName("service", serviceIDsByName),
 		},
 	}
 }
@@ -110,9 +111,10 @@ func WebSchema() (s map[string]*schema.Schema) {
 			Optional:    true,
 		},
 		"policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Policy ID to be attached to this service",
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"external_policy_attachment"},
+			Description:   "Policy ID to be attached to this service. Removing it detaches the attached policy, unless external_policy_attachment is true",
 		},
 		"cluster": {
 			Type:        schema.TypeString,
@@ -293,6 +295,11 @@ func WebSchema() (s map[string]*schema.Schema) {
 			Default:     true,
 			Description: "mode in which policy should be. If this is true policy is in enforcing mode else policy is in Permissive mode",
 		},
+		"external_policy_attachment": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Leave the policy attached to this service alone, so that it can be attached by a banyan_policy_attachment resource, for example in another workspace",
+		},
 		"tls_sni": {
 			Type:     schema.TypeList,
 			Optional: true,
@@ -323,12 +330,12 @@ func WebSchema() (s map[string]*schema.Schema) {
 }
 
 func resourceServiceWebCreate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
-	err := setCluster(d, m)
+	err := setCluster(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	svc := WebFromState(d)
-	diagnostics = resourceServiceCreate(svc, d, m)
+	diagnostics = resourceServiceCreate(ctx, svc, d, m)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
@@ -338,12 +345,11 @@ func resourceServiceWebCreate(ctx context.Context, d *schema.ResourceData, m int
 func resourceServiceWebRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	log.Printf("[INFO] Reading service %s", d.Id())
 	c := m.(*client.Holder)
-	svc, err := c.Service.Get(d.Id())
+	svc, err := c.Service.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
-	diagnostics = resourceServiceInfraCommonRead(svc, d, m)
+	diagnostics = resourceServiceInfraCommonRead(ctx, svc, d, m)
 	err = d.Set("backend_tls", svc.CreateServiceSpec.Spec.BackendTarget.TLS)
 	if err != nil {
 		return diag.FromErr(err)
@@ -440,13 +446,13 @@ func resourceServiceWebRead(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceServiceWebUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	svc := WebFromState(d)
-	diagnostics = resourceServiceUpdate(svc, d, m)
+	diagnostics = resourceServiceUpdate(ctx, svc, d, m)
 	if diagnostics.HasError() {
 		return diagnostics
 	}
 
 	// enable/disable web service
-	err := toggleWebService(d, m)
+	err := toggleWebService(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -455,15 +461,15 @@ func resourceServiceWebUpdate(ctx context.Context, d *schema.ResourceData, m int
 	return
 }
 
-func toggleWebService(d *schema.ResourceData, m interface{}) (err error) {
+func toggleWebService(ctx context.Context, d *schema.ResourceData, m interface{}) (err error) {
 	log.Printf("[INFO] toggle web service %s", d.Id())
 	c := m.(*client.Holder)
 	if d.Get("enable").(bool) {
-		err = c.Service.Enable(d.Id())
+		err = c.Service.Enable(ctx, d.Id())
 		return
 	}
 
-	err = c.Service.Disable(d.Id())
+	err = c.Service.Disable(ctx, d.Id())
 	return
 }
 
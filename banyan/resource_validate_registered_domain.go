@@ -39,7 +39,7 @@ func resourceValidateRegisteredDomainCreate(ctx context.Context, d *schema.Resou
 
 	domainID := d.Get("domain_id").(string)
 
-	_, err := c.RegisteredDomain.ValidateDomain(domainID)
+	_, err := c.RegisteredDomain.ValidateDomain(ctx, domainID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
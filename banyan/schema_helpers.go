@@ -1,6 +1,7 @@
 package banyan
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -8,10 +9,11 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client"
 	"github.com/banyansecurity/terraform-banyan-provider/client/accesstiergroup"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -33,15 +35,17 @@ func getStringListWithinSetForKey(keys *schema.Set, key string) (values []string
 	return
 }
 
-// Adds a warning to the diagnostics if the resource is not found and sets the id to "" which deletes it from the schema
+// Adds a warning to the diagnostics if the resource is not found and sets the id to "" which deletes it from the schema.
+// Any other error is returned as an error diagnostic
 func handleNotFoundError(d *schema.ResourceData, err error) (diagnostics diag.Diagnostics) {
-	if strings.Contains(err.Error(), "not found") {
-		diagnostics = append(diagnostics, diag.Diagnostic{
-			Severity: diag.Warning,
-			Summary:  fmt.Sprintf("%s not found", d.Id()),
-		})
-		d.SetId("")
+	if !restclient.IsNotFound(err) {
+		return diag.FromErr(err)
 	}
+	diagnostics = append(diagnostics, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s not found", d.Id()),
+	})
+	d.SetId("")
 	return
 }
 
@@ -49,6 +53,35 @@ func validateTrustLevel() func(val interface{}, key string) (warns []string, err
 	return validation.StringInSlice([]string{"", "Low", "Medium", "High"}, false)
 }
 
+// validateEndTime checks that an end time is in RFC3339 format, and warns when it has already passed
+func validateEndTime() func(val interface{}, key string) (warns []string, errs []error) {
+	return func(val interface{}, key string) (warns []string, errs []error) {
+		warns, errs = validation.IsRFC3339Time(val, key)
+		if len(errs) > 0 {
+			return
+		}
+		end, _ := time.Parse(time.RFC3339, val.(string))
+		if end.Before(time.Now()) {
+			warns = append(warns, fmt.Sprintf("%s %s has already passed, so the access group no longer grants access", key, val))
+		}
+		return
+	}
+}
+
+// suppressEquivalentTimes suppresses the diff between two RFC3339 times which are the same instant, e.g. in
+// different time zones
+func suppressEquivalentTimes(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
+
 func contains(valid []string, v string) bool {
 	for _, s := range valid {
 		if s == v {
@@ -133,7 +166,14 @@ func isNil(i interface{}) bool {
 	return false
 }
 
-func GetStringPtr(d *schema.ResourceData, key string) (result *string) {
+// schemaGetter is implemented by both schema.ResourceData and schema.ResourceDiff, so that the same expanders can be
+// used at apply and at plan time
+type schemaGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+func GetStringPtr(d schemaGetter, key string) (result *string) {
 	r, ok := d.GetOk(key)
 	if !ok {
 		return nil
@@ -142,7 +182,7 @@ func GetStringPtr(d *schema.ResourceData, key string) (result *string) {
 	return &x
 }
 
-func GetBoolPtr(d *schema.ResourceData, key string) (result *bool) {
+func GetBoolPtr(d schemaGetter, key string) (result *bool) {
 	r, ok := d.GetOk(key)
 	if !ok {
 		return nil
@@ -151,7 +191,7 @@ func GetBoolPtr(d *schema.ResourceData, key string) (result *bool) {
 	return &x
 }
 
-func GetIntPtr(d *schema.ResourceData, key string) (result *int) {
+func GetIntPtr(d schemaGetter, key string) (result *int) {
 	r, ok := d.GetOk(key)
 	if !ok {
 		return nil
@@ -196,14 +236,14 @@ func buildHostTagSelector(d *schema.ResourceData) (hostTagSelector []map[string]
 // errors if connector and access_tier are both set
 // sets cluster to same as access_tier value if access_tier is set
 // sets to first cluster if the access_tier does not exist
-func setCluster(d *schema.ResourceData, m interface{}) (err error) {
+func setCluster(ctx context.Context, d *schema.ResourceData, m interface{}) (err error) {
 	_, clusterOk := d.GetOk("cluster")
 	if clusterOk {
 		return
 	}
 
 	c := m.(*client.Holder)
-	clusterName, err := determineCluster(c, d)
+	clusterName, err := determineCluster(ctx, c, d)
 	if err != nil {
 		return
 	}
@@ -211,7 +251,7 @@ func setCluster(d *schema.ResourceData, m interface{}) (err error) {
 	return
 }
 
-func determineCluster(c *client.Holder, d *schema.ResourceData) (clusterName string, err error) {
+func determineCluster(ctx context.Context, c *client.Holder, d *schema.ResourceData) (clusterName string, err error) {
 	// registered services
 	_, connOk := d.GetOk("connector")
 	at, atOk := d.GetOk("access_tier")
@@ -254,10 +294,10 @@ func determineCluster(c *client.Holder, d *schema.ResourceData) (clusterName str
 
 	if atg != nil {
 		var atDetails accesstiergroup.AccessTierGroupResponse
-		atDetails, err = c.AccessTierGroup.GetName(atg.(string))
+		atDetails, err = c.AccessTierGroup.GetName(ctx, atg.(string))
 		if err != nil {
 			_ = fmt.Errorf("accesstier group %s not found", atg.(string))
-			clusterName, err = getFirstCluster(c)
+			clusterName, err = getFirstCluster(ctx, c)
 			return
 		}
 		clusterName = atDetails.ClusterName
@@ -265,18 +305,18 @@ func determineCluster(c *client.Holder, d *schema.ResourceData) (clusterName str
 	}
 
 	// otherwise determine which cluster to set based off of the access tier
-	atDetails, err := c.AccessTier.GetName(at.(string))
+	atDetails, err := c.AccessTier.GetName(ctx, at.(string))
 	if err != nil {
 		_ = fmt.Errorf("accesstier %s not found", at.(string))
-		clusterName, err = getFirstCluster(c)
+		clusterName, err = getFirstCluster(ctx, c)
 		return
 	}
 	clusterName = atDetails.ClusterName
 	return
 }
 
-func getFirstCluster(c *client.Holder) (clusterName string, err error) {
-	clusters, err := c.Shield.GetAll()
+func getFirstCluster(ctx context.Context, c *client.Holder) (clusterName string, err error) {
+	clusters, err := c.Shield.GetAll(ctx)
 	if err != nil {
 		return
 	}
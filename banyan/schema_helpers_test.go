@@ -81,6 +81,25 @@ func Test_typeSwitchPort(t *testing.T) {
 	}
 }
 
+func Test_validateEndTime(t *testing.T) {
+	t.Parallel()
+	warns, errs := validateEndTime()("2000-01-01T00:00:00Z", "access.0.end_time")
+	assert.Equal(t, []string{"access.0.end_time 2000-01-01T00:00:00Z has already passed, so the access group no longer grants access"}, warns)
+	assert.Empty(t, errs)
+	warns, errs = validateEndTime()("2999-01-01T00:00:00Z", "access.0.end_time")
+	assert.Empty(t, warns)
+	assert.Empty(t, errs)
+	_, errs = validateEndTime()("2999-01-01", "access.0.end_time")
+	assert.NotEmpty(t, errs)
+}
+
+func Test_suppressEquivalentTimes(t *testing.T) {
+	t.Parallel()
+	assert.True(t, suppressEquivalentTimes("", "2030-01-01T10:00:00+02:00", "2030-01-01T08:00:00Z", nil))
+	assert.False(t, suppressEquivalentTimes("", "2030-01-01T10:00:00Z", "2030-01-01T08:00:00Z", nil))
+	assert.False(t, suppressEquivalentTimes("", "", "2030-01-01T08:00:00Z", nil))
+}
+
 func Test_contains(t *testing.T) {
 	t.Parallel()
 	valid := []string{"a", "b", "c", "d", "e"}
@@ -2,6 +2,7 @@ package banyan
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
@@ -14,16 +15,15 @@ import (
 
 func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	svc, err := c.Service.Get(d.Id())
+	svc, err := c.Service.Get(ctx, d.Id())
 	if err != nil {
-		handleNotFoundError(d, err)
-		return
+		return handleNotFoundError(d, err)
 	}
-	err = c.Service.DetachPolicy(svc.ServiceID)
+	err = c.Service.DetachPolicy(ctx, svc.ServiceID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = c.Service.Delete(d.Id())
+	err = c.Service.Delete(ctx, d.Id())
 	if err != nil {
 		diagnostics = diag.FromErr(err)
 	}
@@ -31,25 +31,30 @@ func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 // common function to create a service
-func resourceServiceCreate(svc service.CreateService, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+func resourceServiceCreate(ctx context.Context, svc service.CreateService, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	created, err := c.Service.Create(svc)
+	created, err := c.Service.Create(ctx, svc)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(created.ServiceID)
-	err = attachPolicyToService(d, c)
+	err = attachPolicyToService(ctx, d, c)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	return
 }
 
-func attachPolicyToService(d *schema.ResourceData, c *client.Holder) (err error) {
+// attachPolicyToService attaches the policy of the service, detaching the policy attached now. When policy is not
+// set the attached policy is detached, unless external_policy_attachment leaves it to a banyan_policy_attachment
+func attachPolicyToService(ctx context.Context, d *schema.ResourceData, c *client.Holder) (err error) {
+	if d.Get("external_policy_attachment").(bool) {
+		return
+	}
 	log.Printf("[INFO] Getting policy for attachment %s", d.Id())
-	currentPolicy, err := c.Service.GetPolicyForService(d.Id())
+	currentPolicy, err := c.Service.GetPolicyForService(ctx, d.Id())
 	if currentPolicy.ID != "" {
-		err = c.Policy.Detach(c.PolicyAttachment, currentPolicy.ID)
+		err = detachPolicyFrom(ctx, c, attachedToService, d.Id(), currentPolicy.ID)
 		if err != nil {
 			return
 		}
@@ -58,7 +63,7 @@ func attachPolicyToService(d *schema.ResourceData, c *client.Holder) (err error)
 	if policyID == "" {
 		return
 	}
-	pol, err := c.Policy.Get(policyID)
+	pol, err := c.Policy.Get(ctx, policyID)
 	if err != nil {
 		return
 	}
@@ -69,14 +74,10 @@ func attachPolicyToService(d *schema.ResourceData, c *client.Holder) (err error)
 		AttachedToID:   d.Get("id").(string),
 		AttachedToType: "service",
 		IsEnabled:      true,
+		Enabled:        boolToString(d.Get("policy_enforcing").(bool)),
 	}
 
-	policyEnforcing := d.Get("policy_enforcing")
-	if policyEnforcing != nil {
-		body.Enabled = boolToString(policyEnforcing.(bool))
-	}
-
-	pa, err := c.PolicyAttachment.Create(policyID, body)
+	pa, err := c.PolicyAttachment.Create(ctx, policyID, body)
 	if err != nil {
 		return
 	}
@@ -84,17 +85,50 @@ func attachPolicyToService(d *schema.ResourceData, c *client.Holder) (err error)
 	return
 }
 
-func resourceServiceUpdate(svc service.CreateService, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+// customizeDiffServicePolicy checks the policy attribute of a service or service tunnel. policyRequired is set for
+// the types which need a policy unless it is attached externally. A policy which would replace one attached by a
+// banyan_policy_attachment is rejected, as banyan_policy_attachment rejects attaching to a service with a policy.
+func customizeDiffServicePolicy(attachedToType string, policyRequired bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if !d.NewValueKnown("policy") || !d.NewValueKnown("external_policy_attachment") {
+			return nil
+		}
+		policyID := d.Get("policy").(string)
+		if d.Get("external_policy_attachment").(bool) {
+			return nil
+		}
+		if policyID == "" {
+			if policyRequired {
+				return errors.New("policy: required unless external_policy_attachment is true")
+			}
+			return nil
+		}
+		if d.Id() == "" || !d.HasChange("policy") {
+			return nil
+		}
+		old, _ := d.GetChange("policy")
+		current, _, err := attachedPolicy(ctx, m.(*client.Holder), attachedToType, d.Id())
+		if err != nil {
+			return err
+		}
+		if current != "" && current != old.(string) && current != policyID {
+			return fmt.Errorf("policy: %s %s already has policy %s attached by a banyan_policy_attachment or outside of terraform; remove that attachment first", attachedToType, d.Id(), current)
+		}
+		return nil
+	}
+}
+
+func resourceServiceUpdate(ctx context.Context, svc service.CreateService, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
-	_, err := c.Service.Get(d.Id())
+	_, err := c.Service.Get(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	_, err = c.Service.Update(d.Id(), svc)
+	_, err = c.Service.Update(ctx, d.Id(), svc)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = attachPolicyToService(d, c)
+	err = attachPolicyToService(ctx, d, c)
 	if err != nil {
 		return diag.FromErr(err)
 	}
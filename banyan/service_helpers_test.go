@@ -1,6 +1,7 @@
 package banyan
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -79,7 +80,7 @@ func AssertServiceTunnelEqual(t *testing.T, got servicetunnel.Info, want service
 // Asserts that the json string j is equal to the service spec in the API with id
 func testAccCheckServiceAgainstJson(t *testing.T, j string, id *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		got, err := testAccClient.Service.Get(*id)
+		got, err := testAccClient.Service.Get(context.Background(), *id)
 		if err != nil {
 			return err
 		}
@@ -95,7 +96,7 @@ func testAccCheckServiceAgainstJson(t *testing.T, j string, id *string) resource
 // Asserts that the json string j is equal to the policy spec in the API with id
 func testAccCheckPolicyAgainstJson(t *testing.T, j string, id *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		got, err := testAccClient.Policy.Get(*id)
+		got, err := testAccClient.Policy.Get(context.Background(), *id)
 		if err != nil {
 			return err
 		}
@@ -115,7 +116,7 @@ func testAccCheckExistingService(resourceName string, bnnService *service.GetSer
 		if !ok {
 			return fmt.Errorf("resource not found %q", rs)
 		}
-		resp, err := testAccClient.Service.Get(rs.Primary.ID)
+		resp, err := testAccClient.Service.Get(context.Background(), rs.Primary.ID)
 		if err != nil {
 			return err
 		}
@@ -132,7 +133,7 @@ func testAccCheckExistingService(resourceName string, bnnService *service.GetSer
 // Uses the API to check that the service was destroyed
 func testAccCheckServiceDestroy(t *testing.T, id *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		r, _ := testAccClient.AccessTier.Get(*id)
+		r, _ := testAccClient.AccessTier.Get(context.Background(), *id)
 		assert.Equal(t, r.ID, "")
 		return nil
 	}
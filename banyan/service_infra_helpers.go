@@ -1,6 +1,7 @@
 package banyan
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
@@ -18,7 +19,7 @@ import (
 // are used to abstract away complexity from the end user by populating the service struct using
 // the minimum required variables
 
-func resourceServiceInfraCommonRead(svc service.GetServiceSpec, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
+func resourceServiceInfraCommonRead(ctx context.Context, svc service.GetServiceSpec, d *schema.ResourceData, m interface{}) (diagnostics diag.Diagnostics) {
 	c := m.(*client.Holder)
 	err := d.Set("name", svc.ServiceName)
 	if err != nil {
@@ -113,24 +114,27 @@ func resourceServiceInfraCommonRead(svc service.GetServiceSpec, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	policyInfo, err := c.PolicyAttachment.Get(svc.ServiceID, "service")
-	if err != nil {
-		return
-	}
+	// the policy is managed by a banyan_policy_attachment
+	if !d.Get("external_policy_attachment").(bool) {
+		policyInfo, err := c.PolicyAttachment.Get(ctx, svc.ServiceID, "service")
+		if err != nil {
+			return
+		}
 
-	err = d.Set("policy", policyInfo.PolicyID)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+		err = d.Set("policy", policyInfo.PolicyID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-	policyEnforcing := false
-	if strings.EqualFold("TRUE", policyInfo.Enabled) {
-		policyEnforcing = true
-	}
+		policyEnforcing := false
+		if strings.EqualFold("TRUE", policyInfo.Enabled) {
+			policyEnforcing = true
+		}
 
-	err = d.Set("policy_enforcing", policyEnforcing)
-	if err != nil {
-		return diag.FromErr(err)
+		err = d.Set("policy_enforcing", policyEnforcing)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	d.SetId(d.Id())
@@ -2,6 +2,14 @@ package accesstier
 
 type AccessTierStatus string
 
+const (
+	Pending          AccessTierStatus = "Pending"
+	Healthy          AccessTierStatus = "Healthy"
+	PartiallyHealthy AccessTierStatus = "PartiallyHealthy"
+	Unhealthy        AccessTierStatus = "Unhealthy"
+	Inactive         AccessTierStatus = "Inactive"
+)
+
 type HostInfo struct {
 	Hostname string
 	IPs      []string
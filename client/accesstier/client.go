@@ -1,6 +1,7 @@
 package accesstier
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,17 +25,18 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get(id string) (spec AccessTierInfo, err error)
-	GetName(name string) (spec AccessTierInfo, err error)
-	Create(spec AccessTierPost) (created AccessTierInfo, err error)
-	Update(id string, spec AccessTierPost) (updated AccessTierInfo, err error)
-	Delete(id string) (err error)
-	GetLocalConfig(name string) (spec AccessTierLocalConfig, err error)
-	UpdateLocalConfig(name string, spec AccessTierLocalConfig) (updated AccessTierLocalConfig, err error)
+	Get(ctx context.Context, id string) (spec AccessTierInfo, err error)
+	GetName(ctx context.Context, name string) (spec AccessTierInfo, err error)
+	GetAll(ctx context.Context) (specs []AccessTierInfo, err error)
+	Create(ctx context.Context, spec AccessTierPost) (created AccessTierInfo, err error)
+	Update(ctx context.Context, id string, spec AccessTierPost) (updated AccessTierInfo, err error)
+	Delete(ctx context.Context, id string) (err error)
+	GetLocalConfig(ctx context.Context, name string) (spec AccessTierLocalConfig, err error)
+	UpdateLocalConfig(ctx context.Context, name string, spec AccessTierLocalConfig) (updated AccessTierLocalConfig, err error)
 }
 
-func (a *AccessTier) Get(id string) (spec AccessTierInfo, err error) {
-	resp, err := a.restClient.Read(apiVersion, component, id, "")
+func (a *AccessTier) Get(ctx context.Context, id string) (spec AccessTierInfo, err error) {
+	resp, err := a.restClient.ReadContext(ctx, apiVersion, component, id, "")
 	if err != nil {
 		return
 	}
@@ -46,10 +48,36 @@ func (a *AccessTier) Get(id string) (spec AccessTierInfo, err error) {
 	return j.Data, nil
 }
 
-func (a *AccessTier) GetName(name string) (spec AccessTierInfo, err error) {
+func (a *AccessTier) GetName(ctx context.Context, name string) (spec AccessTierInfo, err error) {
 	v := url.Values{}
 	v.Add("name", name)
-	resp, err := a.restClient.ReadQuery(component, v, fmt.Sprintf("%s/%s", apiVersion, component))
+	accessTiers, err := a.list(ctx, v)
+	if err != nil {
+		return
+	}
+	if len(accessTiers) == 0 {
+		err = fmt.Errorf("access tier with name %s %w", name, restclient.ErrNotFound)
+		return
+	}
+	for _, accessTier := range accessTiers {
+		if accessTier.Name == name {
+			spec = accessTier
+			break
+		}
+	}
+	if spec.Name == "" {
+		err = fmt.Errorf("access tier with name %s %w in results %+v", name, restclient.ErrNotFound, accessTiers)
+	}
+	return
+}
+
+// GetAll returns every access tier in the org
+func (a *AccessTier) GetAll(ctx context.Context) (specs []AccessTierInfo, err error) {
+	return a.list(ctx, url.Values{})
+}
+
+func (a *AccessTier) list(ctx context.Context, v url.Values) (specs []AccessTierInfo, err error) {
+	resp, err := a.restClient.ReadQueryContext(ctx, component, v, fmt.Sprintf("%s/%s", apiVersion, component))
 	if err != nil {
 		return
 	}
@@ -67,23 +95,11 @@ func (a *AccessTier) GetName(name string) (spec AccessTierInfo, err error) {
 	if err != nil {
 		return
 	}
-	if j.Data.Count == 0 {
-		err = fmt.Errorf("access tier with name %s not found", name)
-		return
-	}
-	for _, accessTier := range j.Data.AccessTiers {
-		if accessTier.Name == name {
-			spec = accessTier
-			break
-		}
-	}
-	if spec.Name == "" {
-		err = fmt.Errorf("access tier with name %s not found in results %+v", name, j.Data.AccessTiers)
-	}
+	specs = j.Data.AccessTiers
 	return
 }
 
-func (a *AccessTier) Create(spec AccessTierPost) (created AccessTierInfo, err error) {
+func (a *AccessTier) Create(ctx context.Context, spec AccessTierPost) (created AccessTierInfo, err error) {
 	body, err := json.Marshal(AccessTierPostBody{
 		Kind:       "BanyanAccessTier",
 		APIVersion: "rbac.banyanops.com/v1",
@@ -96,7 +112,7 @@ func (a *AccessTier) Create(spec AccessTierPost) (created AccessTierInfo, err er
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Create(apiVersion, component, body, "")
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, component, body, "")
 	if err != nil {
 		return
 	}
@@ -109,7 +125,7 @@ func (a *AccessTier) Create(spec AccessTierPost) (created AccessTierInfo, err er
 	return
 }
 
-func (a *AccessTier) Update(id string, spec AccessTierPost) (updated AccessTierInfo, err error) {
+func (a *AccessTier) Update(ctx context.Context, id string, spec AccessTierPost) (updated AccessTierInfo, err error) {
 	body, err := json.Marshal(AccessTierPostBody{
 		Kind:       "BanyanAccessTier",
 		APIVersion: "rbac.banyanops.com/v1",
@@ -122,7 +138,7 @@ func (a *AccessTier) Update(id string, spec AccessTierPost) (updated AccessTierI
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Update(apiVersion, component, id, body, "")
+	resp, err := a.restClient.UpdateContext(ctx, apiVersion, component, id, body, "")
 	if err != nil {
 		return
 	}
@@ -134,17 +150,17 @@ func (a *AccessTier) Update(id string, spec AccessTierPost) (updated AccessTierI
 	return j.Data, nil
 }
 
-func (a *AccessTier) Delete(id string) (err error) {
-	err = deleteNetagents(a, id)
+func (a *AccessTier) Delete(ctx context.Context, id string) (err error) {
+	err = deleteNetagents(ctx, a, id)
 	if err != nil {
 		return err
 	}
-	err = a.restClient.Delete(apiVersion, component, id, "")
+	err = a.restClient.DeleteContext(ctx, apiVersion, component, id, "")
 	return
 }
 
-func deleteNetagents(a *AccessTier, id string) (err error) {
-	spec, err := a.Get(id)
+func deleteNetagents(ctx context.Context, a *AccessTier, id string) (err error) {
+	spec, err := a.Get(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -153,7 +169,7 @@ func deleteNetagents(a *AccessTier, id string) (err error) {
 		query := url.Values{}
 		query.Add("CLUSTERNAME", spec.ClusterName)
 		query.Add("HOSTNAME", agent.Hostname)
-		err = a.restClient.DeleteQuery("accesstier", agent.Hostname, query, path)
+		err = a.restClient.DeleteQueryContext(ctx, "accesstier", agent.Hostname, query, path)
 		if err != nil {
 			return fmt.Errorf("error deleting netagent %s from accesstier %s: %s", agent.Hostname, spec.Name, err)
 		}
@@ -161,13 +177,13 @@ func deleteNetagents(a *AccessTier, id string) (err error) {
 	return
 }
 
-func (a *AccessTier) GetLocalConfig(name string) (spec AccessTierLocalConfig, err error) {
+func (a *AccessTier) GetLocalConfig(ctx context.Context, name string) (spec AccessTierLocalConfig, err error) {
 	if name == "" {
 		err = errors.New("need a name to get an accesstier")
 		return
 	}
 	path := fmt.Sprintf("api/v2/access_tier_facing/%s/config", name)
-	resp, err := a.restClient.Read(apiVersion, component, name, path)
+	resp, err := a.restClient.ReadContext(ctx, apiVersion, component, name, path)
 	if err != nil {
 		return
 	}
@@ -179,7 +195,7 @@ func (a *AccessTier) GetLocalConfig(name string) (spec AccessTierLocalConfig, er
 	return j.Data, nil
 }
 
-func (a *AccessTier) UpdateLocalConfig(id string, spec AccessTierLocalConfig) (updated AccessTierLocalConfig, err error) {
+func (a *AccessTier) UpdateLocalConfig(ctx context.Context, id string, spec AccessTierLocalConfig) (updated AccessTierLocalConfig, err error) {
 	path := fmt.Sprintf("api/v2/access_tier/%s/config", id)
 	body, err := json.Marshal(AccessTierLocalConfigSpec{
 		Kind:       "BanyanAccessTierLocalConfig",
@@ -190,7 +206,7 @@ func (a *AccessTier) UpdateLocalConfig(id string, spec AccessTierLocalConfig) (u
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Update(apiVersion, component, id, body, path)
+	resp, err := a.restClient.UpdateContext(ctx, apiVersion, component, id, body, path)
 	if err != nil {
 		return
 	}
@@ -1,38 +1,38 @@
-package accesstier
+package accesstier_test
 
 import (
-	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
-	"github.com/banyansecurity/terraform-banyan-provider/client/testenv"
-	"github.com/stretchr/testify/assert"
+	"context"
 	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/stretchr/testify/assert"
 )
 
-func GetClientForTest() (accessTierClient *AccessTier) {
-	restClient, err := restclient.New(testenv.GetBanyanHostUrl(), testenv.GetApiKey())
+func GetClientForTest() (accessTierClient accesstier.Client) {
+	c, err := testutil.GetClientHolderForTest()
 	if err != nil {
 		return
 	}
-
-	c := AccessTier{restClient: restClient}
-	return &c
+	return c.AccessTier
 }
 
 func TestAccessTierGet(t *testing.T) {
 	type testCase struct {
 		Name string
 
-		AccessTier *AccessTier
+		AccessTier accesstier.Client
 
 		Id string
 
-		ExpectedAtInfo AccessTierInfo
+		ExpectedAtInfo accesstier.AccessTierInfo
 		ExpectedErr    error
 	}
 
 	validate := func(t *testing.T, tc *testCase) {
 		t.Run(tc.Name, func(t *testing.T) {
 
-			actualAtInfo, actualErr := tc.AccessTier.Get(tc.Id)
+			actualAtInfo, actualErr := tc.AccessTier.Get(context.Background(), tc.Id)
 
 			assert.Equal(t, tc.ExpectedAtInfo.Name, actualAtInfo.Name)
 			assert.Equal(t, tc.ExpectedErr, actualErr)
@@ -43,7 +43,7 @@ func TestAccessTierGet(t *testing.T) {
 		Name:       "Get pre-existing",
 		AccessTier: GetClientForTest(),
 		Id:         "b29f24db-fc3a-4eb4-880b-8fb1245e13d3",
-		ExpectedAtInfo: AccessTierInfo{
+		ExpectedAtInfo: accesstier.AccessTierInfo{
 			Name: "at-recreate-1",
 		},
 		ExpectedErr: nil,
@@ -56,20 +56,20 @@ func TestAccessTierCreate(t *testing.T) {
 	type testCase struct {
 		Name string
 
-		AccessTier *AccessTier
+		AccessTier accesstier.Client
 
-		Post AccessTierPost
+		Post accesstier.AccessTierPost
 
-		ExpectedAtInfo AccessTierInfo
+		ExpectedAtInfo accesstier.AccessTierInfo
 		ExpectedErr    error
 	}
 
-	post := AccessTierPost{
+	post := accesstier.AccessTierPost{
 		Name:             "goclient-testcase-create",
 		Address:          "test.somedomain.com",
 		Domains:          []string{"somedomain.com"},
-		TunnelSatellite:  &AccessTierTunnelInfoPost{},
-		TunnelEnduser:    &AccessTierTunnelInfoPost{},
+		TunnelSatellite:  &accesstier.AccessTierTunnelInfoPost{},
+		TunnelEnduser:    &accesstier.AccessTierTunnelInfoPost{},
 		ClusterName:      "tortoise",
 		DisableSnat:      false,
 		SrcNATCIDRRange:  "",
@@ -80,7 +80,7 @@ func TestAccessTierCreate(t *testing.T) {
 
 	validate := func(t *testing.T, tc *testCase) {
 		t.Run(tc.Name, func(t *testing.T) {
-			actualAtInfo, actualErr := tc.AccessTier.Create(tc.Post)
+			actualAtInfo, actualErr := tc.AccessTier.Create(context.Background(), tc.Post)
 
 			assert.Equal(t, tc.ExpectedAtInfo.Name, actualAtInfo.Name)
 			assert.Equal(t, tc.ExpectedAtInfo.Address, actualAtInfo.Address)
@@ -93,7 +93,7 @@ func TestAccessTierCreate(t *testing.T) {
 		Name:       "",
 		AccessTier: GetClientForTest(),
 		Post:       post,
-		ExpectedAtInfo: AccessTierInfo{
+		ExpectedAtInfo: accesstier.AccessTierInfo{
 			Name:     post.Name,
 			Address:  post.Address,
 			APIKeyID: post.ApiKeyId,
@@ -106,22 +106,22 @@ func TestAccessTierGetLocalConfig(t *testing.T) {
 	type testCase struct {
 		Name string
 
-		AccessTier *AccessTier
+		AccessTier accesstier.Client
 
 		N string
 
 		ExpectedErr error
 	}
 
-	expectedShieldAddress := "test.somedomain.com"
-	expectedSiteAddress := "35.247.44.2:1200"
+	expectedShieldAddress := "tortoise.fakeapi:443"
+	expectedSiteAddress := "test.somedomain.com"
 
 	validate := func(t *testing.T, tc *testCase) {
 		t.Run(tc.Name, func(t *testing.T) {
-			actualAtLocalConf, actualErr := tc.AccessTier.GetLocalConfig(tc.N)
+			actualAtLocalConf, actualErr := tc.AccessTier.GetLocalConfig(context.Background(), tc.N)
 
-			assert.Equal(t, expectedShieldAddress, *actualAtLocalConf.BaseParameters.SiteAddress)
-			assert.Equal(t, expectedSiteAddress, *actualAtLocalConf.BaseParameters.ShieldAddress)
+			assert.Equal(t, expectedShieldAddress, *actualAtLocalConf.BaseParameters.ShieldAddress)
+			assert.Equal(t, expectedSiteAddress, *actualAtLocalConf.BaseParameters.SiteAddress)
 			assert.Equal(t, tc.ExpectedErr, actualErr)
 		})
 	}
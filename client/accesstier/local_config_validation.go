@@ -0,0 +1,109 @@
+package accesstier
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParameterError is a parameter of the local config which violates the constraint in its valid struct tag
+type ParameterError struct {
+	// Section is the JSON name of the section, e.g. "miscellaneous"
+	Section string
+	// Parameter is the JSON name of the parameter within its section, e.g. "listen_port"
+	Parameter string
+	Err       error
+}
+
+func (e ParameterError) Error() string {
+	return fmt.Sprintf("%s.%s: %s", e.Section, e.Parameter, e.Err)
+}
+
+func (e ParameterError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks the parameters of the local config against the constraints in their valid struct tags, which are
+// otherwise only enforced by Netagent. It returns every violation, each prefixed with the section and name of the
+// parameter, e.g. "miscellaneous.listen_port".
+func (atlc AccessTierLocalConfig) Validate() error {
+	var errs []error
+	for _, violation := range atlc.Violations() {
+		errs = append(errs, violation)
+	}
+	return errors.Join(errs...)
+}
+
+// Violations returns a ParameterError for every parameter which violates its valid tag, in the order of the struct
+func (atlc AccessTierLocalConfig) Violations() (violations []ParameterError) {
+	v := reflect.ValueOf(atlc)
+	for i := 0; i < v.NumField(); i++ {
+		section := v.Field(i)
+		if section.Kind() != reflect.Pointer || section.IsNil() || section.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		violations = append(violations, validateParameters(jsonName(v.Type().Field(i)), section.Elem())...)
+	}
+	return
+}
+
+// validateParameters checks each set parameter of a section which has a valid tag
+func validateParameters(section string, params reflect.Value) (violations []ParameterError) {
+	for i := 0; i < params.NumField(); i++ {
+		field := params.Type().Field(i)
+		tag := field.Tag.Get("valid")
+		value := params.Field(i)
+		if tag == "" || (value.Kind() == reflect.Pointer && value.IsNil()) {
+			continue
+		}
+		err := validateParameter(tag, reflect.Indirect(value))
+		if err != nil {
+			violations = append(violations, ParameterError{Section: section, Parameter: jsonName(field), Err: err})
+		}
+	}
+	return
+}
+
+// validateParameter checks a value against a valid tag: in(a|b|c) or range(min|max)
+func validateParameter(tag string, value reflect.Value) error {
+	rule, args, ok := strings.Cut(strings.TrimSuffix(tag, ")"), "(")
+	if !ok {
+		return fmt.Errorf("unsupported validation %q", tag)
+	}
+	allowed := strings.Split(args, "|")
+	switch rule {
+	case "in":
+		if !slices.Contains(allowed, fmt.Sprint(value.Interface())) {
+			return fmt.Errorf("%v must be one of %s", value.Interface(), strings.Join(allowed, ", "))
+		}
+	case "range":
+		if len(allowed) != 2 || !value.CanInt() {
+			return fmt.Errorf("unsupported validation %q", tag)
+		}
+		low, err := strconv.ParseInt(allowed[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unsupported validation %q", tag)
+		}
+		high, err := strconv.ParseInt(allowed[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("unsupported validation %q", tag)
+		}
+		if value.Int() < low || value.Int() > high {
+			return fmt.Errorf("%d must be between %d and %d", value.Int(), low, high)
+		}
+	default:
+		return fmt.Errorf("unsupported validation %q", tag)
+	}
+	return nil
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
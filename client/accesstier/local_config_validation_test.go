@@ -0,0 +1,66 @@
+package accesstier
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessTierLocalConfigValidate(t *testing.T) {
+	assert.NoError(t, NewDefaultAccessLocalConfig().Validate())
+	assert.NoError(t, AccessTierLocalConfig{}.Validate())
+
+	lc := AccessTierLocalConfig{
+		LoggingParameters: &LoggingParameters{
+			ConsoleLogLevel: StringPtr("TRACE"),
+			FileLogLevel:    StringPtr("DEBUG"),
+		},
+		MiscellaneousParameters: &MiscellaneousParameters{
+			ListenPort:       IntPtr(80),
+			ListenPortHealth: IntPtr(65535),
+			PublicIPSource:   StringPtr("azure"),
+			CPULimit:         IntPtr(0),
+		},
+		ServiceDiscoveryParameters: &ServiceDiscoveryParameters{
+			ServiceDiscoveryMsgLimit: IntPtr(500),
+		},
+	}
+	err := lc.Validate()
+	assert.EqualError(t, err, `logging.console_log_level: TRACE must be one of ERR, WARN, INFO, DEBUG
+miscellaneous.listen_port: 80 must be between 1024 and 65535
+miscellaneous.public_ip_source: azure must be one of AWS, GCE, default, none
+miscellaneous.cpu_limit: 0 must be between 1 and 100
+service_discovery.service_discovery_msg_limit: 500 must be one of 100, 1000, 5000`)
+	violations := lc.Violations()
+	if assert.Len(t, violations, 5) {
+		assert.Equal(t, "miscellaneous", violations[1].Section)
+		assert.Equal(t, "listen_port", violations[1].Parameter)
+		assert.EqualError(t, violations[1].Err, "80 must be between 1024 and 65535")
+	}
+}
+
+func Test_validateParameter(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{tag: "in(a|b)", value: "b"},
+		{tag: "in(100|1000)", value: 1000},
+		{tag: "range(1|10)", value: 1},
+		{tag: "range(1|10)", value: 11, err: "11 must be between 1 and 10"},
+		{tag: "range(1|10)", value: "5", err: `unsupported validation "range(1|10)"`},
+		{tag: "range(1)", value: 5, err: `unsupported validation "range(1)"`},
+		{tag: "email", value: "x", err: `unsupported validation "email"`},
+		{tag: "matches(^a$)", value: "a", err: `unsupported validation "matches(^a$)"`},
+	}
+	for _, tt := range tests {
+		err := validateParameter(tt.tag, reflect.ValueOf(tt.value))
+		if tt.err == "" {
+			assert.NoError(t, err, tt.tag)
+			continue
+		}
+		assert.EqualError(t, err, tt.err, tt.tag)
+	}
+}
@@ -2,6 +2,7 @@ package accesstiergroup
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,21 +28,22 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Create(spec AccessTierGroupPost) (created AccessTierGroupResponse, err error)
-	Get(id string) (atg AccessTierGroupResponse, err error)
-	Delete(id string) (err error)
-	Update(id string, post AccessTierGroupPost) (updatedApiKey AccessTierGroupResponse, err error)
-	GetName(name string) (spec AccessTierGroupResponse, err error)
-	AttachAccessTiers(groupID string, ats AccessTierList) (attachedATs []string, err error)
-	DetachAccessTiers(groupID string, ats AccessTierList) (detachedATs []string, err error)
+	Create(ctx context.Context, spec AccessTierGroupPost) (created AccessTierGroupResponse, err error)
+	Get(ctx context.Context, id string) (atg AccessTierGroupResponse, err error)
+	Delete(ctx context.Context, id string) (err error)
+	Update(ctx context.Context, id string, post AccessTierGroupPost) (updatedApiKey AccessTierGroupResponse, err error)
+	GetName(ctx context.Context, name string) (spec AccessTierGroupResponse, err error)
+	GetAll(ctx context.Context) (atgs []AccessTierGroupResponse, err error)
+	AttachAccessTiers(ctx context.Context, groupID string, ats AccessTierList) (attachedATs []string, err error)
+	DetachAccessTiers(ctx context.Context, groupID string, ats AccessTierList) (detachedATs []string, err error)
 }
 
-func (a *AccessTierGroup) Create(atgInfo AccessTierGroupPost) (created AccessTierGroupResponse, err error) {
+func (a *AccessTierGroup) Create(ctx context.Context, atgInfo AccessTierGroupPost) (created AccessTierGroupResponse, err error) {
 	body, err := json.Marshal(atgInfo)
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Create(apiVersion, component, body, "")
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, component, body, "")
 	if err != nil {
 		return
 	}
@@ -54,8 +56,8 @@ func (a *AccessTierGroup) Create(atgInfo AccessTierGroupPost) (created AccessTie
 	return
 }
 
-func (a *AccessTierGroup) Get(id string) (atg AccessTierGroupResponse, err error) {
-	resp, err := a.restClient.Read(apiVersion, component, id, "")
+func (a *AccessTierGroup) Get(ctx context.Context, id string) (atg AccessTierGroupResponse, err error) {
+	resp, err := a.restClient.ReadContext(ctx, apiVersion, component, id, "")
 	if err != nil {
 		return
 	}
@@ -67,12 +69,12 @@ func (a *AccessTierGroup) Get(id string) (atg AccessTierGroupResponse, err error
 	return j.Data, nil
 }
 
-func (a *AccessTierGroup) Update(id string, post AccessTierGroupPost) (updatedApiKey AccessTierGroupResponse, err error) {
+func (a *AccessTierGroup) Update(ctx context.Context, id string, post AccessTierGroupPost) (updatedApiKey AccessTierGroupResponse, err error) {
 	body, err := json.Marshal(post)
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Update(apiVersion, component, id, body, "")
+	resp, err := a.restClient.UpdateContext(ctx, apiVersion, component, id, body, "")
 	if err != nil {
 		return
 	}
@@ -80,14 +82,44 @@ func (a *AccessTierGroup) Update(id string, post AccessTierGroupPost) (updatedAp
 	return
 }
 
-func (a *AccessTierGroup) Delete(id string) (err error) {
-	return a.restClient.Delete(apiVersion, component, id, "")
+func (a *AccessTierGroup) Delete(ctx context.Context, id string) (err error) {
+	return a.restClient.DeleteContext(ctx, apiVersion, component, id, "")
 }
 
-func (a *AccessTierGroup) GetName(name string) (atg AccessTierGroupResponse, err error) {
+func (a *AccessTierGroup) GetName(ctx context.Context, name string) (atg AccessTierGroupResponse, err error) {
 	v := url.Values{}
 	v.Add("access_tier_group_name", name)
-	resp, err := a.restClient.ReadQuery(component, v, fmt.Sprintf("%s/%s", apiVersion, component))
+	accessTierGroups, err := a.list(ctx, v)
+	if err != nil {
+		return
+	}
+
+	if len(accessTierGroups) == 0 {
+		err = fmt.Errorf("access tier group with name %s %w", name, restclient.ErrNotFound)
+		return
+	}
+
+	for _, accessTierGroup := range accessTierGroups {
+		if accessTierGroup.Name == name {
+			atg = accessTierGroup
+			break
+		}
+	}
+
+	if atg.Name == "" {
+		err = fmt.Errorf("access tier group with name %s %w in results %+v", name, restclient.ErrNotFound, accessTierGroups)
+	}
+
+	return
+}
+
+// GetAll returns every access tier group in the org
+func (a *AccessTierGroup) GetAll(ctx context.Context) (atgs []AccessTierGroupResponse, err error) {
+	return a.list(ctx, url.Values{})
+}
+
+func (a *AccessTierGroup) list(ctx context.Context, v url.Values) (accessTierGroups []AccessTierGroupResponse, err error) {
+	resp, err := a.restClient.ReadQueryContext(ctx, component, v, fmt.Sprintf("%s/%s", apiVersion, component))
 	if err != nil {
 		return
 	}
@@ -109,32 +141,17 @@ func (a *AccessTierGroup) GetName(name string) (atg AccessTierGroupResponse, err
 		return
 	}
 
-	if response.Data.Count == 0 {
-		err = fmt.Errorf("access tier with name %s not found", name)
-		return
-	}
-
-	for _, accessTierGroup := range response.Data.AccessTierGroups {
-		if accessTierGroup.Name == name {
-			atg = accessTierGroup
-			break
-		}
-	}
-
-	if atg.Name == "" {
-		err = fmt.Errorf("access tier group with name %s not found in results %+v", name, response.Data.AccessTierGroups)
-	}
-
+	accessTierGroups = response.Data.AccessTierGroups
 	return
 }
 
-func (a *AccessTierGroup) AttachAccessTiers(groupID string, ats AccessTierList) (attachedATs []string, err error) {
+func (a *AccessTierGroup) AttachAccessTiers(ctx context.Context, groupID string, ats AccessTierList) (attachedATs []string, err error) {
 	body, err := json.Marshal(ats)
 	if err != nil {
 		return
 	}
 	attachURL := fmt.Sprintf("/%s/%s/attach", component, groupID)
-	resp, err := a.restClient.Create(apiVersion, attachURL, body, "")
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, attachURL, body, "")
 	if err != nil {
 		return
 	}
@@ -148,22 +165,22 @@ func (a *AccessTierGroup) AttachAccessTiers(groupID string, ats AccessTierList)
 	return
 }
 
-func (a *AccessTierGroup) DetachAccessTiers(groupID string, ats AccessTierList) (detachedATs []string, err error) {
+func (a *AccessTierGroup) DetachAccessTiers(ctx context.Context, groupID string, ats AccessTierList) (detachedATs []string, err error) {
 	body, err := json.Marshal(ats)
 	if err != nil {
 		return
 	}
 	detachURL := fmt.Sprintf("%s/%s/%s/detach", apiVersion, component, groupID)
-	req, err := a.restClient.NewRequest(http.MethodDelete, detachURL, bytes.NewBuffer(body))
+	req, err := a.restClient.NewRequestWithContext(ctx, http.MethodDelete, detachURL, bytes.NewBuffer(body))
 	if err != nil {
 		return
 	}
 
-	HTTPClient := &http.Client{}
-	resp, err := HTTPClient.Do(req)
+	resp, err := a.restClient.Do(req)
 	if err != nil {
 		return
 	}
+	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		err = fmt.Errorf("error occurred while detaching access tier from group")
@@ -1,6 +1,7 @@
 package accesstiergroup_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
@@ -35,7 +36,7 @@ func Test_Create(t *testing.T) {
 
 	assert.NoError(t, err, "Expected to not get an error here")
 
-	got, err := client.AccessTierGroup.Create(want)
+	got, err := client.AccessTierGroup.Create(context.Background(), want)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -50,7 +51,7 @@ func Test_Get(t *testing.T) {
 
 	assert.NoError(t, err, "Expected to not get an error here")
 
-	got, err := client.AccessTierGroup.GetName(want.Name)
+	got, err := client.AccessTierGroup.GetName(context.Background(), want.Name)
 
 	assert.NoError(t, err, "expected no error here")
 	assert.Equal(t, got.Name, want.Name)
@@ -61,12 +62,12 @@ func Test_Delete(t *testing.T) {
 
 	assert.NoError(t, err, "Expected to not get an error here")
 
-	data, err := client.AccessTierGroup.Get(want.Name)
+	data, err := client.AccessTierGroup.GetName(context.Background(), want.Name)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	err = client.ApiKey.Delete(data.ID)
+	err = client.AccessTierGroup.Delete(context.Background(), data.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1,15 +1,17 @@
 package oidcsettings
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/pkg/errors"
 	"io"
+	"net/http"
 )
 
 type Client interface {
-	Get() (Spec, error)
+	Get(ctx context.Context) (Spec, error)
 }
 
 func NewClient(restClient *restclient.Client) Client {
@@ -33,10 +35,10 @@ type Admin struct {
 	restClient *restclient.Client
 }
 
-func (a Admin) Get() (oidcSettings Spec, err error) {
+func (a Admin) Get(ctx context.Context) (oidcSettings Spec, err error) {
 	path := "api/v1/oidc_settings"
 
-	request, _ := a.restClient.Get(path)
+	request, _ := a.restClient.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 
 	// initiate request for response
 	response, err := a.restClient.Do(request)
@@ -1,22 +1,20 @@
 package orgidpconfig
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"html"
-	"io"
+	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
-
-	"github.com/pkg/errors"
 )
 
 // Clienter only supports OIDC currently
 type Clienter interface {
-	Get() (Spec, error)
-	CreateOrUpdate(Spec) error
+	Get(ctx context.Context) (Spec, error)
+	CreateOrUpdate(ctx context.Context, spec Spec) error
 	// TBD if this is necessary since it's tough to delete org wide things like this. I guess we can just use creat or update to set values to essentially empty values...
 	// Delete() error
 }
@@ -51,10 +49,10 @@ type IdpConfig struct {
 }
 
 // Get GetOrfIdpConfig returns back the configuration for an organizations IdP
-func (c *OrgIdpConfig) Get() (orgIdpConfig Spec, err error) {
+func (c *OrgIdpConfig) Get(ctx context.Context) (orgIdpConfig Spec, err error) {
 	path := "api/v1/user_org_details"
 
-	request, err := c.restClient.Get(path)
+	request, err := c.restClient.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return
 	}
@@ -63,13 +61,7 @@ func (c *OrgIdpConfig) Get() (orgIdpConfig Spec, err error) {
 	if err != nil {
 		return
 	}
-	if response.StatusCode != 200 {
-		err = errors.New(fmt.Sprintf("unsuccessful, got status code %q for request: %+v with response: %+v", response.Status, request, response))
-		return
-	}
-
-	defer response.Body.Close()
-	responseData, err := io.ReadAll(response.Body)
+	responseData, err := restclient.HandleResponse(response)
 	if err != nil {
 		return
 	}
@@ -79,12 +71,15 @@ func (c *OrgIdpConfig) Get() (orgIdpConfig Spec, err error) {
 		return
 	}
 	orgIdpConfigJson.IdpConfig = html.UnescapeString(orgIdpConfigJson.IdpConfig)
-	var idpConfig IdpConfig
-	err = json.Unmarshal([]byte(orgIdpConfigJson.IdpConfig), &idpConfig)
-	if err != nil {
-		return
+	// an org which has never been configured has no IdP config at all
+	if orgIdpConfigJson.IdpConfig != "" {
+		var idpConfig IdpConfig
+		err = json.Unmarshal([]byte(orgIdpConfigJson.IdpConfig), &idpConfig)
+		if err != nil {
+			return
+		}
+		orgIdpConfig.IdpConfig = idpConfig
 	}
-	orgIdpConfig.IdpConfig = idpConfig
 	orgIdpConfig.IdpName = orgIdpConfigJson.IdpName
 	orgIdpConfig.IdpProtocol = orgIdpConfigJson.IdpProtocol
 
@@ -92,7 +87,7 @@ func (c *OrgIdpConfig) Get() (orgIdpConfig Spec, err error) {
 }
 
 // CreateOrUpdate CreateUpdateOrgIdpConfig creates or updates the orgs IdP
-func (c *OrgIdpConfig) CreateOrUpdate(orgIdpConfig Spec) (err error) {
+func (c *OrgIdpConfig) CreateOrUpdate(ctx context.Context, orgIdpConfig Spec) (err error) {
 	path := "api/v1/update_org"
 
 	body, err := mapToFormEncodedOrgIdpConfigBody(orgIdpConfig)
@@ -100,7 +95,7 @@ func (c *OrgIdpConfig) CreateOrUpdate(orgIdpConfig Spec) (err error) {
 		return
 	}
 
-	request, err := c.restClient.NewRequest("POST", path, strings.NewReader(body))
+	request, err := c.restClient.NewRequestWithContext(ctx, "POST", path, strings.NewReader(body))
 	if err != nil {
 		return
 	}
@@ -111,11 +106,7 @@ func (c *OrgIdpConfig) CreateOrUpdate(orgIdpConfig Spec) (err error) {
 	if err != nil {
 		return
 	}
-	if response.StatusCode != 200 {
-		err = errors.New(fmt.Sprintf("unsuccessful, got status code %q for request: %+v with response: %+v", response.Status, request, response))
-		return
-	}
-
+	_, err = restclient.HandleResponse(response)
 	return
 }
 
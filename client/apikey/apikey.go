@@ -1,6 +1,7 @@
 package apikey
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
@@ -24,14 +25,15 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get(id string) (apikey Data, err error)
-	Create(post Post) (createdApiKey Data, err error)
-	Update(id string, post Post) (updatedApiKey Data, err error)
-	Delete(id string) (err error)
+	Get(ctx context.Context, id string) (apikey Data, err error)
+	Create(ctx context.Context, post Post) (createdApiKey Data, err error)
+	Update(ctx context.Context, id string, post Post) (updatedApiKey Data, err error)
+	Delete(ctx context.Context, id string) (err error)
+	GetAll(ctx context.Context) (apikeys []Data, err error)
 }
 
-func (k *ApiKey) Get(id string) (apikey Data, err error) {
-	resp, err := k.restClient.Read(apiVersion, component, id, "")
+func (k *ApiKey) Get(ctx context.Context, id string) (apikey Data, err error) {
+	resp, err := k.restClient.ReadContext(ctx, apiVersion, component, id, "")
 	if err != nil {
 		return
 	}
@@ -43,9 +45,9 @@ func (k *ApiKey) Get(id string) (apikey Data, err error) {
 	return j.Data, nil
 }
 
-func (k *ApiKey) Create(post Post) (apikey Data, err error) {
+func (k *ApiKey) Create(ctx context.Context, post Post) (apikey Data, err error) {
 	// check if key exists already
-	responseJSON, err := getAll(k)
+	responseJSON, err := getAll(ctx, k)
 	if err != nil {
 		return
 	}
@@ -58,7 +60,7 @@ func (k *ApiKey) Create(post Post) (apikey Data, err error) {
 	if err != nil {
 		return
 	}
-	response, err := k.restClient.Create(apiVersion, component, body, "")
+	response, err := k.restClient.CreateContext(ctx, apiVersion, component, body, "")
 	if err != nil {
 		return
 	}
@@ -68,12 +70,12 @@ func (k *ApiKey) Create(post Post) (apikey Data, err error) {
 	return
 }
 
-func (k *ApiKey) Update(id string, post Post) (updatedApiKey Data, err error) {
+func (k *ApiKey) Update(ctx context.Context, id string, post Post) (updatedApiKey Data, err error) {
 	body, err := json.Marshal(post)
 	if err != nil {
 		return
 	}
-	resp, err := k.restClient.Update(apiVersion, component, id, body, "")
+	resp, err := k.restClient.UpdateContext(ctx, apiVersion, component, id, body, "")
 	if err != nil {
 		return
 	}
@@ -81,17 +83,26 @@ func (k *ApiKey) Update(id string, post Post) (updatedApiKey Data, err error) {
 	return
 }
 
-func (k *ApiKey) Delete(id string) (err error) {
-	return k.restClient.Delete(apiVersion, component, id, "")
+func (k *ApiKey) Delete(ctx context.Context, id string) (err error) {
+	return k.restClient.DeleteContext(ctx, apiVersion, component, id, "")
 }
 
-func getAll(k *ApiKey) (responseJSON Response, err error) {
+// GetAll returns every API key in the org
+func (k *ApiKey) GetAll(ctx context.Context) (apikeys []Data, err error) {
+	responseJSON, err := getAll(ctx, k)
+	if err != nil {
+		return
+	}
+	return responseJSON.Data, nil
+}
+
+func getAll(ctx context.Context, k *ApiKey) (responseJSON Response, err error) {
 	path := fmt.Sprintf("%s/%s", apiVersion, component)
 	myUrl, err := url.Parse(path)
 	if err != nil {
 		return
 	}
-	response, err := k.restClient.DoGet(myUrl.String())
+	response, err := k.restClient.DoGetContext(ctx, myUrl.String())
 	if err != nil {
 		return
 	}
@@ -118,7 +129,7 @@ func findByName(name string, responseJSON Response) (apikey Data, err error) {
 		}
 	}
 	if apikey.ID == "" {
-		err = errors.Errorf("API key not found: %s", name)
+		err = fmt.Errorf("API key %w: %s", restclient.ErrNotFound, name)
 	}
 	return
 }
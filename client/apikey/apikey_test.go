@@ -1,61 +1,57 @@
 package apikey_test
 
 import (
+	"context"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
 	"github.com/banyansecurity/terraform-banyan-provider/client/apikey"
 	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"github.com/stretchr/testify/require"
 )
 
+var want = apikey.Post{
+	Name:        "goclient-test",
+	Description: "goclient-test",
+	Scope:       "satellite",
+}
+
+// createKey creates an API key for a single test, deleting it when the test ends unless the test already did
+func createKey(t *testing.T) (*client.Holder, apikey.Data) {
+	c, err := testutil.GetClientHolderForTest()
+	require.NoError(t, err, "Expected to not get an error here")
+	created, err := c.ApiKey.Create(context.Background(), want)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if _, err := c.ApiKey.Get(context.Background(), created.ID); err == nil {
+			_ = c.ApiKey.Delete(context.Background(), created.ID)
+		}
+	})
+	return c, created
+}
+
 func Test_Create(t *testing.T) {
-	want := apikey.Post{
-		Name:        "goclient-test",
-		Description: "goclient-test",
-		Scope:       "satellite",
-	}
-	client, err := testutil.GetClientHolderForTest()
-	assert.NoError(t, err, "Expected to not get an error here")
-	got, err := client.ApiKey.Create(want)
-	if err != nil {
-		t.Fatal(err)
-	}
-	assert.Equal(t, got.Name, want.Name)
-	assert.Equal(t, got.Description, want.Description)
-	assert.Equal(t, got.Scope, want.Scope)
+	_, created := createKey(t)
+	assert.Equal(t, want.Name, created.Name)
+	assert.Equal(t, want.Description, created.Description)
+	assert.Equal(t, want.Scope, created.Scope)
 }
 
 func Test_Get(t *testing.T) {
-	want := apikey.Post{
-		Name:        "goclient-test",
-		Description: "goclient-test",
-		Scope:       "satellite",
-	}
-	client, err := testutil.GetClientHolderForTest()
-	assert.NoError(t, err, "Expected to not get an error here")
-	got, err := client.ApiKey.Create(want)
+	c, created := createKey(t)
+	got, err := c.ApiKey.Get(context.Background(), created.ID)
 	assert.NoError(t, err, "expected no error here")
-	assert.Equal(t, got.Name, want.Name)
+	assert.Equal(t, want.Name, got.Name)
 }
 
 func Test_Delete(t *testing.T) {
-	want := apikey.Data{
-		ID:          "",
-		OrgID:       "",
-		Name:        "goclient-test",
-		Secret:      "",
-		Description: "goclient-test",
-		Scope:       "satellite",
-		CreatedBy:   "",
-		UpdatedBy:   "",
-	}
-	client, err := testutil.GetClientHolderForTest()
-	assert.NoError(t, err, "Expected to not get an error here")
-	data, err := client.ApiKey.Get(want.Name)
-	if err != nil {
-		t.Fatal(err)
-	}
-	err = client.ApiKey.Delete(data.Name)
-	if err != nil {
-		t.Fatal(err)
-	}
+	c, created := createKey(t)
+	data, err := c.ApiKey.Get(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, want.Name, data.Name)
+	err = c.ApiKey.Delete(context.Background(), data.ID)
+	require.NoError(t, err)
+	_, err = c.ApiKey.Get(context.Background(), data.ID)
+	assert.Error(t, err, "the key is gone")
 }
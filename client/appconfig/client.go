@@ -1,6 +1,7 @@
 package appconfig
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
@@ -23,17 +24,17 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Create(appConfig AppConfigRequest) (resp AppConfigResponse, err error)
-	Get(id string) (resp AppConfigResponse, err error)
-	Update(appConfig AppConfigRequest) (resp AppConfigResponse, err error)
+	Create(ctx context.Context, appConfig AppConfigRequest) (resp AppConfigResponse, err error)
+	Get(ctx context.Context, id string) (resp AppConfigResponse, err error)
+	Update(ctx context.Context, appConfig AppConfigRequest) (resp AppConfigResponse, err error)
 }
 
-func (a *AppConfig) Create(appConfig AppConfigRequest) (created AppConfigResponse, err error) {
+func (a *AppConfig) Create(ctx context.Context, appConfig AppConfigRequest) (created AppConfigResponse, err error) {
 	body, err := json.Marshal(appConfig)
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Create(apiVersion, component, body, path)
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -46,8 +47,8 @@ func (a *AppConfig) Create(appConfig AppConfigRequest) (created AppConfigRespons
 	return
 }
 
-func (a *AppConfig) Get(id string) (get AppConfigResponse, err error) {
-	resp, err := a.restClient.Read(apiVersion, component, id, path)
+func (a *AppConfig) Get(ctx context.Context, id string) (get AppConfigResponse, err error) {
+	resp, err := a.restClient.ReadContext(ctx, apiVersion, component, id, path)
 	if err != nil {
 		return
 	}
@@ -60,12 +61,12 @@ func (a *AppConfig) Get(id string) (get AppConfigResponse, err error) {
 	return
 }
 
-func (a *AppConfig) Update(appConfig AppConfigRequest) (updated AppConfigResponse, err error) {
+func (a *AppConfig) Update(ctx context.Context, appConfig AppConfigRequest) (updated AppConfigResponse, err error) {
 	body, err := json.Marshal(appConfig)
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Update(apiVersion, component, "", body, path)
+	resp, err := a.restClient.UpdateContext(ctx, apiVersion, component, "", body, path)
 	if err != nil {
 		return
 	}
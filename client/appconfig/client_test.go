@@ -1,6 +1,7 @@
 package appconfig_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/appconfig"
@@ -18,18 +19,20 @@ var req = appconfig.AppConfigRequest{
 	NRPTConfig: &trueValue,
 }
 
+var created appconfig.AppConfigResponse
+
 func Test_Create(t *testing.T) {
 
 	client, err := testutil.GetClientHolderForTest()
 
 	assert.NoError(t, err, "Expected to not get an error here")
 
-	got, err := client.AppConfig.Create(req)
+	created, err = client.AppConfig.Create(context.Background(), req)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	assert.Equal(t, got.Data.NRPTConfig, want.NRPTConfig)
+	assert.Equal(t, created.Data.NRPTConfig, want.NRPTConfig)
 }
 
 func Test_Get(t *testing.T) {
@@ -37,7 +40,7 @@ func Test_Get(t *testing.T) {
 
 	assert.NoError(t, err, "Expected to not get an error here")
 
-	got, err := client.AppConfig.Get("")
+	got, err := client.AppConfig.Get(context.Background(), created.Data.ID)
 
 	assert.NoError(t, err, "expected no error here")
 	assert.Equal(t, got.Data.NRPTConfig, want.NRPTConfig)
@@ -1,7 +1,7 @@
 package client
 
 import (
-	"log"
+	"fmt"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
 	"github.com/banyansecurity/terraform-banyan-provider/client/accesstiergroup"
@@ -40,9 +40,15 @@ type Holder struct {
 
 // NewClientHolder returns a new client which is used to perform operations on all Banyan resources.
 func NewClientHolder(hostUrl string, apiKey string) (client *Holder, err error) {
-	restClient, err := restclient.New(hostUrl, apiKey)
+	return NewClientHolderWithOptions(hostUrl, apiKey, restclient.DefaultOptions())
+}
+
+// NewClientHolderWithOptions returns a new client using the given options for the underlying rest client.
+func NewClientHolderWithOptions(hostUrl string, apiKey string, opts restclient.Options) (client *Holder, err error) {
+	restClient, err := restclient.NewWithOptions(hostUrl, apiKey, opts)
 	if err != nil {
-		log.Fatalf("could not create client %s", err)
+		err = fmt.Errorf("could not create client: %w", err)
+		return
 	}
 	c := Holder{
 		Service:          service.NewClient(restClient),
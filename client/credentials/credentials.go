@@ -0,0 +1,213 @@
+// Package credentials loads Banyan API credentials from a shared profiles file.
+//
+// The file holds one section per profile in INI or TOML syntax:
+//
+//	[default]
+//	host    = "https://net.banyanops.com/"
+//	api_key = "..."
+//
+//	[profile staging]
+//	host    = https://staging.example.com/
+//	api_key = ...
+//
+// Values may be bare, double quoted (with escapes) or single quoted. Lines starting with # or ; are comments.
+package credentials
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultProfile is the profile used when none is given
+const DefaultProfile = "default"
+
+// ErrProfileNotFound is returned by Load when the file does not contain the requested profile
+var ErrProfileNotFound = errors.New("profile not found")
+
+// Profile is a named set of credentials
+type Profile struct {
+	Name   string
+	Host   string
+	APIKey string
+}
+
+// DefaultPath returns the location of the shared credentials file, ~/.banyan/credentials
+func DefaultPath() (path string, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	path = filepath.Join(home, ".banyan", "credentials")
+	return
+}
+
+// Load reads the file at path and returns the profile with the given name
+func Load(path string, name string) (profile Profile, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	profiles, err := Parse(f)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+		return
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		err = fmt.Errorf("%s: %q %w", path, name, ErrProfileNotFound)
+	}
+	return
+}
+
+// Parse reads every profile from r
+func Parse(r io.Reader) (profiles map[string]Profile, err error) {
+	profiles = make(map[string]Profile)
+	scanner := bufio.NewScanner(r)
+	current := ""
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			current, err = parseSection(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			if _, ok := profiles[current]; !ok {
+				profiles[current] = Profile{Name: current}
+			}
+			continue
+		}
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if current == "" {
+			return nil, fmt.Errorf("line %d: %s is not inside a [profile] section", lineNumber, strings.TrimSpace(key))
+		}
+		value, err = parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		profile := profiles[current]
+		switch strings.TrimSpace(key) {
+		case "host":
+			profile.Host = value
+		case "api_key":
+			profile.APIKey = value
+		}
+		profiles[current] = profile
+	}
+	err = scanner.Err()
+	return
+}
+
+// parseSection returns the profile name of a [name], [profile name] or ["name"] header
+func parseSection(line string) (name string, err error) {
+	if !strings.HasSuffix(line, "]") {
+		return "", fmt.Errorf("unterminated section header %s", line)
+	}
+	name = strings.TrimSpace(line[1 : len(line)-1])
+	if strings.HasPrefix(name, "profile ") {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "profile "))
+	}
+	if strings.HasPrefix(name, `"`) || strings.HasPrefix(name, "'") {
+		name, err = parseValue(name)
+		if err != nil {
+			return
+		}
+	}
+	if name == "" {
+		err = errors.New("empty profile name")
+	}
+	return
+}
+
+// parseValue unquotes a TOML style string, or strips a trailing comment from a bare INI value
+func parseValue(raw string) (value string, err error) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		end := closingQuote(raw)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string %s", raw)
+		}
+		return strconv.Unquote(raw[:end+1])
+	case strings.HasPrefix(raw, "'"):
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated string %s", raw)
+		}
+		return raw[1 : end+1], nil
+	}
+	for _, marker := range []string{" #", " ;", "\t#", "\t;"} {
+		if i := strings.Index(raw, marker); i >= 0 {
+			raw = raw[:i]
+		}
+	}
+	return strings.TrimSpace(raw), nil
+}
+
+// closingQuote returns the index of the double quote ending the string starting at s[0]
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// Resolve applies the credential precedence used by the provider. When both host and apiKey are given they
+// always win. Otherwise an explicitly requested profile supplies host and api key together, so that a host
+// from one source is never paired with a key from another; without one, any value still missing is read from
+// the default profile. When neither path nor profile is given the default profile in DefaultPath is used if it
+// exists; an explicitly requested file or profile which cannot be found is an error.
+func Resolve(host string, apiKey string, path string, profile string) (resolvedHost string, resolvedAPIKey string, err error) {
+	resolvedHost, resolvedAPIKey = host, apiKey
+	if host != "" && apiKey != "" {
+		return
+	}
+	explicitProfile := profile != ""
+	explicit := path != "" || explicitProfile
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	if path == "" {
+		path, err = DefaultPath()
+		if err != nil {
+			if !explicit {
+				err = nil
+			}
+			return
+		}
+	}
+	p, err := Load(path, profile)
+	if err != nil {
+		if !explicit && (errors.Is(err, os.ErrNotExist) || errors.Is(err, ErrProfileNotFound)) {
+			err = nil
+		}
+		return
+	}
+	if explicitProfile {
+		resolvedHost, resolvedAPIKey = p.Host, p.APIKey
+		return
+	}
+	if resolvedHost == "" {
+		resolvedHost = p.Host
+	}
+	if resolvedAPIKey == "" {
+		resolvedAPIKey = p.APIKey
+	}
+	return
+}
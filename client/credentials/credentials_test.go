@@ -0,0 +1,134 @@
+package credentials_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sample = `
+# shared Banyan credentials
+[default]
+host    = "https://net.banyanops.com/"
+api_key = "default-key"
+
+; INI style profile with bare values
+[profile staging]
+host = https://staging.example.com/   # trailing comment
+api_key = staging-key
+
+["quoted.name"]
+host = 'https://literal.example.com/'
+api_key = "escaped\"key"
+unknown = ignored
+`
+
+func Test_Parse(t *testing.T) {
+	profiles, err := credentials.Parse(strings.NewReader(sample))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]credentials.Profile{
+		"default":     {Name: "default", Host: "https://net.banyanops.com/", APIKey: "default-key"},
+		"staging":     {Name: "staging", Host: "https://staging.example.com/", APIKey: "staging-key"},
+		"quoted.name": {Name: "quoted.name", Host: "https://literal.example.com/", APIKey: `escaped"key`},
+	}, profiles)
+}
+
+func Test_ParseErrors(t *testing.T) {
+	for name, input := range map[string]string{
+		"key outside section":  "api_key = abc",
+		"missing equals":       "[default]\napi_key",
+		"unterminated section": "[default",
+		"empty section":        "[]",
+		"unterminated string":  "[default]\napi_key = \"abc",
+		"unterminated literal": "[default]\napi_key = 'abc",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := credentials.Parse(strings.NewReader(input))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "line ")
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(sample), 0600))
+
+	profile, err := credentials.Load(path, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "staging-key", profile.APIKey)
+
+	_, err = credentials.Load(path, "missing")
+	assert.True(t, errors.Is(err, credentials.ErrProfileNotFound), "got %v", err)
+
+	_, err = credentials.Load(filepath.Join(t.TempDir(), "nope"), credentials.DefaultProfile)
+	assert.True(t, errors.Is(err, fs.ErrNotExist), "got %v", err)
+}
+
+func Test_DefaultPath(t *testing.T) {
+	t.Setenv("HOME", "/home/banyan")
+	path, err := credentials.DefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/banyan", ".banyan", "credentials"), path)
+}
+
+func Test_Resolve(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(sample), 0600))
+
+	// explicit values win over the profile
+	host, key, err := credentials.Resolve("https://explicit/", "explicit-key", path, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "https://explicit/", host)
+	assert.Equal(t, "explicit-key", key)
+
+	// an explicit profile supplies host and key together unless both are given
+	host, key, err = credentials.Resolve("https://explicit/", "", path, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com/", host)
+	assert.Equal(t, "staging-key", key)
+	host, key, err = credentials.Resolve("", "explicit-key", path, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com/", host)
+	assert.Equal(t, "staging-key", key)
+
+	// without a profile, missing values are filled from the default profile
+	host, key, err = credentials.Resolve("https://explicit/", "", path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://explicit/", host)
+	assert.Equal(t, "default-key", key)
+
+	// the default profile is used when only the file is given
+	host, key, err = credentials.Resolve("", "", path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://net.banyanops.com/", host)
+	assert.Equal(t, "default-key", key)
+
+	// an explicitly requested profile must exist
+	_, _, err = credentials.Resolve("", "", path, "missing")
+	assert.True(t, errors.Is(err, credentials.ErrProfileNotFound), "got %v", err)
+
+	// a missing default file is ignored unless a profile was requested
+	host, key, err = credentials.Resolve("", "", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, host)
+	assert.Empty(t, key)
+	_, _, err = credentials.Resolve("", "", "", "staging")
+	assert.True(t, errors.Is(err, fs.ErrNotExist), "got %v", err)
+
+	// the default file is read from the home directory
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".banyan"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".banyan", "credentials"), []byte(sample), 0600))
+	_, key, err = credentials.Resolve("", "", "", "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "staging-key", key)
+}
@@ -0,0 +1,260 @@
+// Package fakeapi provides an in-memory stand-in for the Banyan API which is used to run the client
+// and provider tests without a live tenant.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstiergroup"
+	"github.com/banyansecurity/terraform-banyan-provider/client/apikey"
+	"github.com/banyansecurity/terraform-banyan-provider/client/appconfig"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policyattachment"
+	"github.com/banyansecurity/terraform-banyan-provider/client/registereddomain"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+	"github.com/banyansecurity/terraform-banyan-provider/client/satellite"
+	"github.com/banyansecurity/terraform-banyan-provider/client/scim"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/servicetunnel"
+	"github.com/hashicorp/go-uuid"
+)
+
+// DefaultCluster is the shield returned by shield_config on a new Server
+const DefaultCluster = "cluster1"
+
+// OrgID is the org every object created on the Server belongs to
+const OrgID = "fakeapi-org"
+
+// Server is a stateful httptest server implementing the subset of the Banyan API used by the client packages
+type Server struct {
+	*httptest.Server
+
+	mu                sync.Mutex
+	shields           []string
+	services          map[string]service.GetServicesJson
+	policies          map[string]policy.GetPolicy
+	attachments       map[string]policyattachment.GetBody
+	roles             map[string]role.GetRole
+	apiKeys           map[string]apikey.Data
+	accessTiers       map[string]accesstier.AccessTierInfo
+	localConfigs      map[string]accesstier.AccessTierLocalConfig
+	accessTierGroups  map[string]accesstiergroup.AccessTierGroupResponse
+	satellites        map[string]satellite.SatelliteTunnelConfig
+	serviceTunnels    map[string]servicetunnel.ServiceTunnelInfoResponse
+	tunnelPolicies    map[string]servicetunnel.GetPolicyAttachmentInfo
+	registeredDomains map[string]registereddomain.RegisteredDomainInfo
+	challenges        map[string]registereddomain.RegisteredDomainChallengeInfo
+	scimEnabled       bool
+	scimTokens        []scim.TokenInfo
+	appConfig         *appconfig.AppConfigRecord
+	orgDetails        orgDetails
+}
+
+// New starts a new Server with a single shield named DefaultCluster. Callers must Close it.
+func New() *Server {
+	s := &Server{
+		shields:           []string{DefaultCluster},
+		services:          map[string]service.GetServicesJson{},
+		policies:          map[string]policy.GetPolicy{},
+		attachments:       map[string]policyattachment.GetBody{},
+		roles:             map[string]role.GetRole{},
+		apiKeys:           map[string]apikey.Data{},
+		accessTiers:       map[string]accesstier.AccessTierInfo{},
+		localConfigs:      map[string]accesstier.AccessTierLocalConfig{},
+		accessTierGroups:  map[string]accesstiergroup.AccessTierGroupResponse{},
+		satellites:        map[string]satellite.SatelliteTunnelConfig{},
+		serviceTunnels:    map[string]servicetunnel.ServiceTunnelInfoResponse{},
+		tunnelPolicies:    map[string]servicetunnel.GetPolicyAttachmentInfo{},
+		registeredDomains: map[string]registereddomain.RegisteredDomainInfo{},
+		challenges:        map[string]registereddomain.RegisteredDomainChallengeInfo{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddShield registers an additional cluster returned by shield_config
+func (s *Server) AddShield(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shields = append(s.shields, name)
+}
+
+// AddService stores a registered service as-is, which allows tests to seed services with known ids
+func (s *Server) AddService(svc service.GetServicesJson) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.services[svc.ServiceID] = svc
+}
+
+// AddAccessTier stores an access tier as-is, which allows tests to seed access tiers with known ids
+func (s *Server) AddAccessTier(at accesstier.AccessTierInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessTiers[at.ID] = at
+}
+
+// AddPolicy stores a policy as-is, which allows tests to seed policies with known ids
+func (s *Server) AddPolicy(p policy.GetPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.policies[p.ID] = p
+}
+
+// AddSatellite stores a connector as-is, which allows tests to seed connectors with known ids
+func (s *Server) AddSatellite(sat satellite.SatelliteTunnelConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.satellites[sat.ID] = sat
+}
+
+// SetNetagents replaces the netagents reported for the access tier with the given id. The status of the access tier
+// follows from the status of its netagents, as with the real API.
+func (s *Server) SetNetagents(accessTierID string, netagents []accesstier.NetagentHostInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	at, ok := s.accessTiers[accessTierID]
+	if !ok {
+		return fmt.Errorf("access tier %s not found", accessTierID)
+	}
+	at.Netagents = netagents
+	healthy := 0
+	for _, n := range netagents {
+		if n.Status == string(accesstier.Healthy) {
+			healthy++
+		}
+	}
+	switch {
+	case len(netagents) == 0:
+		at.Status = string(accesstier.Pending)
+	case healthy == len(netagents):
+		at.Status = string(accesstier.Healthy)
+	case healthy > 0:
+		at.Status = string(accesstier.PartiallyHealthy)
+	default:
+		at.Status = string(accesstier.Unhealthy)
+	}
+	s.accessTiers[accessTierID] = at
+	return nil
+}
+
+// route is a request with the api version stripped and the remaining path split into segments
+type route struct {
+	version  string
+	segments []string
+}
+
+func (r route) is(version string, segments ...string) bool {
+	if r.version != version || len(r.segments) != len(segments) {
+		return false
+	}
+	for i, seg := range segments {
+		if seg != "*" && seg != r.segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func parseRoute(path string) (r route) {
+	for _, seg := range strings.Split(path, "/") {
+		if seg == "" {
+			continue
+		}
+		if r.version == "" && len(r.segments) == 0 && seg == "api" {
+			continue
+		}
+		if r.version == "" {
+			r.version = seg
+			continue
+		}
+		r.segments = append(r.segments, seg)
+	}
+	return
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") || r.Header.Get("Authorization") == "Bearer " {
+		writeError(w, http.StatusUnauthorized, "missing api key")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rt := parseRoute(r.URL.Path)
+	var handled bool
+	switch rt.version {
+	case "v1":
+		handled = s.serveV1(w, r, rt)
+	case "v2":
+		handled = s.serveV2(w, r, rt)
+	}
+	if !handled {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+	}
+}
+
+// envelope is the response wrapper used by the v2 endpoints
+type envelope struct {
+	RequestID        string      `json:"request_id"`
+	ErrorCode        int         `json:"error_code"`
+	ErrorDescription string      `json:"error_description"`
+	Data             interface{} `json:"data,omitempty"`
+	Count            int         `json:"count,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, envelope{RequestID: newID(), Data: data})
+}
+
+func writeError(w http.ResponseWriter, status int, description string) {
+	writeJSON(w, status, envelope{RequestID: newID(), ErrorCode: status, ErrorDescription: description})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if len(body) == 0 {
+		return true
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+func newID() string {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func now() int64 {
+	return time.Now().Unix()
+}
+
+func boolString(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
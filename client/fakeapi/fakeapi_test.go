@@ -0,0 +1,247 @@
+package fakeapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstiergroup"
+	"github.com/banyansecurity/terraform-banyan-provider/client/admin/orgidpconfig"
+	"github.com/banyansecurity/terraform-banyan-provider/client/fakeapi"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policyattachment"
+	"github.com/banyansecurity/terraform-banyan-provider/client/registereddomain"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+	"github.com/banyansecurity/terraform-banyan-provider/client/scim"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/servicetunnel"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequiresApiKey(t *testing.T) {
+	srv := fakeapi.New()
+	defer srv.Close()
+	c, err := restclient.New(srv.URL, "")
+	require.NoError(t, err)
+	_, err = c.Read("api/v2", "access_tier", "abc", "")
+	assert.True(t, restclient.IsUnauthorized(err), "got %v", err)
+}
+
+func Test_ShieldConfig(t *testing.T) {
+	srv, c := testutil.NewFakeClient(t)
+	srv.AddShield("cluster2")
+	clusters, err := c.Shield.GetAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{fakeapi.DefaultCluster, "cluster2"}, clusters)
+}
+
+func Test_ServiceLifecycle(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	domain := "svc.example.com"
+	port := "443"
+	spec := service.CreateService{
+		APIVersion: "rbac.banyanops.com/v1",
+		Kind:       "BanyanService",
+		Type:       "origin",
+		Metadata: service.Metadata{
+			Name:        "fake-web",
+			ClusterName: fakeapi.DefaultCluster,
+			Tags:        service.Tags{Domain: &domain, Port: &port},
+		},
+	}
+	created, err := c.Service.Create(ctx, spec)
+	require.NoError(t, err)
+	assert.Equal(t, "fake-web.cluster1.bnn", created.ServiceID)
+	assert.Equal(t, domain, created.Domain)
+
+	got, err := c.Service.Get(ctx, created.ServiceID)
+	require.NoError(t, err)
+	assert.Equal(t, "fake-web", got.CreateServiceSpec.Metadata.Name)
+
+	spec.Metadata.Description = "updated"
+	updated, err := c.Service.Update(ctx, created.ServiceID, spec)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", updated.Description)
+
+	require.NoError(t, c.Service.Delete(ctx, created.ServiceID))
+	_, err = c.Service.Get(ctx, created.ServiceID)
+	assert.True(t, restclient.IsNotFound(err), "got %v", err)
+}
+
+func Test_PolicyAttachment(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	svc, err := c.Service.Create(ctx, service.CreateService{
+		Kind:     "BanyanService",
+		Metadata: service.Metadata{Name: "fake-attach", ClusterName: fakeapi.DefaultCluster},
+	})
+	require.NoError(t, err)
+	pol, err := c.Policy.Create(ctx, policy.Object{
+		Kind:     "BanyanPolicy",
+		Type:     "USER",
+		Metadata: policy.Metadata{Name: "fake-policy"},
+	})
+	require.NoError(t, err)
+
+	_, err = c.PolicyAttachment.Create(ctx, pol.ID, policyattachment.CreateBody{
+		AttachedToID:   svc.ServiceID,
+		AttachedToType: "service",
+		Enabled:        "TRUE",
+	})
+	require.NoError(t, err)
+	attached, err := c.Service.GetPolicyForService(ctx, svc.ServiceID)
+	require.NoError(t, err)
+	assert.Equal(t, pol.ID, attached.ID)
+
+	require.NoError(t, c.Service.DetachPolicy(ctx, svc.ServiceID))
+	attachment, err := c.PolicyAttachment.Get(ctx, svc.ServiceID, "service")
+	require.NoError(t, err)
+	assert.Equal(t, policyattachment.GetBody{}, attachment)
+}
+
+func Test_RoleLifecycle(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	created, err := c.Role.Create(ctx, role.CreateRole{Kind: "BanyanRole", Metadata: role.Metadata{Name: "fake-role"}})
+	require.NoError(t, err)
+	got, err := c.Role.GetName(ctx, "fake-role")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, got.ID)
+	require.NoError(t, c.Role.Delete(ctx, created.ID))
+	_, err = c.Role.Get(ctx, created.ID)
+	assert.True(t, restclient.IsNotFound(err), "got %v", err)
+}
+
+func Test_AccessTierAndGroup(t *testing.T) {
+	srv, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	at, err := c.AccessTier.Create(ctx, accesstier.AccessTierPost{
+		Name:          "fake-at",
+		Address:       "at.example.com",
+		ClusterName:   fakeapi.DefaultCluster,
+		TunnelEnduser: &accesstier.AccessTierTunnelInfoPost{UDPPortNumber: 51820, CIDRs: []string{"10.10.0.0/16"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(51820), at.TunnelEnduser.UDPPortNumber)
+
+	byName, err := c.AccessTier.GetName(ctx, "fake-at")
+	require.NoError(t, err)
+	assert.Equal(t, at.ID, byName.ID)
+
+	_, err = c.AccessTier.UpdateLocalConfig(ctx, at.ID, accesstier.AccessTierLocalConfig{
+		LoggingParameters: &accesstier.LoggingParameters{StatsD: accesstier.BoolPtr(true)},
+	})
+	require.NoError(t, err)
+	lc, err := c.AccessTier.GetLocalConfig(ctx, "fake-at")
+	require.NoError(t, err)
+	assert.True(t, *lc.LoggingParameters.StatsD)
+
+	atg, err := c.AccessTierGroup.Create(ctx, accesstiergroup.AccessTierGroupPost{Name: "fake-atg", ClusterName: fakeapi.DefaultCluster})
+	require.NoError(t, err)
+	atgs, err := c.AccessTierGroup.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, atgs, 1)
+	assert.Equal(t, atg.ID, atgs[0].ID)
+	attached, err := c.AccessTierGroup.AttachAccessTiers(ctx, atg.ID, accesstiergroup.AccessTierList{AccessTierIDs: []string{at.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{at.ID}, attached)
+	atg, err = c.AccessTierGroup.Get(ctx, atg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{at.ID}, atg.AccessTierIDs)
+	_, err = c.AccessTierGroup.DetachAccessTiers(ctx, atg.ID, accesstiergroup.AccessTierList{AccessTierIDs: []string{at.ID}})
+	require.NoError(t, err)
+
+	require.NoError(t, srv.SetNetagents(at.ID, []accesstier.NetagentHostInfo{{HostInfo: accesstier.HostInfo{Hostname: "netagent-1"}}}))
+	require.NoError(t, c.AccessTier.Delete(ctx, at.ID))
+	_, err = c.AccessTier.Get(ctx, at.ID)
+	assert.True(t, restclient.IsNotFound(err), "got %v", err)
+}
+
+func Test_ServiceTunnelPolicy(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	tun, err := c.ServiceTunnel.Create(ctx, servicetunnel.Info{Metadata: servicetunnel.Metadata{Name: "fake-tunnel"}})
+	require.NoError(t, err)
+	assert.Equal(t, "fake-tunnel", tun.Spec.Metadata.Name)
+	pol, err := c.Policy.Create(ctx, policy.Object{Kind: "BanyanPolicy", Metadata: policy.Metadata{Name: "fake-tunnel-policy"}})
+	require.NoError(t, err)
+
+	_, err = c.ServiceTunnel.AttachPolicy(ctx, tun.ID, servicetunnel.PolicyAttachmentPost{PolicyID: pol.ID, Enabled: true})
+	require.NoError(t, err)
+	attached, err := c.ServiceTunnel.GetPolicy(ctx, tun.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pol.ID, attached.PolicyID)
+
+	require.NoError(t, c.ServiceTunnel.DeletePolicy(ctx, tun.ID, pol.ID))
+	require.NoError(t, c.ServiceTunnel.Delete(ctx, tun.ID))
+	_, err = c.ServiceTunnel.Get(ctx, tun.ID)
+	assert.True(t, restclient.IsNotFound(err), "got %v", err)
+}
+
+func Test_SCIM(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	require.NoError(t, c.SCIM.ProvisionSCIM(ctx, scim.SCIMProvisionRequest{IsEnabled: true}))
+	created, err := c.SCIM.Create(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.Data.Token)
+	creds, err := c.SCIM.Get(ctx)
+	require.NoError(t, err)
+	require.Len(t, creds.Tokens, 1)
+	require.NoError(t, c.SCIM.Delete(ctx, creds.Tokens))
+	creds, err = c.SCIM.Get(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, creds.Tokens)
+}
+
+func Test_OrgIdpConfig(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	got, err := c.Admin.OrgIdpConfig.Get(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got.IdpName)
+	want := orgidpconfig.Spec{
+		IdpName:     "Okta",
+		IdpProtocol: "OIDC",
+		IdpConfig: orgidpconfig.IdpConfig{
+			RedirectUrl:  "https://net.banyanops.com/v2/callback",
+			IssuerUrl:    "https://example.okta.com",
+			ClientId:     "client-id",
+			ClientSecret: "client-secret",
+		},
+	}
+	require.NoError(t, c.Admin.OrgIdpConfig.CreateOrUpdate(ctx, want))
+	got, err = c.Admin.OrgIdpConfig.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func Test_OrgIdpConfigError(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	err := c.Admin.OrgIdpConfig.CreateOrUpdate(context.Background(), orgidpconfig.Spec{IdpProtocol: "OIDC"})
+	var apiErr *restclient.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Contains(t, err.Error(), "IDPName is required")
+	// the request must not be dumped into the error, its headers hold the api key
+	assert.NotContains(t, err.Error(), "fakeapi-key")
+}
+
+func Test_GetAllRegisteredDomains(t *testing.T) {
+	_, c := testutil.NewFakeClient(t)
+	ctx := context.Background()
+	created, err := c.RegisteredDomain.Create(ctx, registereddomain.RegisteredDomainRequest{
+		RegisteredDomainInfo: registereddomain.RegisteredDomainInfo{Name: "*.example.com", ClusterName: fakeapi.DefaultCluster},
+	})
+	require.NoError(t, err)
+	all, err := c.RegisteredDomain.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, created.ID, all[0].ID)
+	assert.Equal(t, "*.example.com", all[0].Name)
+}
@@ -0,0 +1,420 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policyattachment"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+)
+
+// serveV1 handles the v1 endpoints, which return bare objects and lists instead of the v2 envelope
+func (s *Server) serveV1(w http.ResponseWriter, r *http.Request, rt route) bool {
+	q := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && rt.is("v1", "registered_services"):
+		s.listServices(w, q.Get("ServiceID"))
+	case r.Method == http.MethodPost && rt.is("v1", "insert_registered_service"):
+		s.insertService(w, r)
+	case r.Method == http.MethodPost && rt.is("v1", "disable_registered_service"):
+		s.setServiceEnabled(w, q.Get("ServiceID"), false)
+	case r.Method == http.MethodPost && rt.is("v1", "enable_registered_service"):
+		s.setServiceEnabled(w, q.Get("ServiceID"), true)
+	case r.Method == http.MethodDelete && rt.is("v1", "delete_registered_service"):
+		s.deleteService(w, q.Get("ServiceID"))
+
+	case r.Method == http.MethodGet && rt.is("v1", "security_policies"):
+		s.listPolicies(w, q.Get("PolicyID"))
+	case r.Method == http.MethodPost && rt.is("v1", "insert_security_policy"):
+		s.insertPolicy(w, r)
+	case r.Method == http.MethodDelete && rt.is("v1", "delete_security_policy"):
+		s.deletePolicy(w, q.Get("PolicyID"))
+
+	case r.Method == http.MethodGet && rt.is("v1", "policy", "attachment", "*", "*"):
+		s.getAttachment(w, rt.segments[2], rt.segments[3])
+	case r.Method == http.MethodPost && rt.is("v1", "insert_security_attach_policy"):
+		s.insertServiceAttachment(w, r)
+	case r.Method == http.MethodPut && rt.is("v1", "policy", "*", "attach"):
+		s.attachPolicy(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v1", "delete_security_attach_policy"):
+		s.deleteServiceAttachment(w, q.Get("PolicyID"), q.Get("ServiceID"))
+
+	case r.Method == http.MethodGet && rt.is("v1", "security_roles"):
+		s.listRoles(w, q.Get("RoleID"))
+	case r.Method == http.MethodPost && rt.is("v1", "insert_security_role"):
+		s.insertRole(w, r)
+	case r.Method == http.MethodPost && rt.is("v1", "disable_security_role"):
+		s.disableRole(w, q.Get("RoleID"))
+	case r.Method == http.MethodDelete && rt.is("v1", "delete_security_role"):
+		s.deleteRole(w, q.Get("RoleID"))
+
+	case r.Method == http.MethodGet && rt.is("v1", "user_org_details"):
+		writeJSON(w, http.StatusOK, s.orgDetails)
+	case r.Method == http.MethodPost && rt.is("v1", "update_org"):
+		s.updateOrg(w, r)
+
+	case r.Method == http.MethodDelete && rt.is("v1", "delete_netagent"):
+		s.deleteNetagent(w, q.Get("CLUSTERNAME"), q.Get("HOSTNAME"))
+	default:
+		return false
+	}
+	return true
+}
+
+// orgDetails is the subset of user_org_details which holds the org IdP configuration
+type orgDetails struct {
+	IDPName   string `json:"IDPName"`
+	IDPProto  string `json:"IDPProto"`
+	IDPConfig string `json:"IDPConfig"`
+}
+
+func (s *Server) updateOrg(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.PostForm.Get("IDPName") == "" {
+		writeError(w, http.StatusBadRequest, "IDPName is required")
+		return
+	}
+	s.orgDetails = orgDetails{
+		IDPName:   r.PostForm.Get("IDPName"),
+		IDPProto:  r.PostForm.Get("IDPProtocol"),
+		IDPConfig: r.PostForm.Get("IDPConfig"),
+	}
+	writeJSON(w, http.StatusOK, s.orgDetails)
+}
+
+func attachmentKey(attachedToType, attachedToID string) string {
+	return attachedToType + "/" + attachedToID
+}
+
+func (s *Server) listServices(w http.ResponseWriter, id string) {
+	services := []service.GetServicesJson{}
+	for _, svc := range s.services {
+		if id == "" || svc.ServiceID == id {
+			services = append(services, svc)
+		}
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].ServiceID < services[j].ServiceID })
+	writeJSON(w, http.StatusOK, services)
+}
+
+func (s *Server) insertService(w http.ResponseWriter, r *http.Request) {
+	var spec service.CreateService
+	if !decode(w, r, &spec) {
+		return
+	}
+	if spec.Metadata.Name == "" {
+		writeError(w, http.StatusBadRequest, "service name is required")
+		return
+	}
+	specJson, err := json.Marshal(spec)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id := fmt.Sprintf("%s.%s.bnn", spec.Metadata.Name, spec.Metadata.ClusterName)
+	svc, exists := s.services[id]
+	if !exists {
+		svc = service.GetServicesJson{
+			ServiceID:        id,
+			CreatedBy:        "fakeapi",
+			CreatedAt:        now(),
+			ServiceDiscovery: "{}",
+			Enabled:          "TRUE",
+			External:         "FALSE",
+			OIDCEnabled:      "FALSE",
+		}
+	}
+	svc.ServiceName = spec.Metadata.Name
+	svc.ClusterName = spec.Metadata.ClusterName
+	svc.Description = spec.Metadata.Description
+	svc.ServiceVersion++
+	svc.LastUpdatedBy = "fakeapi"
+	svc.LastUpdatedAt = now()
+	svc.ServiceSpec = string(specJson)
+	tags := spec.Metadata.Tags
+	if tags.ServiceAppType != nil {
+		svc.ServiceType = *tags.ServiceAppType
+	}
+	if tags.UserFacing != nil {
+		svc.UserFacing = *tags.UserFacing
+	}
+	if tags.Protocol != nil {
+		svc.Protocol = *tags.Protocol
+	}
+	if tags.Domain != nil {
+		svc.Domain = *tags.Domain
+	}
+	if tags.Port != nil {
+		svc.Port, _ = strconv.Atoi(*tags.Port)
+	}
+	s.services[id] = svc
+	writeJSON(w, http.StatusOK, svc)
+}
+
+func (s *Server) setServiceEnabled(w http.ResponseWriter, id string, enabled bool) {
+	svc, ok := s.services[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "service not found")
+		return
+	}
+	svc.Enabled = boolString(enabled)
+	s.services[id] = svc
+	writeJSON(w, http.StatusOK, svc)
+}
+
+func (s *Server) deleteService(w http.ResponseWriter, id string) {
+	svc, ok := s.services[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "service not found")
+		return
+	}
+	delete(s.services, id)
+	delete(s.attachments, attachmentKey("service", id))
+	writeJSON(w, http.StatusOK, svc)
+}
+
+func (s *Server) listPolicies(w http.ResponseWriter, id string) {
+	policies := []policy.GetPolicy{}
+	for _, p := range s.policies {
+		if id == "" || p.ID == id {
+			policies = append(policies, p)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].ID < policies[j].ID })
+	writeJSON(w, http.StatusOK, policies)
+}
+
+func (s *Server) insertPolicy(w http.ResponseWriter, r *http.Request) {
+	var obj policy.Object
+	if !decode(w, r, &obj) {
+		return
+	}
+	if obj.Name == "" {
+		writeError(w, http.StatusBadRequest, "policy name is required")
+		return
+	}
+	// the API clobbers by id, falling back to name
+	var existing policy.GetPolicy
+	if p, ok := s.policies[obj.ID]; ok && obj.ID != "" {
+		existing = p
+	} else {
+		for _, p := range s.policies {
+			if p.Name == obj.Name {
+				existing = p
+			}
+		}
+	}
+	if existing.ID == "" {
+		existing = policy.GetPolicy{ID: newID(), CreatedBy: "fakeapi", CreatedAt: int(now())}
+	}
+	obj.ID = existing.ID
+	specJson, err := json.Marshal(obj)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	existing.Name = obj.Name
+	existing.Description = obj.Description
+	existing.Spec = string(specJson)
+	existing.Version++
+	existing.LastUpdatedBy = "fakeapi"
+	existing.LastUpdatedAt = int(now())
+	s.policies[existing.ID] = existing
+	writeJSON(w, http.StatusOK, existing)
+}
+
+func (s *Server) deletePolicy(w http.ResponseWriter, id string) {
+	p, ok := s.policies[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "policy not found")
+		return
+	}
+	for key, att := range s.attachments {
+		if att.PolicyID == id {
+			delete(s.attachments, key)
+		}
+	}
+	for key, att := range s.tunnelPolicies {
+		if att.PolicyID == id {
+			delete(s.tunnelPolicies, key)
+		}
+	}
+	delete(s.policies, id)
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) getAttachment(w http.ResponseWriter, attachedToType, attachedToID string) {
+	attachments := []policyattachment.GetBody{}
+	if att, ok := s.attachments[attachmentKey(attachedToType, attachedToID)]; ok {
+		attachments = append(attachments, att)
+	}
+	writeJSON(w, http.StatusOK, attachments)
+}
+
+func (s *Server) saveAttachment(policyID, attachedToType, attachedToID, enabled string) (att policyattachment.GetBody, err error) {
+	p, ok := s.policies[policyID]
+	if !ok {
+		return att, fmt.Errorf("policy %s not found", policyID)
+	}
+	att = policyattachment.GetBody{
+		PolicyID:       policyID,
+		PolicyName:     p.Name,
+		AttachedToID:   attachedToID,
+		AttachedToType: attachedToType,
+		Enabled:        enabled,
+		AttachedAt:     int(now()),
+		AttachedBy:     "fakeapi",
+	}
+	if attachedToType == "service" {
+		svc, ok := s.services[attachedToID]
+		if !ok {
+			return att, fmt.Errorf("service %s not found", attachedToID)
+		}
+		att.AttachedToName = svc.ServiceName
+	}
+	s.attachments[attachmentKey(attachedToType, attachedToID)] = att
+	return
+}
+
+func (s *Server) insertServiceAttachment(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	att, err := s.saveAttachment(r.PostForm.Get("PolicyID"), "service", r.PostForm.Get("ServiceID"), r.PostForm.Get("Enabled"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, policyattachment.RegisteredServiceAttachCreateResponseBody{
+		PolicyID:   att.PolicyID,
+		ServiceID:  att.AttachedToID,
+		Enabled:    att.Enabled,
+		AttachedBy: att.AttachedBy,
+		AttachedAt: att.AttachedAt,
+	})
+}
+
+func (s *Server) attachPolicy(w http.ResponseWriter, r *http.Request, policyID string) {
+	var body policyattachment.CreateBody
+	if !decode(w, r, &body) {
+		return
+	}
+	att, err := s.saveAttachment(policyID, body.AttachedToType, body.AttachedToID, body.Enabled)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, att)
+}
+
+func (s *Server) deleteServiceAttachment(w http.ResponseWriter, policyID, serviceID string) {
+	key := attachmentKey("service", serviceID)
+	att, ok := s.attachments[key]
+	if !ok || att.PolicyID != policyID {
+		writeError(w, http.StatusNotFound, "policy attachment not found")
+		return
+	}
+	delete(s.attachments, key)
+	writeJSON(w, http.StatusOK, att)
+}
+
+func (s *Server) listRoles(w http.ResponseWriter, id string) {
+	roles := []role.GetRole{}
+	for _, ro := range s.roles {
+		if id == "" || ro.ID == id {
+			roles = append(roles, ro)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	writeJSON(w, http.StatusOK, roles)
+}
+
+func (s *Server) insertRole(w http.ResponseWriter, r *http.Request) {
+	var spec role.CreateRole
+	if !decode(w, r, &spec) {
+		return
+	}
+	if spec.Metadata.Name == "" {
+		writeError(w, http.StatusBadRequest, "role name is required")
+		return
+	}
+	var existing role.GetRole
+	if ro, ok := s.roles[spec.Metadata.ID]; ok && spec.Metadata.ID != "" {
+		existing = ro
+	} else {
+		for _, ro := range s.roles {
+			if ro.Name == spec.Metadata.Name {
+				existing = ro
+			}
+		}
+	}
+	if existing.ID == "" {
+		existing = role.GetRole{ID: newID(), CreatedBy: "fakeapi", CreatedAt: int(now())}
+	}
+	spec.Metadata.ID = existing.ID
+	specJson, err := json.Marshal(spec)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	existing.Name = spec.Metadata.Name
+	existing.Description = spec.Metadata.Description
+	existing.Spec = string(specJson)
+	existing.Version++
+	existing.IsEnabledString = "TRUE"
+	existing.LastUpdatedBy = "fakeapi"
+	existing.LastUpdatedAt = int(now())
+	s.roles[existing.ID] = existing
+	writeJSON(w, http.StatusOK, existing)
+}
+
+func (s *Server) disableRole(w http.ResponseWriter, id string) {
+	ro, ok := s.roles[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "role not found")
+		return
+	}
+	ro.IsEnabledString = "FALSE"
+	s.roles[id] = ro
+	writeJSON(w, http.StatusOK, ro)
+}
+
+func (s *Server) deleteRole(w http.ResponseWriter, id string) {
+	ro, ok := s.roles[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "role not found")
+		return
+	}
+	if ro.IsEnabledString != "FALSE" {
+		writeError(w, http.StatusBadRequest, "role must be disabled before it is deleted")
+		return
+	}
+	delete(s.roles, id)
+	writeJSON(w, http.StatusOK, ro)
+}
+
+func (s *Server) deleteNetagent(w http.ResponseWriter, clusterName string, hostname string) {
+	for id, at := range s.accessTiers {
+		if at.ClusterName != clusterName {
+			continue
+		}
+		for i, agent := range at.Netagents {
+			if agent.Hostname == hostname {
+				at.Netagents = append(at.Netagents[:i], at.Netagents[i+1:]...)
+				s.accessTiers[id] = at
+				writeJSON(w, http.StatusOK, agent)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "netagent not found")
+}
@@ -0,0 +1,754 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstiergroup"
+	"github.com/banyansecurity/terraform-banyan-provider/client/apikey"
+	"github.com/banyansecurity/terraform-banyan-provider/client/appconfig"
+	"github.com/banyansecurity/terraform-banyan-provider/client/registereddomain"
+	"github.com/banyansecurity/terraform-banyan-provider/client/satellite"
+	"github.com/banyansecurity/terraform-banyan-provider/client/scim"
+	"github.com/banyansecurity/terraform-banyan-provider/client/servicetunnel"
+	"github.com/banyansecurity/terraform-banyan-provider/client/shield"
+)
+
+// serveV2 handles the v2 endpoints, most of which wrap their response in an envelope
+func (s *Server) serveV2(w http.ResponseWriter, r *http.Request, rt route) bool {
+	switch {
+	case r.Method == http.MethodGet && rt.is("v2", "shield_config"):
+		s.shieldConfig(w)
+
+	case r.Method == http.MethodGet && rt.is("v2", "api_key"):
+		s.listAPIKeys(w)
+	case r.Method == http.MethodPost && rt.is("v2", "api_key"):
+		s.saveAPIKey(w, r, "")
+	case r.Method == http.MethodGet && rt.is("v2", "api_key", "*"):
+		s.getAPIKey(w, rt.segments[1])
+	case r.Method == http.MethodPut && rt.is("v2", "api_key", "*"):
+		s.saveAPIKey(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v2", "api_key", "*"):
+		s.deleteAPIKey(w, rt.segments[1])
+
+	case r.Method == http.MethodGet && rt.is("v2", "access_tier"):
+		s.listAccessTiers(w, r.URL.Query().Get("name"))
+	case r.Method == http.MethodPost && rt.is("v2", "access_tier"):
+		s.saveAccessTier(w, r, "")
+	case r.Method == http.MethodGet && rt.is("v2", "access_tier", "*"):
+		s.getAccessTier(w, rt.segments[1])
+	case r.Method == http.MethodPut && rt.is("v2", "access_tier", "*"):
+		s.saveAccessTier(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v2", "access_tier", "*"):
+		s.deleteAccessTier(w, rt.segments[1])
+	case r.Method == http.MethodGet && rt.is("v2", "access_tier_facing", "*", "config"):
+		s.getLocalConfig(w, rt.segments[1])
+	case r.Method == http.MethodPut && rt.is("v2", "access_tier", "*", "config"):
+		s.updateLocalConfig(w, r, rt.segments[1])
+
+	case r.Method == http.MethodGet && rt.is("v2", "access_tier_groups"):
+		s.listAccessTierGroups(w, r.URL.Query().Get("access_tier_group_name"))
+	case r.Method == http.MethodPost && rt.is("v2", "access_tier_groups"):
+		s.saveAccessTierGroup(w, r, "")
+	case r.Method == http.MethodGet && rt.is("v2", "access_tier_groups", "*"):
+		s.getAccessTierGroup(w, rt.segments[1])
+	case r.Method == http.MethodPut && rt.is("v2", "access_tier_groups", "*"):
+		s.saveAccessTierGroup(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v2", "access_tier_groups", "*"):
+		s.deleteAccessTierGroup(w, rt.segments[1])
+	case r.Method == http.MethodPost && rt.is("v2", "access_tier_groups", "*", "attach"):
+		s.attachAccessTiers(w, r, rt.segments[1], true)
+	case r.Method == http.MethodDelete && rt.is("v2", "access_tier_groups", "*", "detach"):
+		s.attachAccessTiers(w, r, rt.segments[1], false)
+
+	case r.Method == http.MethodGet && rt.is("v2", "satellite"):
+		s.listSatellites(w)
+	case r.Method == http.MethodPost && rt.is("v2", "satellite"):
+		s.saveSatellite(w, r, "")
+	case r.Method == http.MethodGet && rt.is("v2", "satellite", "*"):
+		s.getSatellite(w, rt.segments[1])
+	case r.Method == http.MethodPut && rt.is("v2", "satellite", "*"):
+		s.saveSatellite(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v2", "satellite", "*"):
+		s.deleteSatellite(w, rt.segments[1])
+
+	case r.Method == http.MethodGet && rt.is("v2", "service_tunnel"):
+		s.listServiceTunnels(w)
+	case r.Method == http.MethodPost && rt.is("v2", "service_tunnel"):
+		s.saveServiceTunnel(w, r, "")
+	case r.Method == http.MethodGet && rt.is("v2", "service_tunnel", "*"):
+		s.getServiceTunnel(w, rt.segments[1])
+	case r.Method == http.MethodPut && rt.is("v2", "service_tunnel", "*"):
+		s.saveServiceTunnel(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v2", "service_tunnel", "*"):
+		s.deleteServiceTunnel(w, rt.segments[1])
+	case r.Method == http.MethodGet && rt.is("v2", "service_tunnel", "*", "security_policy"):
+		s.getTunnelPolicy(w, rt.segments[1])
+	case r.Method == http.MethodPost && rt.is("v2", "service_tunnel", "*", "security_policy"):
+		s.attachTunnelPolicy(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v2", "service_tunnel", "*", "security_policy", "*"):
+		s.detachTunnelPolicy(w, rt.segments[1], rt.segments[3])
+
+	case r.Method == http.MethodGet && rt.is("v2", "registered_domain"):
+		s.listRegisteredDomains(w)
+	case r.Method == http.MethodPost && rt.is("v2", "registered_domain"):
+		s.saveRegisteredDomain(w, r, "")
+	case r.Method == http.MethodGet && rt.is("v2", "registered_domain", "*"):
+		s.getRegisteredDomain(w, rt.segments[1])
+	case r.Method == http.MethodPut && rt.is("v2", "registered_domain", "*"):
+		s.saveRegisteredDomain(w, r, rt.segments[1])
+	case r.Method == http.MethodDelete && rt.is("v2", "registered_domain", "*"):
+		s.deleteRegisteredDomain(w, rt.segments[1])
+	case r.Method == http.MethodPost && rt.is("v2", "registered_domain", "*", "validate"):
+		s.validateRegisteredDomain(w, rt.segments[1])
+	case r.Method == http.MethodPost && rt.is("v2", "registered_domain_challenge"):
+		s.createChallenge(w, r)
+	case r.Method == http.MethodGet && rt.is("v2", "registered_domain_challenge", "*"):
+		s.getChallenge(w, rt.segments[1])
+
+	case r.Method == http.MethodGet && rt.is("v2", "scim", "credentials"):
+		s.getSCIMCredentials(w)
+	case r.Method == http.MethodPost && rt.is("v2", "scim", "credentials"):
+		s.createSCIMCredentials(w)
+	case r.Method == http.MethodPost && rt.is("v2", "scim", "provision"):
+		s.provisionSCIM(w, r)
+	case r.Method == http.MethodDelete && rt.is("v2", "scim", "token", "*"):
+		s.deleteSCIMToken(w, rt.segments[2])
+
+	case r.Method == http.MethodGet && rt.is("v2", "org", "app_config"):
+		s.getAppConfig(w)
+	case (r.Method == http.MethodPost || r.Method == http.MethodPut) && rt.is("v2", "org", "app_config"):
+		s.saveAppConfig(w, r)
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Server) shieldConfig(w http.ResponseWriter) {
+	var data shield.Data
+	for _, name := range s.shields {
+		data.Configs = append(data.Configs, shield.Info{ShieldName: name})
+	}
+	writeData(w, data)
+}
+
+func (s *Server) listAPIKeys(w http.ResponseWriter) {
+	keys := []apikey.Data{}
+	for _, k := range s.apiKeys {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	writeData(w, keys)
+}
+
+func (s *Server) getAPIKey(w http.ResponseWriter, id string) {
+	k, ok := s.apiKeys[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+	writeData(w, k)
+}
+
+func (s *Server) saveAPIKey(w http.ResponseWriter, r *http.Request, id string) {
+	var post apikey.Post
+	if !decode(w, r, &post) {
+		return
+	}
+	k, ok := s.apiKeys[id]
+	if id != "" && !ok {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+	if !ok {
+		k = apikey.Data{ID: newID(), OrgID: OrgID, Secret: newID(), CreatedBy: "fakeapi", CreatedAt: now()}
+	}
+	k.Name = post.Name
+	k.Description = post.Description
+	k.Scope = post.Scope
+	k.UpdatedBy = "fakeapi"
+	k.UpdatedAt = now()
+	s.apiKeys[k.ID] = k
+	if id != "" {
+		// the update endpoint returns the bare key
+		writeJSON(w, http.StatusOK, k)
+		return
+	}
+	writeData(w, k)
+}
+
+func (s *Server) deleteAPIKey(w http.ResponseWriter, id string) {
+	if _, ok := s.apiKeys[id]; !ok {
+		writeError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+	delete(s.apiKeys, id)
+	writeData(w, nil)
+}
+
+func (s *Server) listAccessTiers(w http.ResponseWriter, name string) {
+	ats := []accesstier.AccessTierInfo{}
+	for _, at := range s.accessTiers {
+		if name == "" || at.Name == name {
+			ats = append(ats, at)
+		}
+	}
+	sort.Slice(ats, func(i, j int) bool { return ats[i].ID < ats[j].ID })
+	writeData(w, map[string]interface{}{"access_tiers": ats, "count": len(ats)})
+}
+
+func (s *Server) getAccessTier(w http.ResponseWriter, id string) {
+	at, ok := s.accessTiers[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "access tier not found")
+		return
+	}
+	writeData(w, at)
+}
+
+func tunnelInfo(accessTierID string, peerType string, post *accesstier.AccessTierTunnelInfoPost) *accesstier.AccessTierTunnelInfo {
+	if post == nil {
+		return nil
+	}
+	return &accesstier.AccessTierTunnelInfo{
+		ID:                  newID(),
+		OrgID:               OrgID,
+		AccessTierID:        accessTierID,
+		TunnelPeerType:      peerType,
+		DNSSearchDomains:    post.DNSSearchDomains,
+		UDPPortNumber:       int64(post.UDPPortNumber),
+		TunnelIPAddress:     post.TunnelIPAddress,
+		WireguardPublicKey:  "fakeapi-wireguard-public-key",
+		WireguardPrivateKey: "fakeapi-wireguard-private-key",
+		DNSEnabled:          post.DNSEnabled,
+		CIDRs:               post.CIDRs,
+		Domains:             post.Domains,
+		CreatedAt:           now(),
+		UpdatedAt:           now(),
+	}
+}
+
+func (s *Server) saveAccessTier(w http.ResponseWriter, r *http.Request, id string) {
+	var body accesstier.AccessTierPostBody
+	if !decode(w, r, &body) {
+		return
+	}
+	at, ok := s.accessTiers[id]
+	if id != "" && !ok {
+		writeError(w, http.StatusNotFound, "access tier not found")
+		return
+	}
+	spec := body.Spec
+	if !ok {
+		for _, existing := range s.accessTiers {
+			if existing.Name == spec.Name {
+				writeError(w, http.StatusConflict, fmt.Sprintf("access tier %s already exists", spec.Name))
+				return
+			}
+		}
+		at = accesstier.AccessTierInfo{ID: newID(), Status: string(accesstier.Pending), Netagents: []accesstier.NetagentHostInfo{}, CreatedBy: "fakeapi", CreatedAt: now()}
+		// like the real API, a new access tier runs its netagents in access tier mode
+		s.localConfigs[at.ID] = accesstier.AccessTierLocalConfig{
+			MiscellaneousParameters: &accesstier.MiscellaneousParameters{AccessTier: accesstier.BoolPtr(true)},
+		}
+	}
+	at.Name = spec.Name
+	at.Address = spec.Address
+	at.Domains = spec.Domains
+	at.ClusterName = spec.ClusterName
+	at.DisableSnat = spec.DisableSnat
+	at.SrcNATCIDRRange = spec.SrcNATCIDRRange
+	at.Description = spec.Description
+	at.APIKeyID = spec.ApiKeyId
+	at.DeploymentMethod = spec.DeploymentMethod
+	at.TunnelSatellite = tunnelInfo(at.ID, accesstier.SatelliteTunnelPeerType, spec.TunnelSatellite)
+	at.TunnelEnduser = tunnelInfo(at.ID, accesstier.EnduserDeviceTunnelPeerType, spec.TunnelEnduser)
+	at.UpdatedBy = "fakeapi"
+	at.UpdatedAt = now()
+	s.accessTiers[at.ID] = at
+	writeData(w, at)
+}
+
+func (s *Server) deleteAccessTier(w http.ResponseWriter, id string) {
+	if _, ok := s.accessTiers[id]; !ok {
+		writeError(w, http.StatusNotFound, "access tier not found")
+		return
+	}
+	delete(s.accessTiers, id)
+	delete(s.localConfigs, id)
+	writeData(w, nil)
+}
+
+func (s *Server) getLocalConfig(w http.ResponseWriter, name string) {
+	for id, at := range s.accessTiers {
+		if at.Name == name {
+			// like the real API, the base parameters are always filled in
+			lc := s.localConfigs[id]
+			lc.BaseParameters = &accesstier.BaseParameters{
+				ShieldAddress: accesstier.StringPtr(at.ClusterName + ".fakeapi:443"),
+				SiteAddress:   accesstier.StringPtr(at.Address),
+			}
+			writeData(w, lc)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "access tier not found")
+}
+
+func (s *Server) updateLocalConfig(w http.ResponseWriter, r *http.Request, id string) {
+	var body accesstier.AccessTierLocalConfigSpec
+	if !decode(w, r, &body) {
+		return
+	}
+	if _, ok := s.accessTiers[id]; !ok {
+		writeError(w, http.StatusNotFound, "access tier not found")
+		return
+	}
+	s.localConfigs[id] = body.Spec
+	writeData(w, body.Spec)
+}
+
+func (s *Server) listAccessTierGroups(w http.ResponseWriter, name string) {
+	atgs := []accesstiergroup.AccessTierGroupResponse{}
+	for _, atg := range s.accessTierGroups {
+		if name == "" || atg.Name == name {
+			atgs = append(atgs, atg)
+		}
+	}
+	sort.Slice(atgs, func(i, j int) bool { return atgs[i].ID < atgs[j].ID })
+	writeData(w, map[string]interface{}{"access_tier_groups": atgs, "count": len(atgs)})
+}
+
+func (s *Server) getAccessTierGroup(w http.ResponseWriter, id string) {
+	atg, ok := s.accessTierGroups[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "access tier group not found")
+		return
+	}
+	writeData(w, atg)
+}
+
+func (s *Server) saveAccessTierGroup(w http.ResponseWriter, r *http.Request, id string) {
+	var post accesstiergroup.AccessTierGroupPost
+	if !decode(w, r, &post) {
+		return
+	}
+	atg, ok := s.accessTierGroups[id]
+	if id != "" && !ok {
+		writeError(w, http.StatusNotFound, "access tier group not found")
+		return
+	}
+	if !ok {
+		atg = accesstiergroup.AccessTierGroupResponse{ID: newID(), OrgID: OrgID, AccessTierIDs: []string{}, CreatedAt: now()}
+	}
+	advancedSettings, err := json.Marshal(post.AdvancedSettings)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	atg.Name = post.Name
+	atg.Description = post.Description
+	atg.ClusterName = post.ClusterName
+	atg.AdvancedSettings = string(advancedSettings)
+	atg.TunnelConfig = accesstiergroup.TunnelConfigInfo{}
+	if tun := tunnelInfo(atg.ID, accesstier.EnduserDeviceTunnelPeerType, post.TunnelConfig); tun != nil {
+		atg.TunnelConfig = accesstiergroup.TunnelConfigInfo{
+			ID:                 tun.ID,
+			OrgID:              tun.OrgID,
+			TunnelPeerType:     tun.TunnelPeerType,
+			DNSSearchDomains:   tun.DNSSearchDomains,
+			UDPPortNumber:      tun.UDPPortNumber,
+			TunnelIPAddress:    tun.TunnelIPAddress,
+			WireguardPublicKey: tun.WireguardPublicKey,
+			DNSEnabled:         tun.DNSEnabled,
+			CreatedAt:          tun.CreatedAt,
+			UpdatedAt:          tun.UpdatedAt,
+			SharedFQDN:         post.SharedFQDN,
+			CIDRs:              tun.CIDRs,
+			Domains:            tun.Domains,
+		}
+	}
+	atg.UpdatedAt = now()
+	s.accessTierGroups[atg.ID] = atg
+	if id != "" {
+		// the update endpoint returns the bare group
+		writeJSON(w, http.StatusOK, atg)
+		return
+	}
+	writeData(w, atg)
+}
+
+func (s *Server) deleteAccessTierGroup(w http.ResponseWriter, id string) {
+	if _, ok := s.accessTierGroups[id]; !ok {
+		writeError(w, http.StatusNotFound, "access tier group not found")
+		return
+	}
+	delete(s.accessTierGroups, id)
+	writeData(w, nil)
+}
+
+// attachAccessTiers adds or removes access tiers from a group and, like the API, returns the bare list
+func (s *Server) attachAccessTiers(w http.ResponseWriter, r *http.Request, id string, attach bool) {
+	var list accesstiergroup.AccessTierList
+	if !decode(w, r, &list) {
+		return
+	}
+	atg, ok := s.accessTierGroups[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "access tier group not found")
+		return
+	}
+	for _, atID := range list.AccessTierIDs {
+		if _, ok := s.accessTiers[atID]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("access tier %s not found", atID))
+			return
+		}
+	}
+	remaining := []string{}
+	for _, existing := range atg.AccessTierIDs {
+		if !contains(list.AccessTierIDs, existing) {
+			remaining = append(remaining, existing)
+		}
+	}
+	if attach {
+		remaining = append(remaining, list.AccessTierIDs...)
+	}
+	atg.AccessTierIDs = remaining
+	s.accessTierGroups[id] = atg
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) listSatellites(w http.ResponseWriter) {
+	sats := []satellite.SatelliteTunnelConfig{}
+	for _, sat := range s.satellites {
+		sats = append(sats, sat)
+	}
+	sort.Slice(sats, func(i, j int) bool { return sats[i].ID < sats[j].ID })
+	writeData(w, map[string]interface{}{"satellites": sats, "count": len(sats)})
+}
+
+func (s *Server) getSatellite(w http.ResponseWriter, id string) {
+	sat, ok := s.satellites[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "satellite not found")
+		return
+	}
+	writeData(w, sat)
+}
+
+func (s *Server) saveSatellite(w http.ResponseWriter, r *http.Request, id string) {
+	var info satellite.Info
+	if !decode(w, r, &info) {
+		return
+	}
+	sat, ok := s.satellites[id]
+	if id != "" && !ok {
+		writeError(w, http.StatusNotFound, "satellite not found")
+		return
+	}
+	if !ok {
+		sat = satellite.SatelliteTunnelConfig{
+			ID:                  newID(),
+			OrgID:               OrgID,
+			Status:              string(satellite.Pending),
+			WireguardPublicKey:  "fakeapi-wireguard-public-key",
+			WireguardPrivateKey: "fakeapi-wireguard-private-key",
+			TunnelIPAddress:     "100.64.0.1",
+			CreatedBy:           "fakeapi",
+			CreatedAt:           now(),
+		}
+	}
+	spec, err := json.Marshal(info)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sat.Name = info.Metadata.Name
+	sat.DisplayName = info.Metadata.DisplayName
+	sat.Description = info.Metadata.Description
+	sat.APIKeyID = info.Spec.APIKeyID
+	sat.Keepalive = info.Spec.Keepalive
+	sat.CIDRs = info.Spec.CIDRs
+	sat.Domains = info.Spec.Domains
+	sat.Spec = string(spec)
+	sat.UpdatedBy = "fakeapi"
+	sat.UpdatedAt = now()
+	s.satellites[sat.ID] = sat
+	writeData(w, sat)
+}
+
+func (s *Server) deleteSatellite(w http.ResponseWriter, id string) {
+	if _, ok := s.satellites[id]; !ok {
+		writeError(w, http.StatusNotFound, "satellite not found")
+		return
+	}
+	delete(s.satellites, id)
+	writeData(w, nil)
+}
+
+func (s *Server) listServiceTunnels(w http.ResponseWriter) {
+	tuns := []servicetunnel.ServiceTunnelInfoResponse{}
+	for _, tun := range s.serviceTunnels {
+		tuns = append(tuns, tun)
+	}
+	sort.Slice(tuns, func(i, j int) bool { return tuns[i].ID < tuns[j].ID })
+	writeData(w, map[string]interface{}{"service_tunnels": tuns, "count": len(tuns)})
+}
+
+func (s *Server) getServiceTunnel(w http.ResponseWriter, id string) {
+	tun, ok := s.serviceTunnels[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "service tunnel not found")
+		return
+	}
+	writeData(w, tun)
+}
+
+func (s *Server) saveServiceTunnel(w http.ResponseWriter, r *http.Request, id string) {
+	var info servicetunnel.Info
+	if !decode(w, r, &info) {
+		return
+	}
+	tun, ok := s.serviceTunnels[id]
+	if id != "" && !ok {
+		writeError(w, http.StatusNotFound, "service tunnel not found")
+		return
+	}
+	if !ok {
+		tun = servicetunnel.ServiceTunnelInfoResponse{ID: newID(), OrgID: OrgID, Enabled: true, CreatedBy: "fakeapi", CreatedAt: now()}
+	}
+	spec, err := json.Marshal(info)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	tun.Name = info.Metadata.Name
+	tun.FriendlyName = info.Metadata.FriendlyName
+	tun.Description = info.Metadata.Description
+	tun.Spec = string(spec)
+	tun.UpdatedBy = "fakeapi"
+	tun.UpdatedAt = now()
+	s.serviceTunnels[tun.ID] = tun
+	writeData(w, tun)
+}
+
+func (s *Server) deleteServiceTunnel(w http.ResponseWriter, id string) {
+	if _, ok := s.serviceTunnels[id]; !ok {
+		writeError(w, http.StatusNotFound, "service tunnel not found")
+		return
+	}
+	delete(s.serviceTunnels, id)
+	delete(s.tunnelPolicies, id)
+	delete(s.attachments, attachmentKey("service_tunnel", id))
+	writeData(w, nil)
+}
+
+func (s *Server) getTunnelPolicy(w http.ResponseWriter, id string) {
+	if _, ok := s.serviceTunnels[id]; !ok {
+		writeError(w, http.StatusNotFound, "service tunnel not found")
+		return
+	}
+	writeData(w, s.tunnelPolicies[id])
+}
+
+func (s *Server) attachTunnelPolicy(w http.ResponseWriter, r *http.Request, id string) {
+	var post servicetunnel.PolicyAttachmentPost
+	if !decode(w, r, &post) {
+		return
+	}
+	if _, ok := s.serviceTunnels[id]; !ok {
+		writeError(w, http.StatusNotFound, "service tunnel not found")
+		return
+	}
+	att, err := s.saveAttachment(post.PolicyID, "service_tunnel", id, boolString(post.Enabled))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	info := servicetunnel.GetPolicyAttachmentInfo{
+		ID:             newID(),
+		PolicyID:       att.PolicyID,
+		PolicyName:     att.PolicyName,
+		AttachedToID:   id,
+		AttachedToType: att.AttachedToType,
+		AttachedBy:     att.AttachedBy,
+		AttachedAt:     int64(att.AttachedAt),
+		Enabled:        att.Enabled,
+	}
+	s.tunnelPolicies[id] = info
+	writeData(w, servicetunnel.PolicyAttachmentInfo{
+		ID:              info.ID,
+		PolicyID:        info.PolicyID,
+		PolicyVersion:   int64(s.policies[info.PolicyID].Version),
+		ServiceTunnelID: id,
+		AttachedBy:      info.AttachedBy,
+		AttachedAt:      info.AttachedAt,
+		Enabled:         post.Enabled,
+	})
+}
+
+func (s *Server) detachTunnelPolicy(w http.ResponseWriter, id string, policyID string) {
+	att, ok := s.tunnelPolicies[id]
+	if !ok || att.PolicyID != policyID {
+		writeError(w, http.StatusNotFound, "policy attachment not found")
+		return
+	}
+	delete(s.tunnelPolicies, id)
+	delete(s.attachments, attachmentKey("service_tunnel", id))
+	writeData(w, att)
+}
+
+func (s *Server) listRegisteredDomains(w http.ResponseWriter) {
+	rds := []registereddomain.RegisteredDomainInfo{}
+	for _, rd := range s.registeredDomains {
+		rds = append(rds, rd)
+	}
+	sort.Slice(rds, func(i, j int) bool { return rds[i].ID < rds[j].ID })
+	writeData(w, rds)
+}
+
+func (s *Server) getRegisteredDomain(w http.ResponseWriter, id string) {
+	rd, ok := s.registeredDomains[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "registered domain not found")
+		return
+	}
+	writeData(w, rd)
+}
+
+func (s *Server) saveRegisteredDomain(w http.ResponseWriter, r *http.Request, id string) {
+	var req registereddomain.RegisteredDomainRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	rd, ok := s.registeredDomains[id]
+	if id != "" && !ok {
+		writeError(w, http.StatusNotFound, "registered domain not found")
+		return
+	}
+	if !ok {
+		rd = registereddomain.RegisteredDomainInfo{ID: newID(), OrgID: OrgID, Status: "Pending", CreatedBy: "fakeapi", CreatedAt: now()}
+	}
+	rd.Name = req.Name
+	rd.ClusterName = req.ClusterName
+	rd.Cname = req.Cname
+	rd.Description = req.Description
+	rd.RegisteredDomainChallengeID = req.RegisteredDomainChallengeID
+	rd.UpdatedBy = "fakeapi"
+	rd.UpdatedAt = now()
+	s.registeredDomains[rd.ID] = rd
+	writeData(w, rd)
+}
+
+func (s *Server) deleteRegisteredDomain(w http.ResponseWriter, id string) {
+	if _, ok := s.registeredDomains[id]; !ok {
+		writeError(w, http.StatusNotFound, "registered domain not found")
+		return
+	}
+	delete(s.registeredDomains, id)
+	writeData(w, nil)
+}
+
+func (s *Server) validateRegisteredDomain(w http.ResponseWriter, id string) {
+	rd, ok := s.registeredDomains[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "registered domain not found")
+		return
+	}
+	rd.Status = "Validated"
+	s.registeredDomains[id] = rd
+	writeData(w, rd)
+}
+
+func (s *Server) createChallenge(w http.ResponseWriter, r *http.Request) {
+	var req registereddomain.RegisteredDomainChallengeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	challenge := registereddomain.RegisteredDomainChallengeInfo{
+		ID:        newID(),
+		Label:     "_banyanchallenge." + req.RegisteredDomainName,
+		Value:     newID(),
+		CreatedAt: now(),
+	}
+	s.challenges[challenge.ID] = challenge
+	writeData(w, challenge)
+}
+
+func (s *Server) getChallenge(w http.ResponseWriter, id string) {
+	challenge, ok := s.challenges[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "registered domain challenge not found")
+		return
+	}
+	writeData(w, challenge)
+}
+
+func (s *Server) getSCIMCredentials(w http.ResponseWriter) {
+	writeData(w, scim.SCIMCredentialsResponse{BaseURL: s.URL + "/scim", Tokens: s.scimTokens})
+}
+
+func (s *Server) createSCIMCredentials(w http.ResponseWriter) {
+	if !s.scimEnabled {
+		writeError(w, http.StatusBadRequest, "scim is not enabled")
+		return
+	}
+	s.scimTokens = append(s.scimTokens, scim.TokenInfo{UUID: newID(), CreatedAt: now()})
+	writeData(w, scim.CreateSCIMCredentialsResponse{BaseURL: s.URL + "/scim", Token: newID()})
+}
+
+func (s *Server) provisionSCIM(w http.ResponseWriter, r *http.Request) {
+	var req scim.SCIMProvisionRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	s.scimEnabled = req.IsEnabled
+	writeData(w, nil)
+}
+
+func (s *Server) deleteSCIMToken(w http.ResponseWriter, uuid string) {
+	for i, t := range s.scimTokens {
+		if t.UUID == uuid {
+			s.scimTokens = append(s.scimTokens[:i], s.scimTokens[i+1:]...)
+			writeData(w, nil)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "scim token not found")
+}
+
+func (s *Server) getAppConfig(w http.ResponseWriter) {
+	if s.appConfig == nil {
+		writeError(w, http.StatusNotFound, "app config not found")
+		return
+	}
+	writeData(w, s.appConfig)
+}
+
+func (s *Server) saveAppConfig(w http.ResponseWriter, r *http.Request) {
+	var req appconfig.AppConfigRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if s.appConfig == nil {
+		s.appConfig = &appconfig.AppConfigRecord{ID: newID(), OrgID: OrgID, CreatedAt: now()}
+	}
+	if req.NRPTConfig != nil {
+		s.appConfig.NRPTConfig = *req.NRPTConfig
+	}
+	s.appConfig.UpdatedAt = now()
+	writeData(w, s.appConfig)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
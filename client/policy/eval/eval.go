@@ -0,0 +1,417 @@
+// Package eval evaluates Banyan policies offline, answering whether a principal can reach a resource
+// protected by a policy without asking the Banyan API or an access tier.
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+)
+
+// trustLevels orders the trust levels of a device, an empty or unknown trust level satisfies no requirement
+var trustLevels = map[string]int{
+	"low":    1,
+	"medium": 2,
+	"high":   3,
+}
+
+// Principal is the end user and device requesting access.
+type Principal struct {
+	Email           string
+	Groups          []string
+	DeviceOwnership string
+	Platform        string
+	SerialNumber    string
+	KnownDevice     bool
+	MDMPresent      bool
+	// TrustLevel of the device: "High", "Medium", "Low" or empty if it is unknown.
+	TrustLevel string
+}
+
+// Request is what the principal is trying to access. Path and Action describe an application level (L7)
+// request, IP, Port, Protocol and FQDN describe a network level (L4) request. A request with none of them
+// set only asks whether the principal can reach the service at all.
+type Request struct {
+	Path     string
+	Action   string
+	IP       string
+	Port     int
+	Protocol string
+	FQDN     string
+	// Time the request is made at, checked against the start and end time of access groups. Defaults to now.
+	Time time.Time
+}
+
+func (r Request) isL7() bool {
+	return r.Path != "" || r.Action != ""
+}
+
+func (r Request) isL4() bool {
+	return r.IP != "" || r.Port != 0 || r.Protocol != "" || r.FQDN != ""
+}
+
+// Decision is the result of evaluating a policy.
+type Decision struct {
+	Allowed bool
+	// Access is the index of the access group which decided the request, -1 if none did.
+	Access int
+	// AccessName is the name of the access group which decided the request.
+	AccessName string
+	// Roles are the roles of the policy the principal has, sorted as they appear in the policy.
+	Roles []string
+	// Rule describes the rule within the access group which decided the request, e.g. `l7_access[0] resource "!/admin/*"`.
+	// It is empty if the access group grants access to the whole service or no access group decided the request.
+	Rule string
+	// Reason explains the decision in a sentence.
+	Reason string
+}
+
+// Evaluate decides whether the principal is allowed to make the request to a service the policy is attached to.
+// roles maps the names of the roles used by the policy to their specification; referencing a role which is not
+// in roles is an error.
+//
+// An access group applies if the principal has any of its roles and its conditions are satisfied. A deny rule
+// of any applicable access group overrides any allow rule. An applicable access group without L7 rules allows
+// every L7 request, and one without L4 rules allows every L4 request.
+func Evaluate(pol policy.Object, roles map[string]role.Spec, p Principal, r Request) (d Decision, err error) {
+	d.Access = -1
+	if r.Path != "" && r.Action == "" {
+		err = errors.New("an action is required to evaluate a request for a path")
+		return
+	}
+	if r.IP != "" && net.ParseIP(r.IP) == nil {
+		err = fmt.Errorf("invalid IP address %q", r.IP)
+		return
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+
+	var applicable []int
+	var unmet []string
+	for i, access := range pol.Access {
+		var matched []string
+		for _, name := range access.Roles {
+			if name == role.Any {
+				matched = append(matched, name)
+				d.Roles = appendUnique(d.Roles, name)
+				continue
+			}
+			spec, ok := roles[name]
+			if !ok {
+				err = fmt.Errorf("role %s of access group %s is not defined", name, accessName(access, i))
+				return
+			}
+			if RoleMatches(spec, p) {
+				matched = append(matched, name)
+				d.Roles = appendUnique(d.Roles, name)
+			}
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		var reason string
+		reason, err = unmetCondition(access.Rules.Conditions, p, r.Time)
+		if err != nil {
+			err = fmt.Errorf("access group %s: %w", accessName(access, i), err)
+			return
+		}
+		if reason != "" {
+			unmet = append(unmet, fmt.Sprintf("access group %s requires %s", accessName(access, i), reason))
+			continue
+		}
+		applicable = append(applicable, i)
+	}
+
+	for _, i := range applicable {
+		rule, denied := deniedBy(pol.Access[i].Rules, r)
+		if denied {
+			d.decide(pol, i, false, rule)
+			d.Reason = fmt.Sprintf("denied by %s of access group %s", rule, d.AccessName)
+			return
+		}
+	}
+	for _, i := range applicable {
+		rule, allowed := allowedBy(pol.Access[i].Rules, r)
+		if allowed {
+			d.decide(pol, i, true, rule)
+			if rule == "" {
+				d.Reason = fmt.Sprintf("access group %s grants access to the whole service", d.AccessName)
+			} else {
+				d.Reason = fmt.Sprintf("allowed by %s of access group %s", rule, d.AccessName)
+			}
+			return
+		}
+	}
+
+	switch {
+	case len(applicable) > 0:
+		d.Reason = "no rule of the access groups of the principal allows the request"
+	case len(unmet) > 0:
+		d.Reason = strings.Join(unmet, ", ")
+	default:
+		d.Reason = "the principal has none of the roles of the policy"
+	}
+	return
+}
+
+func (d *Decision) decide(pol policy.Object, i int, allowed bool, rule string) {
+	d.Allowed = allowed
+	d.Access = i
+	d.AccessName = accessName(pol.Access[i], i)
+	d.Rule = rule
+}
+
+// accessName returns the name of the access group, or its index if it has none
+func accessName(access policy.Access, i int) string {
+	if access.Name != "" {
+		return access.Name
+	}
+	return fmt.Sprintf("#%d", i)
+}
+
+// RoleMatches returns true if the principal has the role. Every user or device attribute set in the role has
+// to match; a value matches if it is equal to any of the values of that attribute, or matches one with a leading
+// or trailing "*" wildcard. A role without user or device attributes, such as a workload role, matches no principal.
+func RoleMatches(spec role.Spec, p Principal) bool {
+	if len(spec.UserGroup) == 0 && len(spec.Email) == 0 && len(spec.DeviceOwnership) == 0 && len(spec.Platform) == 0 &&
+		len(spec.SerialNumbers) == 0 && !spec.KnownDeviceOnly && !spec.MDMPresent {
+		return false
+	}
+	if len(spec.UserGroup) > 0 && !anyMatches(spec.UserGroup, p.Groups...) {
+		return false
+	}
+	if len(spec.Email) > 0 && !anyMatches(spec.Email, p.Email) {
+		return false
+	}
+	if len(spec.DeviceOwnership) > 0 && !anyMatches(spec.DeviceOwnership, p.DeviceOwnership) {
+		return false
+	}
+	if len(spec.Platform) > 0 && !anyMatches(spec.Platform, p.Platform) {
+		return false
+	}
+	if len(spec.SerialNumbers) > 0 && !anyMatches(spec.SerialNumbers, p.SerialNumber) {
+		return false
+	}
+	if spec.KnownDeviceOnly && !p.KnownDevice {
+		return false
+	}
+	if spec.MDMPresent && !p.MDMPresent {
+		return false
+	}
+	return true
+}
+
+// unmetCondition returns a description of the condition the principal does not satisfy, or an empty string
+func unmetCondition(c policy.Conditions, p Principal, at time.Time) (reason string, err error) {
+	if c.TrustLevel != "" {
+		required, ok := trustLevels[strings.ToLower(c.TrustLevel)]
+		if !ok {
+			err = fmt.Errorf("invalid trust level %q", c.TrustLevel)
+			return
+		}
+		if trustLevels[strings.ToLower(p.TrustLevel)] < required {
+			return fmt.Sprintf("trust level %s", c.TrustLevel), nil
+		}
+	}
+	if c.StartTime != "" {
+		var start time.Time
+		start, err = time.Parse(time.RFC3339, c.StartTime)
+		if err != nil {
+			return
+		}
+		if at.Before(start) {
+			return fmt.Sprintf("a request after %s", c.StartTime), nil
+		}
+	}
+	if c.EndTime != "" {
+		var end time.Time
+		end, err = time.Parse(time.RFC3339, c.EndTime)
+		if err != nil {
+			return
+		}
+		if at.After(end) {
+			return fmt.Sprintf("a request before %s", c.EndTime), nil
+		}
+	}
+	return
+}
+
+// deniedBy returns the deny rule which matches the request
+func deniedBy(rules policy.Rules, r Request) (rule string, denied bool) {
+	if r.isL7() {
+		for j, l7 := range rules.L7Access {
+			if !actionMatches(l7, r.Action) {
+				continue
+			}
+			for _, res := range l7.Resources {
+				if strings.HasPrefix(res, "!") && wildcardMatch(strings.TrimPrefix(res, "!"), r.Path, false) {
+					return fmt.Sprintf("l7_access[%d] resource %q", j, res), true
+				}
+			}
+		}
+	}
+	if r.isL4() && rules.L4Access != nil {
+		for k, l4 := range rules.L4Access.Deny {
+			if l4RuleMatches(l4, r) {
+				return fmt.Sprintf("l4_access deny[%d]", k), true
+			}
+		}
+	}
+	return
+}
+
+// allowedBy returns the allow rule which matches the request, rule is empty if the rules allow the whole service
+func allowedBy(rules policy.Rules, r Request) (rule string, allowed bool) {
+	if r.isL7() && len(rules.L7Access) > 0 {
+		for j, l7 := range rules.L7Access {
+			if !actionMatches(l7, r.Action) {
+				continue
+			}
+			for _, res := range l7.Resources {
+				if !strings.HasPrefix(res, "!") && wildcardMatch(res, r.Path, false) {
+					return fmt.Sprintf("l7_access[%d] resource %q", j, res), true
+				}
+			}
+		}
+		return
+	}
+	if r.isL4() && rules.L4Access != nil {
+		for k, l4 := range rules.L4Access.Allow {
+			if l4RuleMatches(l4, r) {
+				return fmt.Sprintf("l4_access allow[%d]", k), true
+			}
+		}
+		return
+	}
+	return "", true
+}
+
+func actionMatches(l7 policy.L7Access, action string) bool {
+	for _, a := range append(l7.Actions, l7.Action...) {
+		if a == policy.L7_ACCESS_ACTION_ALL || strings.EqualFold(a, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// l4RuleMatches returns true if every field set in the rule matches the request
+func l4RuleMatches(rule policy.L4Rule, r Request) bool {
+	if len(rule.CIDRs) > 0 && !cidrsMatch(rule.CIDRs, r.IP) {
+		return false
+	}
+	if len(rule.Protocols) > 0 && !protocolsMatch(rule.Protocols, r.Protocol) {
+		return false
+	}
+	if len(rule.Ports) > 0 && !portsMatch(rule.Ports, r.Port) {
+		return false
+	}
+	if len(rule.FQDNs) > 0 && !anyMatches(rule.FQDNs, r.FQDN) {
+		return false
+	}
+	return true
+}
+
+func cidrsMatch(cidrs []string, ip string) bool {
+	for _, cidr := range cidrs {
+		if cidr == "*" {
+			return true
+		}
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			continue
+		}
+		if _, network, err := net.ParseCIDR(cidr); err == nil && network.Contains(addr) {
+			return true
+		}
+		if other := net.ParseIP(cidr); other != nil && other.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func protocolsMatch(protocols []string, protocol string) bool {
+	for _, p := range protocols {
+		if strings.EqualFold(p, "ALL") || p == "*" || (protocol != "" && strings.EqualFold(p, protocol)) {
+			return true
+		}
+	}
+	return false
+}
+
+// portsMatch accepts ports in the form "443", "8000-9000" or "*"
+func portsMatch(ports []string, port int) bool {
+	for _, p := range ports {
+		if p == "*" {
+			return true
+		}
+		low, high, isRange := strings.Cut(p, "-")
+		min, err := strconv.Atoi(strings.TrimSpace(low))
+		if err != nil {
+			continue
+		}
+		max := min
+		if isRange {
+			max, err = strconv.Atoi(strings.TrimSpace(high))
+			if err != nil {
+				continue
+			}
+		}
+		if port != 0 && port >= min && port <= max {
+			return true
+		}
+	}
+	return false
+}
+
+// anyMatches returns true if any of the values matches any of the patterns, ignoring case
+func anyMatches(patterns []string, values ...string) bool {
+	for _, pattern := range patterns {
+		for _, v := range values {
+			if v != "" && wildcardMatch(pattern, v, true) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wildcardMatch matches s against a pattern which may have a "*" wildcard prefix, suffix or both
+func wildcardMatch(pattern, s string, ignoreCase bool) bool {
+	if ignoreCase {
+		pattern = strings.ToLower(pattern)
+		s = strings.ToLower(s)
+	}
+	if pattern == "*" {
+		return true
+	}
+	prefix := strings.HasPrefix(pattern, "*")
+	suffix := strings.HasSuffix(pattern, "*")
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(pattern, "*"), "*")
+	switch {
+	case prefix && suffix:
+		return strings.Contains(s, trimmed)
+	case prefix:
+		return strings.HasSuffix(s, trimmed)
+	case suffix:
+		return strings.HasPrefix(s, trimmed)
+	}
+	return s == pattern
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
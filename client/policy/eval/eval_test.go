@@ -0,0 +1,152 @@
+package eval_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy/eval"
+	"github.com/banyansecurity/terraform-banyan-provider/client/role"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var roles = map[string]role.Spec{
+	"engineers":   {UserGroup: []string{"Engineering"}},
+	"contractors": {Email: []string{"*@contractor.example.com"}},
+	"corporate":   {DeviceOwnership: []string{"Corporate Dedicated"}, Platform: []string{"macOS", "Windows"}},
+	"workloads":   {ContainerFQDN: []string{"api.internal"}},
+}
+
+var webPolicy = policy.Object{
+	Spec: policy.Spec{
+		Access: []policy.Access{
+			{
+				Name:  "engineering",
+				Roles: []string{"engineers"},
+				Rules: policy.Rules{
+					L7Access: []policy.L7Access{
+						{Resources: []string{"!/admin/*"}, Actions: []string{"WRITE"}},
+						{Resources: []string{"*"}, Actions: []string{"*"}},
+					},
+					Conditions: policy.Conditions{TrustLevel: "Medium"},
+				},
+			},
+			{
+				Roles: []string{"contractors"},
+				Rules: policy.Rules{
+					L7Access: []policy.L7Access{
+						{Resources: []string{"/docs/*"}, Actions: []string{"read"}},
+					},
+					Conditions: policy.Conditions{TrustLevel: "Low", EndTime: "2030-01-01T00:00:00Z"},
+				},
+			},
+		},
+	},
+}
+
+var tunnelPolicy = policy.Object{
+	Spec: policy.Spec{
+		Access: []policy.Access{
+			{
+				Name:  "corporate",
+				Roles: []string{"corporate", "workloads"},
+				Rules: policy.Rules{
+					L4Access: &policy.L4Access{
+						Deny: []policy.L4Rule{
+							{CIDRs: []string{"10.10.1.0/24"}, Protocols: []string{"ALL"}, Ports: []string{"*"}},
+						},
+						Allow: []policy.L4Rule{
+							{CIDRs: []string{"10.10.0.0/16"}, Protocols: []string{"TCP"}, Ports: []string{"22", "8000-9000"}},
+							{FQDNs: []string{"*.internal.example.com"}, Protocols: []string{"ALL"}, Ports: []string{"*"}},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+var at = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func Test_Evaluate(t *testing.T) {
+	engineer := eval.Principal{Email: "jane@example.com", Groups: []string{"Engineering"}, TrustLevel: "High"}
+	contractor := eval.Principal{Email: "bob@contractor.example.com", TrustLevel: "Low"}
+	laptop := eval.Principal{Email: "jane@example.com", DeviceOwnership: "Corporate Dedicated", Platform: "macOS"}
+
+	tests := []struct {
+		name      string
+		policy    policy.Object
+		principal eval.Principal
+		request   eval.Request
+		allowed   bool
+		access    int
+		rule      string
+	}{
+		{"l7 allow all", webPolicy, engineer, eval.Request{Path: "/app", Action: "READ"}, true, 0, `l7_access[1] resource "*"`},
+		{"l7 deny overrides allow", webPolicy, engineer, eval.Request{Path: "/admin/users", Action: "write"}, false, 0, `l7_access[0] resource "!/admin/*"`},
+		{"l7 deny only applies to its actions", webPolicy, engineer, eval.Request{Path: "/admin/users", Action: "READ"}, true, 0, `l7_access[1] resource "*"`},
+		{"trust level too low", webPolicy, eval.Principal{Groups: []string{"Engineering"}, TrustLevel: "Low"}, eval.Request{Path: "/app", Action: "READ"}, false, -1, ""},
+		{"wildcard email", webPolicy, contractor, eval.Request{Path: "/docs/index.html", Action: "READ"}, true, 1, `l7_access[0] resource "/docs/*"`},
+		{"no matching resource", webPolicy, contractor, eval.Request{Path: "/app", Action: "READ"}, false, -1, ""},
+		{"expired", webPolicy, contractor, eval.Request{Path: "/docs/index.html", Action: "READ", Time: time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)}, false, -1, ""},
+		{"no role", webPolicy, eval.Principal{Email: "eve@example.com", TrustLevel: "High"}, eval.Request{Path: "/app", Action: "READ"}, false, -1, ""},
+		{"service level", webPolicy, engineer, eval.Request{}, true, 0, ""},
+		{"l4 allow cidr and port range", tunnelPolicy, laptop, eval.Request{IP: "10.10.2.5", Port: 8080, Protocol: "tcp"}, true, 0, "l4_access allow[0]"},
+		{"l4 deny overrides allow", tunnelPolicy, laptop, eval.Request{IP: "10.10.1.5", Port: 22, Protocol: "TCP"}, false, 0, "l4_access deny[0]"},
+		{"l4 wrong protocol", tunnelPolicy, laptop, eval.Request{IP: "10.10.2.5", Port: 22, Protocol: "UDP"}, false, -1, ""},
+		{"l4 fqdn", tunnelPolicy, laptop, eval.Request{FQDN: "git.internal.example.com", Port: 443, Protocol: "TCP"}, true, 0, "l4_access allow[1]"},
+		{"l4 wrong platform", tunnelPolicy, eval.Principal{DeviceOwnership: "Corporate Dedicated", Platform: "Linux"}, eval.Request{IP: "10.10.2.5", Port: 22, Protocol: "TCP"}, false, -1, ""},
+		{"l7 request allowed without l7 rules", tunnelPolicy, laptop, eval.Request{Path: "/", Action: "READ"}, true, 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.request.Time.IsZero() {
+				tt.request.Time = at
+			}
+			d, err := eval.Evaluate(tt.policy, roles, tt.principal, tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, d.Allowed, d.Reason)
+			assert.Equal(t, tt.access, d.Access, d.Reason)
+			assert.Equal(t, tt.rule, d.Rule, d.Reason)
+			assert.NotEmpty(t, d.Reason)
+		})
+	}
+}
+
+func Test_EvaluateReasons(t *testing.T) {
+	d, err := eval.Evaluate(webPolicy, roles, eval.Principal{Groups: []string{"Engineering"}}, eval.Request{Time: at})
+	require.NoError(t, err)
+	assert.Equal(t, "access group engineering requires trust level Medium", d.Reason)
+	assert.Equal(t, []string{"engineers"}, d.Roles)
+
+	d, err = eval.Evaluate(webPolicy, roles, eval.Principal{Email: "eve@example.com"}, eval.Request{Time: at})
+	require.NoError(t, err)
+	assert.Equal(t, "the principal has none of the roles of the policy", d.Reason)
+}
+
+func Test_EvaluateAnyRole(t *testing.T) {
+	pol := policy.Object{Spec: policy.Spec{Access: []policy.Access{{Roles: []string{role.Any}}}}}
+	d, err := eval.Evaluate(pol, map[string]role.Spec{}, eval.Principal{Email: "eve@example.com"}, eval.Request{Time: at})
+	require.NoError(t, err)
+	assert.True(t, d.Allowed, d.Reason)
+	assert.Equal(t, []string{role.Any}, d.Roles)
+}
+
+func Test_EvaluateErrors(t *testing.T) {
+	_, err := eval.Evaluate(webPolicy, map[string]role.Spec{}, eval.Principal{}, eval.Request{})
+	assert.EqualError(t, err, "role engineers of access group engineering is not defined")
+
+	_, err = eval.Evaluate(webPolicy, roles, eval.Principal{}, eval.Request{Path: "/app"})
+	assert.Error(t, err)
+
+	_, err = eval.Evaluate(tunnelPolicy, roles, eval.Principal{}, eval.Request{IP: "10.10.300.1"})
+	assert.Error(t, err)
+}
+
+func Test_RoleMatches(t *testing.T) {
+	assert.True(t, eval.RoleMatches(roles["contractors"], eval.Principal{Email: "Bob@Contractor.Example.com"}))
+	assert.False(t, eval.RoleMatches(roles["workloads"], eval.Principal{Email: "api.internal"}))
+	assert.False(t, eval.RoleMatches(role.Spec{}, eval.Principal{Email: "jane@example.com"}))
+	assert.False(t, eval.RoleMatches(role.Spec{Email: []string{"*"}, MDMPresent: true}, eval.Principal{Email: "jane@example.com"}))
+}
@@ -1,6 +1,7 @@
 package policy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html"
@@ -29,20 +30,21 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get(id string) (spec GetPolicy, err error)
-	GetName(name string) (spec GetPolicy, err error)
-	Create(policy Object) (created GetPolicy, err error)
-	Update(policy Object) (updated GetPolicy, err error)
-	Delete(id string) (err error)
-	Detach(paClient policyattachment.Client, id string) (err error)
+	Get(ctx context.Context, id string) (spec GetPolicy, err error)
+	GetName(ctx context.Context, name string) (spec GetPolicy, err error)
+	GetAll(ctx context.Context) (specs []GetPolicy, err error)
+	Create(ctx context.Context, policy Object) (created GetPolicy, err error)
+	Update(ctx context.Context, policy Object) (updated GetPolicy, err error)
+	Delete(ctx context.Context, id string) (err error)
+	Detach(ctx context.Context, paClient policyattachment.Client, id string) (err error)
 }
 
-func (p *policy) Get(id string) (spec GetPolicy, err error) {
-	spec, err = p.GetQuery("PolicyID", id)
+func (p *policy) Get(ctx context.Context, id string) (spec GetPolicy, err error) {
+	spec, err = p.GetQuery(ctx, "PolicyID", id)
 	return
 }
 
-func (p *policy) Create(policy Object) (created GetPolicy, err error) {
+func (p *policy) Create(ctx context.Context, policy Object) (created GetPolicy, err error) {
 	log.Printf("[INFO] Creating policy %s", policy.Name)
 	path := "api/v1/insert_security_policy"
 	body, err := json.Marshal(policy)
@@ -52,7 +54,7 @@ func (p *policy) Create(policy Object) (created GetPolicy, err error) {
 	// The API will always clobber, which leads to odd behavior
 	// This aligns behavior with user expectations
 	// Don't clobber if the policy name is in use
-	existing, err := p.GetName(policy.Name)
+	existing, err := p.GetName(ctx, policy.Name)
 	if err != nil {
 		return
 	}
@@ -60,7 +62,7 @@ func (p *policy) Create(policy Object) (created GetPolicy, err error) {
 		err = fmt.Errorf("the policy name %s already in use", policy.Name)
 		return
 	}
-	resp, err := p.restClient.Create(apiVersion, component, body, path)
+	resp, err := p.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -73,14 +75,14 @@ func (p *policy) Create(policy Object) (created GetPolicy, err error) {
 	return
 }
 
-func (p *policy) Update(policy Object) (updated GetPolicy, err error) {
+func (p *policy) Update(ctx context.Context, policy Object) (updated GetPolicy, err error) {
 	log.Printf("[INFO] Updating policy %s", policy.Name)
 	body, err := json.Marshal(policy)
 	if err != nil {
 		return
 	}
 	path := "api/v1/insert_security_policy"
-	resp, err := p.restClient.Create(apiVersion, component, body, path)
+	resp, err := p.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -93,13 +95,13 @@ func (p *policy) Update(policy Object) (updated GetPolicy, err error) {
 	return
 }
 
-func (p *policy) Detach(paClient policyattachment.Client, id string) (err error) {
+func (p *policy) Detach(ctx context.Context, paClient policyattachment.Client, id string) (err error) {
 	log.Printf("[INFO] Detaching policy %s", id)
-	err = paClient.Delete(id)
+	err = paClient.Delete(ctx, id)
 	return
 }
 
-func (p *policy) Delete(id string) (err error) {
+func (p *policy) Delete(ctx context.Context, id string) (err error) {
 	log.Printf("[INFO] Deleting policy %s", id)
 	path := "api/v1/delete_security_policy"
 	myUrl, err := url.Parse(path)
@@ -109,11 +111,11 @@ func (p *policy) Delete(id string) (err error) {
 	query := myUrl.Query()
 	query.Set("PolicyID", id)
 	myUrl.RawQuery = query.Encode()
-	err = p.restClient.DeleteQuery(component, id, query, path)
+	err = p.restClient.DeleteQueryContext(ctx, component, id, query, path)
 	return
 }
 
-func (p *policy) GetQuery(key string, value string) (spec GetPolicy, err error) {
+func (p *policy) GetQuery(ctx context.Context, key string, value string) (spec GetPolicy, err error) {
 	path := "api/v1/security_policies"
 	myUrl, err := url.Parse(path)
 	if err != nil {
@@ -121,7 +123,7 @@ func (p *policy) GetQuery(key string, value string) (spec GetPolicy, err error)
 	}
 	query := myUrl.Query()
 	query.Set(key, value)
-	resp, err := p.restClient.ReadQuery(component, query, path)
+	resp, err := p.restClient.ReadQueryContext(ctx, component, query, path)
 	if err != nil {
 		return
 	}
@@ -131,7 +133,7 @@ func (p *policy) GetQuery(key string, value string) (spec GetPolicy, err error)
 		return
 	}
 	if len(j) == 0 {
-		err = errors.New("did not get policy")
+		err = fmt.Errorf("policy %w", restclient.ErrNotFound)
 		return
 	}
 	if len(j) > 1 {
@@ -148,23 +150,27 @@ func (p *policy) GetQuery(key string, value string) (spec GetPolicy, err error)
 }
 
 // GetName Need to add new API query parameters
-func (p *policy) GetName(name string) (spec GetPolicy, err error) {
-	specs, err := p.GetAll()
+func (p *policy) GetName(ctx context.Context, name string) (spec GetPolicy, err error) {
+	specs, err := p.GetAll(ctx)
 	if err != nil {
 		return
 	}
 	spec, err = findByName(name, specs)
+	if err != nil || spec.ID == "" {
+		return
+	}
+	err = json.Unmarshal([]byte(html.UnescapeString(spec.Spec)), &spec.UnmarshalledPolicy)
 	return
 }
 
-func (p *policy) GetAll() (specs []GetPolicy, err error) {
+func (p *policy) GetAll(ctx context.Context) (specs []GetPolicy, err error) {
 	path := "api/v1/security_policies"
 	myUrl, err := url.Parse(path)
 	if err != nil {
 		return
 	}
 	query := myUrl.Query()
-	resp, err := p.restClient.ReadQuery(component, query, path)
+	resp, err := p.restClient.ReadQueryContext(ctx, component, query, path)
 	if err != nil {
 		return
 	}
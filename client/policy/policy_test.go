@@ -1,9 +1,11 @@
 package policy_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,8 +14,8 @@ func Test_GetNonexistentService(t *testing.T) {
 	emptyPolicy := policy.GetPolicy{}
 	client, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	myPolicy, err := client.Policy.Get("heh")
-	assert.NoError(t, err, "expected no error here")
+	myPolicy, err := client.Policy.Get(context.Background(), "heh")
+	assert.True(t, restclient.IsNotFound(err), "expected a not found error here")
 	assert.Equal(t, emptyPolicy, myPolicy, "expected to get service x")
 }
 
@@ -22,7 +24,7 @@ func Test_GetExistingPolicy(t *testing.T) {
 	assert.NoError(t, err, "Expected to not get an error here")
 	emptyPolicy := policy.GetPolicy{}
 	emptyPolicy.CreatedBy = "me"
-	myPolicy, err := client.Policy.Get("9ddf21be-2db3-42f6-aa77-2d1a61931278")
+	myPolicy, err := client.Policy.Get(context.Background(), "9ddf21be-2db3-42f6-aa77-2d1a61931278")
 	assert.NoError(t, err, "expected no error here")
 	assert.NotEqual(t, emptyPolicy, myPolicy, "expected to get service x")
 }
@@ -2,6 +2,7 @@ package policyattachment
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,24 +30,21 @@ func NewClient(restClient *restclient.Client) Client {
 
 // Client is used to perform CRUD operations against the policy attachment resource
 type Client interface {
-	Get(attachedToID string, attachedToType string) (attachment GetBody, err error)
-	Create(policyID string, PolicyAttachment CreateBody) (createdAttachment GetBody, err error)
-	Update(policyID string, PolicyAttachment CreateBody) (updatedAttachment GetBody, err error)
-	Delete(policyID string) (err error)
-	DeleteServiceAttachment(policyID string, serviceID string) (err error)
+	Get(ctx context.Context, attachedToID string, attachedToType string) (attachment GetBody, err error)
+	Create(ctx context.Context, policyID string, PolicyAttachment CreateBody) (createdAttachment GetBody, err error)
+	Update(ctx context.Context, policyID string, PolicyAttachment CreateBody) (updatedAttachment GetBody, err error)
+	Delete(ctx context.Context, policyID string) (err error)
+	DeleteServiceAttachment(ctx context.Context, policyID string, serviceID string) (err error)
 }
 
-func (p *PolicyAttachment) Get(attachedToID string, attachedToType string) (attachment GetBody, err error) {
+func (p *PolicyAttachment) Get(ctx context.Context, attachedToID string, attachedToType string) (attachment GetBody, err error) {
 	path := fmt.Sprintf("api/v1/policy/attachment/%s/%s", attachedToType, attachedToID)
 	myUrl, _ := url.Parse(path)
-	response, err := p.restClient.DoGet(myUrl.String())
+	response, err := p.restClient.DoGetContext(ctx, myUrl.String())
 	if err != nil {
-		if response.StatusCode == 404 {
-			err = nil
-			return
-		}
 		return
 	}
+	defer response.Body.Close()
 	responseData, err := io.ReadAll(response.Body)
 	if err != nil {
 		return
@@ -76,14 +74,14 @@ func (p *PolicyAttachment) Get(attachedToID string, attachedToType string) (atta
 	return
 }
 
-func (p *PolicyAttachment) createServiceAttachment(policyID string, PolicyAttachment CreateBody) (createdAttachment GetBody, err error) {
+func (p *PolicyAttachment) createServiceAttachment(ctx context.Context, policyID string, PolicyAttachment CreateBody) (createdAttachment GetBody, err error) {
 	path := "/api/v1/insert_security_attach_policy"
 	form := url.Values{}
 	form.Add("PolicyID", policyID)
 	form.Add("ServiceID", PolicyAttachment.AttachedToID)
 	form.Add("Enabled", PolicyAttachment.Enabled)
 
-	request, err := p.restClient.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	request, err := p.restClient.NewRequestWithContext(ctx, http.MethodPost, path, strings.NewReader(form.Encode()))
 	if err != nil {
 		return
 	}
@@ -120,17 +118,17 @@ func (p *PolicyAttachment) createServiceAttachment(policyID string, PolicyAttach
 	return
 }
 
-func (p *PolicyAttachment) Create(policyID string, PolicyAttachment CreateBody) (createdAttachment GetBody, err error) {
+func (p *PolicyAttachment) Create(ctx context.Context, policyID string, PolicyAttachment CreateBody) (createdAttachment GetBody, err error) {
 	log.Printf("[INFO] Creating policy attachment %v", PolicyAttachment)
 	if PolicyAttachment.AttachedToType == "service" {
-		return p.createServiceAttachment(policyID, PolicyAttachment)
+		return p.createServiceAttachment(ctx, policyID, PolicyAttachment)
 	}
 	path := fmt.Sprintf("/api/v1/policy/%s/attach", policyID)
 	body, err := json.Marshal(PolicyAttachment)
 	if err != nil {
 		return
 	}
-	response, err := p.restClient.DoPut(path, bytes.NewBuffer(body))
+	response, err := p.restClient.DoPutContext(ctx, path, bytes.NewBuffer(body))
 	if err != nil {
 		return
 	}
@@ -151,12 +149,12 @@ func (p *PolicyAttachment) Create(policyID string, PolicyAttachment CreateBody)
 	return
 }
 
-func (p *PolicyAttachment) Update(policyID string, attachment CreateBody) (updatedAttachment GetBody, err error) {
-	updatedAttachment, err = p.Create(policyID, attachment)
+func (p *PolicyAttachment) Update(ctx context.Context, policyID string, attachment CreateBody) (updatedAttachment GetBody, err error) {
+	updatedAttachment, err = p.Create(ctx, policyID, attachment)
 	return
 }
 
-func (p *PolicyAttachment) DeleteServiceAttachment(policyID, serviceID string) (err error) {
+func (p *PolicyAttachment) DeleteServiceAttachment(ctx context.Context, policyID, serviceID string) (err error) {
 	path := "api/v1/delete_security_attach_policy"
 
 	myUrl, err := url.Parse(path)
@@ -167,7 +165,7 @@ func (p *PolicyAttachment) DeleteServiceAttachment(policyID, serviceID string) (
 	query.Set("PolicyID", policyID)
 	query.Set("ServiceID", serviceID)
 	myUrl.RawQuery = query.Encode()
-	resp, err := p.restClient.DoDelete(myUrl.String())
+	resp, err := p.restClient.DoDeleteContext(ctx, myUrl.String())
 	if err != nil {
 		return
 	}
@@ -178,18 +176,18 @@ func (p *PolicyAttachment) DeleteServiceAttachment(policyID, serviceID string) (
 	return
 }
 
-func (p *PolicyAttachment) Delete(policyID string) (err error) {
-	pAttachment, err := p.Get(policyID, "service")
+func (p *PolicyAttachment) Delete(ctx context.Context, policyID string) (err error) {
+	pAttachment, err := p.Get(ctx, policyID, "service")
 	if err != nil {
 		return
 	}
 	if pAttachment.AttachedToType == "service" {
-		err = p.DeleteServiceAttachment(policyID, pAttachment.AttachedToID)
+		err = p.DeleteServiceAttachment(ctx, policyID, pAttachment.AttachedToID)
 		if err != nil {
 			return
 		}
 	}
-	pAttachment, err = p.Get(policyID, "service_tunnel")
+	pAttachment, err = p.Get(ctx, policyID, "service_tunnel")
 	if pAttachment.AttachedToType == "service_tunnel" {
 		err = detachServiceTunnel(p.restClient, pAttachment)
 		if err != nil {
@@ -1,6 +1,7 @@
 package policyattachment_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/policyattachment"
@@ -12,7 +13,7 @@ func Test_GetNonexistentAttachment(t *testing.T) {
 	emptyAttachment := policyattachment.GetBody{}
 	client, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	attachment, err := client.PolicyAttachment.Get("hi", "service")
+	attachment, err := client.PolicyAttachment.Get(context.Background(), "hi", "service")
 	assert.NoError(t, err, "expected no error here")
 	assert.False(t, false, "expected to get a value here")
 
@@ -34,9 +35,9 @@ func Test_CreateGetUpdateGetThenDeleteAttachment(t *testing.T) {
 		IsEnabled:      true,
 		Enabled:        "TRUE",
 	}
-	createdAttachment, err := client.PolicyAttachment.Create(everyonePolicyID, createAttachment)
+	createdAttachment, err := client.PolicyAttachment.Create(context.Background(), everyonePolicyID, createAttachment)
 	assert.NoError(t, err)
-	retrievedAttachment, err := client.PolicyAttachment.Get(testServiceID, attachedToType)
+	retrievedAttachment, err := client.PolicyAttachment.Get(context.Background(), testServiceID, attachedToType)
 	assert.NoError(t, err)
 	assert.True(t, true)
 	// handle slight bug here
@@ -45,21 +46,21 @@ func Test_CreateGetUpdateGetThenDeleteAttachment(t *testing.T) {
 	assert.Equal(t, createdAttachment, retrievedAttachment)
 	createAttachment.IsEnabled = false
 	createAttachment.Enabled = "FALSE"
-	updatedAttachment, err := client.PolicyAttachment.Update(everyonePolicyID, createAttachment)
+	updatedAttachment, err := client.PolicyAttachment.Update(context.Background(), everyonePolicyID, createAttachment)
 	assert.NoError(t, err)
 	assert.NotEqual(t, updatedAttachment, createdAttachment)
-	retrievedAttachment, err = client.PolicyAttachment.Get(testServiceID, attachedToType)
+	retrievedAttachment, err = client.PolicyAttachment.Get(context.Background(), testServiceID, attachedToType)
 	assert.NoError(t, err)
 	assert.True(t, true)
 	updatedAttachment.PolicyName = everyonePolicyName
 	updatedAttachment.AttachedToName = testServiceName
 	assert.Equal(t, updatedAttachment, retrievedAttachment)
 
-	//client.PolicyAttachment.Delete(everyonePolicyID, policyattachment.DetachBody{
+	//client.PolicyAttachment.Delete(context.Background(), everyonePolicyID, policyattachment.DetachBody{
 	//	AttachedToID:   testServiceID,
 	//	AttachedToType: attachedToType,
 	//})
-	_, err = client.PolicyAttachment.Get(testServiceID, attachedToType)
+	_, err = client.PolicyAttachment.Get(context.Background(), testServiceID, attachedToType)
 	assert.NoError(t, err)
 	assert.False(t, false)
 }
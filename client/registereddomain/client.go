@@ -1,8 +1,10 @@
 package registereddomain
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 )
@@ -16,13 +18,14 @@ type RegisteredDomain struct {
 }
 
 type Client interface {
-	Get(id string) (resp RegisteredDomainInfo, err error)
-	Create(RDReqBody RegisteredDomainRequest) (resp RegisteredDomainInfo, err error)
-	Update(id string, RDReqBody RegisteredDomainRequest) (resp RegisteredDomainInfo, err error)
-	Delete(id string) (err error)
-	CreateRDChallenge(RDChallengeReqBody RegisteredDomainChallengeRequest) (RegisteredDomainChallengeID string, err error)
-	GetRDChallenge(id string) (getResp RegisteredDomainChallengeInfo, err error)
-	ValidateDomain(id string) (domainInfo RegisteredDomainInfo, err error)
+	Get(ctx context.Context, id string) (resp RegisteredDomainInfo, err error)
+	GetAll(ctx context.Context) (resp []RegisteredDomainInfo, err error)
+	Create(ctx context.Context, RDReqBody RegisteredDomainRequest) (resp RegisteredDomainInfo, err error)
+	Update(ctx context.Context, id string, RDReqBody RegisteredDomainRequest) (resp RegisteredDomainInfo, err error)
+	Delete(ctx context.Context, id string) (err error)
+	CreateRDChallenge(ctx context.Context, RDChallengeReqBody RegisteredDomainChallengeRequest) (RegisteredDomainChallengeID string, err error)
+	GetRDChallenge(ctx context.Context, id string) (getResp RegisteredDomainChallengeInfo, err error)
+	ValidateDomain(ctx context.Context, id string) (domainInfo RegisteredDomainInfo, err error)
 }
 
 func NewClient(restClient *restclient.Client) Client {
@@ -32,8 +35,8 @@ func NewClient(restClient *restclient.Client) Client {
 	return &client
 }
 
-func (a *RegisteredDomain) Get(id string) (resp RegisteredDomainInfo, err error) {
-	getResp, err := a.restClient.Read(apiVersion, registeredDomainComponent, id, "")
+func (a *RegisteredDomain) Get(ctx context.Context, id string) (resp RegisteredDomainInfo, err error) {
+	getResp, err := a.restClient.ReadContext(ctx, apiVersion, registeredDomainComponent, id, "")
 	if err != nil {
 		return
 	}
@@ -46,13 +49,31 @@ func (a *RegisteredDomain) Get(id string) (resp RegisteredDomainInfo, err error)
 	return
 }
 
-func (a *RegisteredDomain) Create(reqBody RegisteredDomainRequest) (createResp RegisteredDomainInfo, err error) {
+// GetAll returns every registered domain in the org
+func (a *RegisteredDomain) GetAll(ctx context.Context) (resp []RegisteredDomainInfo, err error) {
+	getResp, err := a.restClient.ReadQueryContext(ctx, registeredDomainComponent, url.Values{}, fmt.Sprintf("%s/%s", apiVersion, registeredDomainComponent))
+	if err != nil {
+		return
+	}
+
+	var j RDListResponse
+	err = json.Unmarshal(getResp, &j)
+	if err != nil {
+		return
+	}
+
+	resp = j.Data
+
+	return
+}
+
+func (a *RegisteredDomain) Create(ctx context.Context, reqBody RegisteredDomainRequest) (createResp RegisteredDomainInfo, err error) {
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		return
 	}
 
-	resp, err := a.restClient.Create(apiVersion, registeredDomainComponent, body, "")
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, registeredDomainComponent, body, "")
 	if err != nil {
 		return
 	}
@@ -65,12 +86,12 @@ func (a *RegisteredDomain) Create(reqBody RegisteredDomainRequest) (createResp R
 	return
 }
 
-func (a *RegisteredDomain) Update(id string, reqBody RegisteredDomainRequest) (updateResponse RegisteredDomainInfo, err error) {
+func (a *RegisteredDomain) Update(ctx context.Context, id string, reqBody RegisteredDomainRequest) (updateResponse RegisteredDomainInfo, err error) {
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Update(apiVersion, registeredDomainComponent, id, body, "")
+	resp, err := a.restClient.UpdateContext(ctx, apiVersion, registeredDomainComponent, id, body, "")
 	if err != nil {
 		return
 	}
@@ -82,8 +103,8 @@ func (a *RegisteredDomain) Update(id string, reqBody RegisteredDomainRequest) (u
 	return
 }
 
-func (a *RegisteredDomain) Delete(id string) (err error) {
-	err = a.restClient.Delete(apiVersion, registeredDomainComponent, id, "")
+func (a *RegisteredDomain) Delete(ctx context.Context, id string) (err error) {
+	err = a.restClient.DeleteContext(ctx, apiVersion, registeredDomainComponent, id, "")
 	if err != nil {
 		return
 	}
@@ -91,13 +112,13 @@ func (a *RegisteredDomain) Delete(id string) (err error) {
 	return
 }
 
-func (a *RegisteredDomain) CreateRDChallenge(reqBody RegisteredDomainChallengeRequest) (RegisteredDomainChallengeID string, err error) {
+func (a *RegisteredDomain) CreateRDChallenge(ctx context.Context, reqBody RegisteredDomainChallengeRequest) (RegisteredDomainChallengeID string, err error) {
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		return
 	}
 
-	resp, err := a.restClient.Create(apiVersion, RegisteredDomainChallengeComponent, body, "")
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, RegisteredDomainChallengeComponent, body, "")
 	if err != nil {
 		return
 	}
@@ -113,11 +134,11 @@ func (a *RegisteredDomain) CreateRDChallenge(reqBody RegisteredDomainChallengeRe
 	return
 }
 
-func (a *RegisteredDomain) ValidateDomain(id string) (domainInfo RegisteredDomainInfo, err error) {
+func (a *RegisteredDomain) ValidateDomain(ctx context.Context, id string) (domainInfo RegisteredDomainInfo, err error) {
 
 	path := fmt.Sprintf("%s/%s/%s/validate", apiVersion, registeredDomainComponent, id)
 
-	_, err = a.restClient.Create(apiVersion, registeredDomainComponent, nil, path)
+	_, err = a.restClient.CreateContext(ctx, apiVersion, registeredDomainComponent, nil, path)
 	if err != nil {
 		return
 	}
@@ -125,8 +146,8 @@ func (a *RegisteredDomain) ValidateDomain(id string) (domainInfo RegisteredDomai
 	return
 }
 
-func (a *RegisteredDomain) GetRDChallenge(id string) (rdChallengeInfo RegisteredDomainChallengeInfo, err error) {
-	getResp, err := a.restClient.Read(apiVersion, RegisteredDomainChallengeComponent, id, "")
+func (a *RegisteredDomain) GetRDChallenge(ctx context.Context, id string) (rdChallengeInfo RegisteredDomainChallengeInfo, err error) {
+	getResp, err := a.restClient.ReadContext(ctx, apiVersion, RegisteredDomainChallengeComponent, id, "")
 	if err != nil {
 		return
 	}
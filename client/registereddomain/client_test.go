@@ -1,6 +1,7 @@
 package registereddomain_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/registereddomain"
@@ -23,13 +24,13 @@ func Test_RD_create_read_delete(t *testing.T) {
 	assert.NoError(t, err, "Expected to not get an error here")
 
 	//create record
-	got, err := client.RegisteredDomain.Create(reqBody)
+	got, err := client.RegisteredDomain.Create(context.Background(), reqBody)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	//get created record info
-	resp, err := client.RegisteredDomain.Get(got.ID)
+	resp, err := client.RegisteredDomain.Get(context.Background(), got.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -39,7 +40,7 @@ func Test_RD_create_read_delete(t *testing.T) {
 	assert.Equal(t, resp.Description, reqBody.Description)
 
 	//delete create record
-	err = client.RegisteredDomain.Delete(resp.ID)
+	err = client.RegisteredDomain.Delete(context.Background(), resp.ID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -39,6 +39,13 @@ type RDResponse struct {
 	Data             RegisteredDomainInfo `json:"data"`
 }
 
+type RDListResponse struct {
+	RequestId        string                 `json:"request_id"`
+	ErrorCode        int                    `json:"error_code"`
+	ErrorDescription string                 `json:"error_description"`
+	Data             []RegisteredDomainInfo `json:"data"`
+}
+
 type RegisteredDomainChallengeRequest struct {
 	RegisteredDomainName string `json:"registered_domain_name" validate:"required,validateDomainName"`
 }
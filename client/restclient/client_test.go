@@ -1,21 +1,450 @@
 package restclient
 
 import (
-	"github.com/banyansecurity/terraform-banyan-provider/client/testenv"
-	"github.com/stretchr/testify/assert"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_Authentication(t *testing.T) {
-	testhost := testenv.GetBanyanHostUrl()
-	testApiToken := testenv.GetApiKey()
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	testApiToken := "test-api-token"
 
 	t.Run("use api token as access token", func(t *testing.T) {
-		myClient, err := New(testhost, testApiToken)
+		myClient, err := New(server.URL, testApiToken)
 		if err != nil {
 			t.Fatalf("%+v", err)
 		}
 		assert.Equal(t, testApiToken, myClient.accessToken)
+		_, err = myClient.Read("api/v1", "thing", "abc", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer "+testApiToken, authorization)
 	})
 
 }
+
+func newRetryTestClient(t *testing.T, handler http.HandlerFunc, maxRetries int) (*Client, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := NewWithOptions(server.URL, "test-key", Options{
+		Retry: RetryPolicy{
+			MaxRetries: maxRetries,
+			WaitMin:    time.Millisecond,
+			WaitMax:    10 * time.Millisecond,
+		},
+	})
+	assert.NoError(t, err)
+	return c, server
+}
+
+func Test_RetryOnServerErrors(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusTooManyRequests} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var attempts int32
+			c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) < 3 {
+					w.WriteHeader(status)
+					return
+				}
+				_, _ = w.Write([]byte(`{"ok":true}`))
+			}, 4)
+			resp, err := c.Read("api/v1", "thing", "abc", "")
+			assert.NoError(t, err)
+			assert.Equal(t, `{"ok":true}`, string(resp))
+			assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func Test_RetryGivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, 2)
+	_, err := c.Read("api/v1", "thing", "abc", "")
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func Test_RetryReplaysBody(t *testing.T) {
+	var attempts int32
+	c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"test"}`, string(body))
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write(body)
+	}, 2)
+	resp, err := c.Update("api/v1", "thing", "abc", []byte(`{"name":"test"}`), "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"test"}`, string(resp))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func Test_NoRetryForPostOnServerError(t *testing.T) {
+	var attempts int32
+	c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, 3)
+	_, err := c.Create("api/v1", "thing", []byte(`{}`), "")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func Test_RetryPostOnTooManyRequests(t *testing.T) {
+	var attempts int32
+	c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}, 3)
+	_, err := c.Create("api/v1", "thing", []byte(`{}`), "")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func Test_NoRetryOnClientError(t *testing.T) {
+	var attempts int32
+	c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}, 3)
+	_, err := c.Read("api/v1", "thing", "abc", "")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func Test_RetryAfterHeader(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 1, WaitMin: time.Millisecond, WaitMax: time.Minute}
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Retry-After", "7")
+	assert.Equal(t, 7*time.Second, p.backoff(0, resp))
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.Equal(t, time.Duration(0), p.backoff(0, resp))
+
+	p.WaitMax = 2 * time.Second
+	resp.Header.Set("Retry-After", "120")
+	assert.Equal(t, 2*time.Second, p.backoff(0, resp))
+}
+
+func Test_BackoffIsBounded(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 10, WaitMin: time.Second, WaitMax: 8 * time.Second}
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := p.backoff(attempt, nil)
+		expected := time.Second << attempt
+		if expected > p.WaitMax {
+			expected = p.WaitMax
+		}
+		assert.GreaterOrEqual(t, wait, expected/2)
+		assert.LessOrEqual(t, wait, expected)
+	}
+}
+
+func Test_RetryStopsWhenContextCancelled(t *testing.T) {
+	c, _ := newRetryTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, 5)
+	c.retry.WaitMin = time.Hour
+	c.retry.WaitMax = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	request, err := c.NewRequest(http.MethodGet, "api/v1/thing", nil)
+	assert.NoError(t, err)
+	_, err = c.Do(request.WithContext(ctx))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_HandleResponseReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/thing/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"request_id":"req-1","error_code":404,"error_description":"thing not found"}`))
+		case "/api/v2/thing/taken":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"request_id":"req-2","error_code":409,"error_description":"name already in use"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`unauthorized`))
+		}
+	}))
+	defer server.Close()
+	c, err := NewWithOptions(server.URL, "test-key", Options{})
+	assert.NoError(t, err)
+
+	_, err = c.Read("api/v2", "thing", "missing", "")
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+	assert.Equal(t, 404, apiErr.ErrorCode)
+	assert.Equal(t, "thing not found", apiErr.ErrorDescription)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, server.URL+"/api/v2/thing/missing", apiErr.URL)
+	assert.True(t, IsNotFound(err))
+	assert.False(t, IsConflict(err))
+
+	_, err = c.Update("api/v2", "thing", "taken", []byte(`{}`), "")
+	assert.True(t, IsConflict(err))
+	assert.Contains(t, err.Error(), "409 conflict: PUT")
+	assert.Contains(t, err.Error(), "request_id req-2")
+
+	err = c.Delete("api/v2", "thing", "other", "")
+	assert.True(t, IsUnauthorized(err))
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, []byte("unauthorized"), apiErr.Body)
+}
+
+func Test_SentinelErrorsWrapped(t *testing.T) {
+	err := fmt.Errorf("service %w", ErrNotFound)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, "service not found", err.Error())
+	assert.False(t, IsNotFound(errors.New("something else")))
+}
+
+func Test_ContextCancelsRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	c, err := NewWithOptions(server.URL, "test-key", Options{})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.ReadContext(ctx, "api/v1", "thing", "abc", "")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = c.CreateContext(ctx, "api/v1", "thing", []byte(`{}`), "")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = c.UpdateContext(ctx, "api/v1", "thing", "abc", []byte(`{}`), "")
+	assert.ErrorIs(t, err, context.Canceled)
+	err = c.DeleteContext(ctx, "api/v1", "thing", "abc", "")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = c.ReadQueryContext(ctx, "thing", url.Values{"id": {"abc"}}, "api/v1/thing")
+	assert.ErrorIs(t, err, context.Canceled)
+	err = c.DeleteQueryContext(ctx, "thing", "abc", url.Values{"id": {"abc"}}, "api/v1/thing")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_TransportCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	c, err := NewWithOptions(server.URL, "test-key", Options{})
+	assert.NoError(t, err)
+	_, err = c.Read("api/v1", "thing", "abc", "")
+	assert.Error(t, err, "expected the self signed certificate to be rejected")
+
+	c, err = NewWithOptions(server.URL, "test-key", Options{Transport: TransportOptions{CACertPEM: string(caPEM)}})
+	assert.NoError(t, err)
+	resp, err := c.Read("api/v1", "thing", "abc", "")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, string(resp))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, caPEM, 0600))
+	c, err = NewWithOptions(server.URL, "test-key", Options{Transport: TransportOptions{CACertFile: caFile}})
+	assert.NoError(t, err)
+	_, err = c.Read("api/v1", "thing", "abc", "")
+	assert.NoError(t, err)
+
+	c, err = NewWithOptions(server.URL, "test-key", Options{Transport: TransportOptions{InsecureSkipVerify: true}})
+	assert.NoError(t, err)
+	_, err = c.Read("api/v1", "thing", "abc", "")
+	assert.NoError(t, err)
+}
+
+func Test_TransportInvalidOptions(t *testing.T) {
+	_, err := NewWithOptions("https://example.com", "test-key", Options{Transport: TransportOptions{CACertPEM: "not a certificate"}})
+	assert.ErrorContains(t, err, "no valid certificates")
+	_, err = NewWithOptions("https://example.com", "test-key", Options{Transport: TransportOptions{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}})
+	assert.ErrorContains(t, err, "could not read CA certificate file")
+	_, err = NewWithOptions("https://example.com", "test-key", Options{Transport: TransportOptions{Proxy: "not a url"}})
+	assert.ErrorContains(t, err, "invalid proxy url")
+}
+
+func Test_TransportProxy(t *testing.T) {
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		assert.Equal(t, "http://banyan.invalid/api/v1/thing/abc", r.URL.String())
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer proxy.Close()
+
+	c, err := NewWithOptions("http://banyan.invalid", "test-key", Options{Transport: TransportOptions{Proxy: proxy.URL}})
+	assert.NoError(t, err)
+	_, err = c.Read("api/v1", "thing", "abc", "")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+}
+
+func Test_TransportTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c, err := NewWithOptions(server.URL, "test-key", Options{Transport: TransportOptions{Timeout: 50 * time.Millisecond}})
+	assert.NoError(t, err)
+	start := time.Now()
+	_, err = c.Read("api/v1", "thing", "abc", "")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func Test_DebugLoggingRedactsSecrets(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", "DEBUG")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"request_id":"req-42","data":{"secret":"api-key-secret","name":"visible",` +
+			`"ServiceSpec":"{\"oidc\":{\"client_secret\":\"oidc-secret\"}}",` +
+			`"tunnel":[{"wireguard_private_key":"wg-secret","wireguard_public_key":"wg-public"}]}}`))
+	}))
+	defer server.Close()
+	c, err := NewWithOptions(server.URL, "bearer-secret", Options{})
+	assert.NoError(t, err)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	_, err = c.CreateContext(ctx, "api/v2", "thing", []byte(`{"name":"visible","token":"token-secret"}`), "")
+	assert.NoError(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	logged := output.String()
+	for _, entry := range entries {
+		assert.Equal(t, "POST", entry["method"])
+		assert.Equal(t, "/api/v2/thing", entry["path"])
+		logged += fmt.Sprintf("%v", entry)
+	}
+	response := entries[1]
+	assert.Equal(t, float64(http.StatusOK), response["status"])
+	assert.Equal(t, "req-42", response["request_id"])
+	assert.Contains(t, response, "latency_ms")
+	assert.Contains(t, logged, "visible")
+	assert.Contains(t, logged, "wg-public")
+	for _, secret := range []string{"bearer-secret", "token-secret", "api-key-secret", "oidc-secret", "wg-secret"} {
+		assert.NotContains(t, logged, secret)
+	}
+}
+
+func Test_LoggingSkipsBodiesWithoutDebug(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER", "")
+	t.Setenv("TF_LOG", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"name":"visible"}}`))
+	}))
+	defer server.Close()
+	c, err := NewWithOptions(server.URL, "bearer-secret", Options{})
+	assert.NoError(t, err)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	resp, err := c.CreateContext(ctx, "api/v2", "thing", []byte(`{"name":"visible"}`), "")
+	assert.NoError(t, err)
+	assert.Contains(t, string(resp), "visible")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "/api/v2/thing", entry["path"])
+		assert.NotContains(t, entry, "body")
+	}
+	assert.Equal(t, float64(http.StatusOK), entries[1]["status"])
+}
+
+func Test_debugLogging(t *testing.T) {
+	tests := []struct {
+		provider, core string
+		want           bool
+	}{
+		{"", "", false},
+		{"", "info", false},
+		{"", "debug", true},
+		{"", "TRACE", true},
+		{"", "JSON", true},
+		{"", "unknown", true},
+		{"WARN", "DEBUG", false},
+		{"DEBUG", "", true},
+	}
+	for _, tt := range tests {
+		t.Setenv("TF_LOG_PROVIDER", tt.provider)
+		t.Setenv("TF_LOG", tt.core)
+		assert.Equal(t, tt.want, debugLogging(), "TF_LOG_PROVIDER=%q TF_LOG=%q", tt.provider, tt.core)
+	}
+}
+
+func Test_RedactFormBody(t *testing.T) {
+	got := redactBody("application/x-www-form-urlencoded", []byte("PolicyID=abc&Token=xyz"))
+	assert.Equal(t, "PolicyID=abc&Token="+url.QueryEscape(redacted), got)
+}
+
+func Test_RedactOrgIdpConfig(t *testing.T) {
+	idpConfig := `{"RedirectURL":"https://net.banyanops.com/v2/callback","IssuerURL":"https://example.okta.com",` +
+		`"ClientID":"client-id","ClientSecret":"idp-secret"}`
+	form := url.Values{}
+	form.Add("IDPName", "Okta")
+	form.Add("IDPProtocol", "OIDC")
+	form.Add("IDPConfig", idpConfig)
+	request := redactBody("application/x-www-form-urlencoded", []byte(form.Encode()))
+	assert.NotContains(t, request, "idp-secret")
+	assert.Contains(t, request, "client-id")
+	assert.Contains(t, request, "Okta")
+
+	// user_org_details returns the IdP config HTML escaped
+	for _, embedded := range []string{idpConfig, html.EscapeString(idpConfig)} {
+		encoded, err := json.Marshal(map[string]string{"IDPName": "Okta", "IDPProto": "OIDC", "IDPConfig": embedded})
+		assert.NoError(t, err)
+		response := redactBody("application/json", encoded)
+		assert.NotContains(t, response, "idp-secret")
+		assert.Contains(t, response, "client-id")
+	}
+}
+
+func Test_isSensitiveKey(t *testing.T) {
+	for _, k := range []string{"client_secret", "ClientSecret", "CLIENT-SECRET", "WireguardPrivateKey", "Authorization", "token"} {
+		assert.True(t, isSensitiveKey(k), k)
+	}
+	for _, k := range []string{"ClientID", "wireguard_public_key", "name"} {
+		assert.False(t, isSensitiveKey(k), k)
+	}
+}
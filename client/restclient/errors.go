@@ -0,0 +1,116 @@
+package restclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors which can be matched with errors.Is against any error returned by the client packages
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+)
+
+// APIError is returned when the Banyan API responds with a non 200 status code
+type APIError struct {
+	StatusCode       int
+	RequestID        string
+	ErrorCode        int
+	ErrorDescription string
+	Method           string
+	URL              string
+	// Body is the raw response body, kept for responses which do not use the error envelope
+	Body []byte
+}
+
+// errorEnvelope is the error portion of the response envelope shared by every API response
+type errorEnvelope struct {
+	RequestID        string `json:"request_id"`
+	ErrorCode        int    `json:"error_code"`
+	ErrorDescription string `json:"error_description"`
+	// v1 endpoints return a bare message instead of the envelope
+	Message string `json:"message"`
+}
+
+func newAPIError(response *http.Response, body []byte) *APIError {
+	e := APIError{
+		StatusCode: response.StatusCode,
+		Body:       body,
+	}
+	if response.Request != nil {
+		e.Method = response.Request.Method
+		e.URL = response.Request.URL.String()
+	}
+	var envelope errorEnvelope
+	if json.Unmarshal(body, &envelope) == nil {
+		e.RequestID = envelope.RequestID
+		e.ErrorCode = envelope.ErrorCode
+		e.ErrorDescription = envelope.ErrorDescription
+		if e.ErrorDescription == "" {
+			e.ErrorDescription = envelope.Message
+		}
+	}
+	return &e
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%d %s: %s %s", e.StatusCode, statusText(e.StatusCode), e.Method, e.URL)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request_id %s)", e.RequestID)
+	}
+	switch {
+	case e.ErrorDescription != "":
+		msg += ": " + e.ErrorDescription
+	case len(e.Body) > 0 && e.StatusCode != http.StatusNotFound:
+		msg += fmt.Sprintf(" \n response: \n %s", e.Body)
+	}
+	return msg
+}
+
+// Is allows an APIError to be matched against the sentinel errors by status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+func statusText(code int) string {
+	text := http.StatusText(code)
+	if text == "" {
+		return "error"
+	}
+	return strings.ToLower(text)
+}
+
+// IsNotFound reports whether err indicates the requested object does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err indicates the object conflicts with an existing one
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsUnauthorized reports whether err indicates the API key was missing, invalid or expired
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsForbidden reports whether err indicates the API key lacks the scope for the request
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
@@ -0,0 +1,231 @@
+package restclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces the value of sensitive fields in logged bodies and headers
+const redacted = "***REDACTED***"
+
+// maxLoggedBody is the number of bytes of a body written to the debug log
+const maxLoggedBody = 16 * 1024
+
+// sensitiveKeys are the body fields and headers whose values are never logged, in the form of normalizeKey so that
+// e.g. client_secret, ClientSecret and Client-Secret all match
+var sensitiveKeys = map[string]bool{
+	"secret":              true,
+	"clientsecret":        true,
+	"wireguardprivatekey": true,
+	"token":               true,
+	"authorization":       true,
+}
+
+// normalizeKey lowercases a field or header name and drops the separators between its words
+func normalizeKey(k string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(k))
+}
+
+func isSensitiveKey(k string) bool {
+	return sensitiveKeys[normalizeKey(k)]
+}
+
+// debugLogging reports whether Terraform writes provider logs at debug level or above, using TF_LOG_PROVIDER and
+// falling back to TF_LOG. Terraform treats an unknown level as TRACE, and JSON as TRACE in JSON format.
+func debugLogging() bool {
+	level := os.Getenv("TF_LOG_PROVIDER")
+	if level == "" {
+		level = os.Getenv("TF_LOG")
+	}
+	switch strings.ToUpper(level) {
+	case "", "OFF", "INFO", "WARN", "ERROR":
+		return false
+	}
+	return true
+}
+
+// loggingTransport writes each request and response to the tflog debug log with sensitive fields masked. Bodies are
+// only read and redacted when debug logging is on, otherwise just the method, path and status are logged.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(request *http.Request) (response *http.Response, err error) {
+	ctx := request.Context()
+	logBodies := debugLogging()
+	fields := map[string]interface{}{
+		"method": request.Method,
+		"path":   request.URL.Path,
+	}
+	if request.URL.RawQuery != "" {
+		fields["query"] = request.URL.RawQuery
+	}
+
+	requestFields := copyFields(fields)
+	requestFields["headers"] = redactHeaders(request.Header)
+	if logBodies && request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			requestFields["body"] = redactBody(request.Header.Get("Content-Type"), data)
+		}
+	}
+	tflog.Debug(ctx, "Sending Banyan API request", requestFields)
+
+	start := time.Now()
+	response, err = t.next.RoundTrip(request)
+	if err != nil {
+		fields["latency_ms"] = time.Since(start).Milliseconds()
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Banyan API request failed", fields)
+		return
+	}
+	if !logBodies {
+		fields["latency_ms"] = time.Since(start).Milliseconds()
+		fields["status"] = response.StatusCode
+		tflog.Debug(ctx, "Received Banyan API response", fields)
+		return
+	}
+	data, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	fields["status"] = response.StatusCode
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Banyan API request failed", fields)
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(data))
+	if requestID := responseRequestID(response, data); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	fields["body"] = redactBody(response.Header.Get("Content-Type"), data)
+	tflog.Debug(ctx, "Received Banyan API response", fields)
+	return
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		c[k] = v
+	}
+	return c
+}
+
+// responseRequestID returns the request id from the response headers, falling back to the response envelope
+func responseRequestID(response *http.Response, body []byte) string {
+	if id := response.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	var envelope errorEnvelope
+	if json.Unmarshal(body, &envelope) == nil {
+		return envelope.RequestID
+	}
+	return ""
+}
+
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for k := range header {
+		if isSensitiveKey(k) {
+			headers[k] = redacted
+			continue
+		}
+		headers[k] = header.Get(k)
+	}
+	return headers
+}
+
+// redactBody returns the body with the value of every sensitive field masked. JSON and form bodies are
+// redacted field by field, including JSON documents embedded in string values such as ServiceSpec or IDPConfig.
+func redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var redactedBody string
+	if v, ok := decodeJSON(body); ok {
+		encoded, _ := json.Marshal(redactValue(v))
+		redactedBody = string(encoded)
+	} else if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		redactedBody = redactForm(string(body))
+	} else {
+		redactedBody = string(body)
+	}
+	if len(redactedBody) > maxLoggedBody {
+		redactedBody = redactedBody[:maxLoggedBody] + "...(truncated)"
+	}
+	return redactedBody
+}
+
+func decodeJSON(data []byte) (v interface{}, ok bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, false
+	}
+	// reject input with trailing data, which is not a single JSON document
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, false
+	}
+	return v, true
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, field := range value {
+			if isSensitiveKey(k) && field != nil && field != "" {
+				value[k] = redacted
+				continue
+			}
+			value[k] = redactValue(field)
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactValue(item)
+		}
+		return value
+	case string:
+		trimmed := strings.TrimSpace(value)
+		if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+			return value
+		}
+		embedded, ok := decodeJSON([]byte(trimmed))
+		if !ok {
+			// some documents are HTML escaped, e.g. IDPConfig of user_org_details
+			embedded, ok = decodeJSON([]byte(html.UnescapeString(trimmed)))
+		}
+		if !ok {
+			return value
+		}
+		encoded, _ := json.Marshal(redactValue(embedded))
+		return string(encoded)
+	}
+	return v
+}
+
+func redactForm(body string) string {
+	values, err := url.ParseQuery(body)
+	if err != nil {
+		return body
+	}
+	for k, items := range values {
+		for i, item := range items {
+			if isSensitiveKey(k) {
+				items[i] = redacted
+				continue
+			}
+			items[i] = redactValue(item).(string)
+		}
+	}
+	return values.Encode()
+}
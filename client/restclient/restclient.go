@@ -2,13 +2,12 @@ package restclient
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 )
 
 // Client is the struct used interact with the Banyan REST API.
@@ -16,12 +15,32 @@ type Client struct {
 	accessToken string
 	hostUrl     string
 	httpClient  *http.Client
+	retry       RetryPolicy
 }
 
 const defaultHostUrl = "https://net.banyanops.com"
 
+// Options configures the behavior of the http client created by NewWithOptions
+type Options struct {
+	Retry     RetryPolicy
+	Transport TransportOptions
+}
+
+// DefaultOptions returns the options used by New
+func DefaultOptions() Options {
+	return Options{
+		Retry:     DefaultRetryPolicy(),
+		Transport: TransportOptions{Timeout: DefaultTimeout},
+	}
+}
+
 // New creates a new client that will let the user interact with the REST API server.
 func New(hostUrl string, apiKey string) (client *Client, err error) {
+	return NewWithOptions(hostUrl, apiKey, DefaultOptions())
+}
+
+// NewWithOptions creates a new client using the given options
+func NewWithOptions(hostUrl string, apiKey string, opts Options) (client *Client, err error) {
 	clientHostUrl := defaultHostUrl
 	if hostUrl != "" {
 		clientHostUrl = hostUrl
@@ -31,10 +50,16 @@ func New(hostUrl string, apiKey string) (client *Client, err error) {
 		clientHostUrl = hostUrl + "/"
 	}
 
+	httpClient, err := newHTTPClient(opts.Transport)
+	if err != nil {
+		return
+	}
+
 	client = &Client{
 		accessToken: apiKey,
 		hostUrl:     clientHostUrl,
-		httpClient:  &http.Client{Timeout: 10 * time.Second},
+		httpClient:  httpClient,
+		retry:       opts.Retry,
 	}
 
 	return
@@ -42,7 +67,12 @@ func New(hostUrl string, apiKey string) (client *Client, err error) {
 
 // DoPut posts a message to host url, with the method path and body listed
 func (c *Client) DoPut(path string, body io.Reader) (response *http.Response, err error) {
-	req, err := c.NewRequest(http.MethodPut, path, body)
+	return c.DoPutContext(context.Background(), path, body)
+}
+
+// DoPutContext is DoPut bound to the given context
+func (c *Client) DoPutContext(ctx context.Context, path string, body io.Reader) (response *http.Response, err error) {
+	req, err := c.NewRequestWithContext(ctx, http.MethodPut, path, body)
 	if err != nil {
 		return
 	}
@@ -52,7 +82,12 @@ func (c *Client) DoPut(path string, body io.Reader) (response *http.Response, er
 
 // DoPost posts a message to host url, with the method path and body listed
 func (c *Client) DoPost(path string, body io.Reader) (response *http.Response, err error) {
-	req, err := c.NewRequest(http.MethodPost, path, body)
+	return c.DoPostContext(context.Background(), path, body)
+}
+
+// DoPostContext is DoPost bound to the given context
+func (c *Client) DoPostContext(ctx context.Context, path string, body io.Reader) (response *http.Response, err error) {
+	req, err := c.NewRequestWithContext(ctx, http.MethodPost, path, body)
 	if err != nil {
 		return
 	}
@@ -62,7 +97,12 @@ func (c *Client) DoPost(path string, body io.Reader) (response *http.Response, e
 
 // DoGet sends and does the get request
 func (c *Client) DoGet(path string) (response *http.Response, err error) {
-	request, err := c.Get(path)
+	return c.DoGetContext(context.Background(), path)
+}
+
+// DoGetContext is DoGet bound to the given context
+func (c *Client) DoGetContext(ctx context.Context, path string) (response *http.Response, err error) {
+	request, err := c.NewRequestWithContext(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return
 	}
@@ -72,7 +112,12 @@ func (c *Client) DoGet(path string) (response *http.Response, err error) {
 
 // DoDelete sends the delete request
 func (c *Client) DoDelete(path string) (response *http.Response, err error) {
-	request, err := c.delete(path)
+	return c.DoDeleteContext(context.Background(), path)
+}
+
+// DoDeleteContext is DoDelete bound to the given context
+func (c *Client) DoDeleteContext(ctx context.Context, path string) (response *http.Response, err error) {
+	request, err := c.NewRequestWithContext(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return
 	}
@@ -80,27 +125,28 @@ func (c *Client) DoDelete(path string) (response *http.Response, err error) {
 	return
 }
 
-func (c *Client) delete(path string) (request *http.Request, err error) {
-	return c.NewRequest(http.MethodDelete, path, nil)
-}
-
 // Get creates a new Get request, saving the user from needing to pass in a nil value
 func (c *Client) Get(path string) (request *http.Request, err error) {
 	return c.NewRequest("GET", path, nil)
 }
 
-// Do execute the request and returns the response
+// Do execute the request and returns the response, retrying according to the client's RetryPolicy
 func (c *Client) Do(request *http.Request) (response *http.Response, err error) {
-	return c.httpClient.Do(request)
+	return c.doWithRetry(request)
 }
 
 // NewRequest creates a new request with the accessToken added as a header
 func (c *Client) NewRequest(method string, path string, body io.Reader) (request *http.Request, err error) {
-	return c.newRequest(method, c.hostUrl+path, body)
+	return c.NewRequestWithContext(context.Background(), method, path, body)
+}
+
+// NewRequestWithContext creates a new request bound to ctx with the accessToken added as a header
+func (c *Client) NewRequestWithContext(ctx context.Context, method string, path string, body io.Reader) (request *http.Request, err error) {
+	return c.newRequest(ctx, method, c.hostUrl+path, body)
 }
 
-func (c *Client) newRequest(method string, url string, body io.Reader) (request *http.Request, err error) {
-	request, err = http.NewRequest(method, url, body)
+func (c *Client) newRequest(ctx context.Context, method string, url string, body io.Reader) (request *http.Request, err error) {
+	request, err = http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return
 	}
@@ -108,13 +154,12 @@ func (c *Client) newRequest(method string, url string, body io.Reader) (request
 	return
 }
 
-// This will be depreciated out fo the provider which will only use API keys
-
-type ErrorResponse struct {
-	Message string `json:"error_description"`
+func (c *Client) Read(api string, component string, id string, path string) (resp []byte, err error) {
+	return c.ReadContext(context.Background(), api, component, id, path)
 }
 
-func (c *Client) Read(api string, component string, id string, path string) (resp []byte, err error) {
+// ReadContext is Read bound to the given context
+func (c *Client) ReadContext(ctx context.Context, api string, component string, id string, path string) (resp []byte, err error) {
 	if id == "" {
 		err = fmt.Errorf("need an id to get %s", component)
 		return
@@ -126,35 +171,45 @@ func (c *Client) Read(api string, component string, id string, path string) (res
 	if err != nil {
 		return
 	}
-	response, err := c.DoGet(myUrl.String())
+	response, err := c.DoGetContext(ctx, myUrl.String())
 	if err != nil {
-		err = fmt.Errorf("request to %s %s failed %w", response.Request.Method, response.Request.URL.String(), err)
+		err = fmt.Errorf("request to %s %s failed %w", http.MethodGet, myUrl.String(), err)
 		return
 	}
 	return HandleResponse(response)
 }
 
-func (c *Client) ReadQuery(_ string, query url.Values, path string) (r []byte, err error) {
+func (c *Client) ReadQuery(component string, query url.Values, path string) (r []byte, err error) {
+	return c.ReadQueryContext(context.Background(), component, query, path)
+}
+
+// ReadQueryContext is ReadQuery bound to the given context
+func (c *Client) ReadQueryContext(ctx context.Context, _ string, query url.Values, path string) (r []byte, err error) {
 	myUrl, err := url.Parse(path)
 	if err != nil {
 		return
 	}
 	myUrl.RawQuery = query.Encode()
-	response, err := c.DoGet(myUrl.String())
+	response, err := c.DoGetContext(ctx, myUrl.String())
 	if err != nil {
-		err = fmt.Errorf("request to %s %s failed %w", response.Request.Method, response.Request.URL.String(), err)
+		err = fmt.Errorf("request to %s %s failed %w", http.MethodGet, myUrl.String(), err)
 		return
 	}
 	return HandleResponse(response)
 }
 
 func (c *Client) Create(api string, component string, body []byte, path string) (r []byte, err error) {
+	return c.CreateContext(context.Background(), api, component, body, path)
+}
+
+// CreateContext is Create bound to the given context
+func (c *Client) CreateContext(ctx context.Context, api string, component string, body []byte, path string) (r []byte, err error) {
 	if path == "" {
 		path = fmt.Sprintf("%s/%s", api, component)
 	}
-	request, err := c.NewRequest(http.MethodPost, path, bytes.NewBuffer(body))
+	request, err := c.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewBuffer(body))
 	if err != nil {
-		err = fmt.Errorf("request formation failed for %s %s %w", request.Method, request.URL.String(), err)
+		err = fmt.Errorf("request formation failed for %s %s %w", http.MethodPost, path, err)
 		return
 	}
 	response, err := c.Do(request)
@@ -166,12 +221,17 @@ func (c *Client) Create(api string, component string, body []byte, path string)
 }
 
 func (c *Client) Update(api string, component string, id string, body []byte, path string) (r []byte, err error) {
+	return c.UpdateContext(context.Background(), api, component, id, body, path)
+}
+
+// UpdateContext is Update bound to the given context
+func (c *Client) UpdateContext(ctx context.Context, api string, component string, id string, body []byte, path string) (r []byte, err error) {
 	if path == "" {
 		path = fmt.Sprintf("%s/%s/%s", api, component, id)
 	}
-	request, err := c.NewRequest(http.MethodPut, path, bytes.NewBuffer(body))
+	request, err := c.NewRequestWithContext(ctx, http.MethodPut, path, bytes.NewBuffer(body))
 	if err != nil {
-		err = fmt.Errorf("request formation failed for %s %s %w", request.Method, request.URL.String(), err)
+		err = fmt.Errorf("request formation failed for %s %s %w", http.MethodPut, path, err)
 		return
 	}
 	response, err := c.Do(request)
@@ -184,6 +244,11 @@ func (c *Client) Update(api string, component string, id string, body []byte, pa
 }
 
 func (c *Client) Delete(api string, component string, id string, path string) (err error) {
+	return c.DeleteContext(context.Background(), api, component, id, path)
+}
+
+// DeleteContext is Delete bound to the given context
+func (c *Client) DeleteContext(ctx context.Context, api string, component string, id string, path string) (err error) {
 	if id == "" {
 		err = fmt.Errorf("need an id to delete %s", component)
 		return
@@ -195,9 +260,9 @@ func (c *Client) Delete(api string, component string, id string, path string) (e
 	if err != nil {
 		return
 	}
-	response, err := c.DoDelete(myUrl.String())
+	response, err := c.DoDeleteContext(ctx, myUrl.String())
 	if err != nil {
-		err = fmt.Errorf("request to %s %s failed %w", response.Request.Method, response.Request.URL.String(), err)
+		err = fmt.Errorf("request to %s %s failed %w", http.MethodDelete, myUrl.String(), err)
 		return
 	}
 
@@ -206,6 +271,11 @@ func (c *Client) Delete(api string, component string, id string, path string) (e
 }
 
 func (c *Client) DeleteQuery(component string, id string, query url.Values, path string) (err error) {
+	return c.DeleteQueryContext(context.Background(), component, id, query, path)
+}
+
+// DeleteQueryContext is DeleteQuery bound to the given context
+func (c *Client) DeleteQueryContext(ctx context.Context, component string, id string, query url.Values, path string) (err error) {
 	if id == "" {
 		err = fmt.Errorf("need an id to delete %s", component)
 		return
@@ -215,36 +285,24 @@ func (c *Client) DeleteQuery(component string, id string, query url.Values, path
 		return
 	}
 	myUrl.RawQuery = query.Encode()
-	response, err := c.DoDelete(myUrl.String())
+	response, err := c.DoDeleteContext(ctx, myUrl.String())
 	if err != nil {
-		err = fmt.Errorf("request to %s %s failed %w", response.Request.Method, response.Request.URL.String(), err)
+		err = fmt.Errorf("request to %s %s failed %w", http.MethodDelete, myUrl.String(), err)
 		return
 	}
 	_, err = HandleResponse(response)
 	return
 }
 
+// HandleResponse reads the response body and returns an *APIError for any non 200 response
 func HandleResponse(response *http.Response) (responseData []byte, err error) {
 	defer response.Body.Close()
-	requestStr := fmt.Sprintf("%s %s", response.Request.Method, response.Request.URL.String())
 	responseData, err = io.ReadAll(response.Body)
 	if err != nil {
 		return
 	}
-	if response.StatusCode == 400 {
-		err = fmt.Errorf("%d bad request: %s \n Response: \n %s", response.StatusCode, requestStr, responseData)
-		return
-	}
-	if response.StatusCode == 404 {
-		err = fmt.Errorf("404 not found: %s", requestStr)
-		return
-	}
-	if response.StatusCode != 200 {
-		var errResp ErrorResponse
-		err = json.Unmarshal(responseData, &errResp)
-		if err == nil {
-			err = fmt.Errorf("received error code %d: %s \n response: \n %s", response.StatusCode, requestStr, responseData)
-		}
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response, responseData)
 		return
 	}
 	return
@@ -0,0 +1,152 @@
+package restclient
+
+import (
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy controls how failed requests are retried.
+// Requests are retried when the Command Center responds with 429, or for idempotent methods
+// when the response is a 502, 503 or 504 or the connection fails.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the initial attempt, 0 disables retries
+	MaxRetries int
+	// WaitMin is the base wait before the first retry, doubled for each subsequent retry
+	WaitMin time.Duration
+	// WaitMax caps the wait between two attempts, including waits requested through Retry-After
+	WaitMax time.Duration
+}
+
+const (
+	defaultMaxRetries   = 4
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: defaultMaxRetries,
+		WaitMin:    defaultRetryWaitMin,
+		WaitMax:    defaultRetryWaitMax,
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry reports whether a request with the given method should be attempted again
+// after receiving the given response or error
+func shouldRetry(method string, response *http.Response, err error) bool {
+	if err != nil {
+		return isIdempotent(method)
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+// backoff returns the wait before the given retry attempt (starting at 0) using exponential
+// backoff with jitter. A Retry-After header on the response takes precedence.
+func (p RetryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	if wait, ok := retryAfter(response); ok {
+		if wait > p.WaitMax {
+			return p.WaitMax
+		}
+		return wait
+	}
+	base := float64(p.WaitMin) * math.Pow(2, float64(attempt))
+	if base > float64(p.WaitMax) || math.IsInf(base, 0) {
+		base = float64(p.WaitMax)
+	}
+	// wait somewhere between half and all of the exponential backoff
+	half := base / 2
+	return time.Duration(half + rand.Float64()*half)
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an http date
+func retryAfter(response *http.Response) (wait time.Duration, ok bool) {
+	if response == nil {
+		return
+	}
+	header := response.Header.Get("Retry-After")
+	if header == "" {
+		return
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait = time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return
+}
+
+func (c *Client) doWithRetry(request *http.Request) (response *http.Response, err error) {
+	attemptRequest := request
+	for attempt := 0; ; attempt++ {
+		response, err = c.httpClient.Do(attemptRequest)
+		if attempt >= c.retry.MaxRetries || !shouldRetry(request.Method, response, err) {
+			return
+		}
+		// the body has already been consumed, only retry when it can be replayed
+		if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+			return
+		}
+		wait := c.retry.backoff(attempt, response)
+		if response != nil {
+			drainBody(response)
+		}
+		tflog.Debug(request.Context(), "Retrying Banyan API request", map[string]interface{}{
+			"method":  request.Method,
+			"path":    request.URL.Path,
+			"attempt": attempt + 1,
+			"wait_ms": wait.Milliseconds(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+
+		attemptRequest = request.Clone(request.Context())
+		if request.GetBody != nil {
+			attemptRequest.Body, err = request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// drainBody reads and closes the body of a response which is about to be discarded so the
+// connection can be reused
+func drainBody(response *http.Response) {
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+}
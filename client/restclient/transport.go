@@ -0,0 +1,100 @@
+package restclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// DefaultTimeout is the time limit for a single request, including reading the response body
+const DefaultTimeout = 10 * time.Second
+
+// TransportOptions configures the http.Transport used to reach the Banyan API
+type TransportOptions struct {
+	// Timeout is the time limit for each attempt of a request; DefaultTimeout is used when zero
+	Timeout time.Duration
+	// Proxy is the URL of the proxy to send requests through. When empty the HTTPS_PROXY, HTTP_PROXY
+	// and NO_PROXY environment variables are honored
+	Proxy string
+	// CACertFile is the path to a PEM encoded bundle of additional certificate authorities to trust
+	CACertFile string
+	// CACertPEM is a PEM encoded bundle of additional certificate authorities to trust
+	CACertPEM string
+	// InsecureSkipVerify disables verification of the server certificate chain and host name
+	InsecureSkipVerify bool
+}
+
+// newHTTPClient builds the http.Client described by opts
+func newHTTPClient(opts TransportOptions) (httpClient *http.Client, err error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.Proxy = http.ProxyFromEnvironment
+	if opts.Proxy != "" {
+		proxyUrl, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %w", opts.Proxy, err)
+		}
+		if proxyUrl.Scheme == "" || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %q: expected scheme://host[:port]", opts.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	rootCAs, err := caCertPool(opts)
+	if err != nil {
+		return
+	}
+	if rootCAs != nil || opts.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			RootCAs:            rootCAs,
+			InsecureSkipVerify: opts.InsecureSkipVerify,
+		}
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	httpClient = &http.Client{
+		Timeout:   timeout,
+		Transport: &loggingTransport{next: transport},
+	}
+	return
+}
+
+// caCertPool returns the system pool extended with the configured certificate authorities, or nil if there are none
+func caCertPool(opts TransportOptions) (pool *x509.CertPool, err error) {
+	if opts.CACertFile != "" && opts.CACertPEM != "" {
+		err = errors.New("only one of CACertFile and CACertPEM may be set")
+		return
+	}
+	pemData := []byte(opts.CACertPEM)
+	source := "CA certificate PEM"
+	if opts.CACertFile != "" {
+		pemData, err = os.ReadFile(opts.CACertFile)
+		if err != nil {
+			err = fmt.Errorf("could not read CA certificate file: %w", err)
+			return
+		}
+		source = opts.CACertFile
+	}
+	if len(pemData) == 0 {
+		return
+	}
+	pool, err = x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	err = nil
+	if !pool.AppendCertsFromPEM(pemData) {
+		err = fmt.Errorf("no valid certificates found in %s", source)
+		pool = nil
+	}
+	return
+}
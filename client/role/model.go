@@ -1,5 +1,8 @@
 package role
 
+// Any is the built-in role which every user and device has. It can be used in policies without being created.
+const Any = "ANY"
+
 // Info represents the specification of a role populated by json.Unmarshal.
 type Info struct {
 	Kind       string `json:"kind"`
@@ -1,6 +1,7 @@
 package role
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,16 +27,17 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get(id string) (role GetRole, err error)
-	GetName(name string) (role GetRole, err error)
-	Create(role CreateRole) (created GetRole, err error)
-	Update(role CreateRole) (updated GetRole, err error)
-	Delete(id string) (err error)
-	disable(id string) (err error)
+	Get(ctx context.Context, id string) (role GetRole, err error)
+	GetName(ctx context.Context, name string) (role GetRole, err error)
+	GetAll(ctx context.Context) (specs []GetRole, err error)
+	Create(ctx context.Context, role CreateRole) (created GetRole, err error)
+	Update(ctx context.Context, role CreateRole) (updated GetRole, err error)
+	Delete(ctx context.Context, id string) (err error)
+	disable(ctx context.Context, id string) (err error)
 }
 
 // disable is used to disable a role. This is required before deleting a role.
-func (r *Role) disable(id string) (err error) {
+func (r *Role) disable(ctx context.Context, id string) (err error) {
 	if id == "" {
 		err = errors.New("need an id disable a role")
 		return
@@ -48,7 +50,7 @@ func (r *Role) disable(id string) (err error) {
 	query := myUrl.Query()
 	query.Set("RoleID", id)
 	myUrl.RawQuery = query.Encode()
-	response, err := r.restClient.DoPost(myUrl.String(), nil)
+	response, err := r.restClient.DoPostContext(ctx, myUrl.String(), nil)
 	if err != nil {
 		return
 	}
@@ -60,7 +62,7 @@ func (r *Role) disable(id string) (err error) {
 	return
 }
 
-func (r *Role) Get(id string) (role GetRole, err error) {
+func (r *Role) Get(ctx context.Context, id string) (role GetRole, err error) {
 	if id == "" {
 		err = errors.New("need an id to get a role")
 		return
@@ -72,7 +74,7 @@ func (r *Role) Get(id string) (role GetRole, err error) {
 	}
 	query := myUrl.Query()
 	query.Set("RoleID", id)
-	resp, err := r.restClient.ReadQuery(component, query, path)
+	resp, err := r.restClient.ReadQueryContext(ctx, component, query, path)
 	if err != nil {
 		return
 	}
@@ -82,7 +84,7 @@ func (r *Role) Get(id string) (role GetRole, err error) {
 		return
 	}
 	if len(j) == 0 {
-		err = errors.New("role not found")
+		err = fmt.Errorf("role %w", restclient.ErrNotFound)
 		return
 	}
 	if len(j) > 1 {
@@ -99,7 +101,7 @@ func (r *Role) Get(id string) (role GetRole, err error) {
 
 }
 
-func (r *Role) Create(role CreateRole) (created GetRole, err error) {
+func (r *Role) Create(ctx context.Context, role CreateRole) (created GetRole, err error) {
 	path := "api/v1/insert_security_role"
 	body, err := json.Marshal(role)
 	if err != nil {
@@ -108,7 +110,7 @@ func (r *Role) Create(role CreateRole) (created GetRole, err error) {
 	// The API will always clobber, which leads to odd behavior
 	// This aligns behavior with user expectations
 	// Don't clobber if the role name is in use
-	existing, err := r.GetName(role.Metadata.Name)
+	existing, err := r.GetName(ctx, role.Metadata.Name)
 	if err != nil {
 		return
 	}
@@ -116,7 +118,7 @@ func (r *Role) Create(role CreateRole) (created GetRole, err error) {
 		err = fmt.Errorf("the role %s already in use", role.Metadata.Name)
 		return
 	}
-	resp, err := r.restClient.Create(apiVersion, component, body, path)
+	resp, err := r.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -129,13 +131,13 @@ func (r *Role) Create(role CreateRole) (created GetRole, err error) {
 	return
 }
 
-func (r *Role) Update(role CreateRole) (updatedRole GetRole, err error) {
+func (r *Role) Update(ctx context.Context, role CreateRole) (updatedRole GetRole, err error) {
 	body, err := json.Marshal(role)
 	if err != nil {
 		return
 	}
 	path := "api/v1/insert_security_role"
-	resp, err := r.restClient.Create(apiVersion, component, body, path)
+	resp, err := r.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -149,8 +151,8 @@ func (r *Role) Update(role CreateRole) (updatedRole GetRole, err error) {
 }
 
 // Delete will disable the role and then delete it
-func (r *Role) Delete(id string) (err error) {
-	err = r.disable(id)
+func (r *Role) Delete(ctx context.Context, id string) (err error) {
+	err = r.disable(ctx, id)
 	if err != nil {
 		return
 	}
@@ -162,12 +164,12 @@ func (r *Role) Delete(id string) (err error) {
 	query := myUrl.Query()
 	query.Set("RoleID", id)
 	myUrl.RawQuery = query.Encode()
-	err = r.restClient.DeleteQuery(component, id, query, path)
+	err = r.restClient.DeleteQueryContext(ctx, component, id, query, path)
 	return
 }
 
-func (r *Role) GetName(name string) (spec GetRole, err error) {
-	specs, err := r.GetAll()
+func (r *Role) GetName(ctx context.Context, name string) (spec GetRole, err error) {
+	specs, err := r.GetAll(ctx)
 	if err != nil {
 		return
 	}
@@ -175,14 +177,14 @@ func (r *Role) GetName(name string) (spec GetRole, err error) {
 	return
 }
 
-func (r *Role) GetAll() (specs []GetRole, err error) {
+func (r *Role) GetAll(ctx context.Context) (specs []GetRole, err error) {
 	path := "api/v1/security_roles"
 	myUrl, err := url.Parse(path)
 	if err != nil {
 		return
 	}
 	query := myUrl.Query()
-	resp, err := r.restClient.ReadQuery(component, query, path)
+	resp, err := r.restClient.ReadQueryContext(ctx, component, query, path)
 	if err != nil {
 		return
 	}
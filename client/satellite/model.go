@@ -16,6 +16,13 @@ type SatelliteTunnelResponse struct {
 	Data SatelliteTunnelConfig `json:"data"`
 }
 
+type SatelliteTunnelListResponse struct {
+	Data struct {
+		Satellites []SatelliteTunnelConfig `json:"satellites"`
+		Count      int                     `json:"count"`
+	} `json:"data"`
+}
+
 const (
 	DefaultName      = "default-connector"
 	ConnectorNameKey = "connector"
@@ -1,7 +1,11 @@
 package satellite
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net/url"
+
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 )
 
@@ -20,14 +24,15 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get(id string) (satellite SatelliteTunnelConfig, err error)
-	Create(satellite Info) (created SatelliteTunnelConfig, err error)
-	Update(id string, satellite Info) (updated SatelliteTunnelConfig, err error)
-	Delete(id string) (err error)
+	Get(ctx context.Context, id string) (satellite SatelliteTunnelConfig, err error)
+	GetAll(ctx context.Context) (satellites []SatelliteTunnelConfig, err error)
+	Create(ctx context.Context, satellite Info) (created SatelliteTunnelConfig, err error)
+	Update(ctx context.Context, id string, satellite Info) (updated SatelliteTunnelConfig, err error)
+	Delete(ctx context.Context, id string) (err error)
 }
 
-func (s *Satellite) Get(id string) (satellite SatelliteTunnelConfig, err error) {
-	resp, err := s.restClient.Read(apiVersion, component, id, "")
+func (s *Satellite) Get(ctx context.Context, id string) (satellite SatelliteTunnelConfig, err error) {
+	resp, err := s.restClient.ReadContext(ctx, apiVersion, component, id, "")
 	if err != nil {
 		return
 	}
@@ -37,12 +42,23 @@ func (s *Satellite) Get(id string) (satellite SatelliteTunnelConfig, err error)
 	return
 }
 
-func (s *Satellite) Create(satellite Info) (created SatelliteTunnelConfig, err error) {
+func (s *Satellite) GetAll(ctx context.Context) (satellites []SatelliteTunnelConfig, err error) {
+	resp, err := s.restClient.ReadQueryContext(ctx, component, url.Values{}, fmt.Sprintf("%s/%s", apiVersion, component))
+	if err != nil {
+		return
+	}
+	var j SatelliteTunnelListResponse
+	err = json.Unmarshal(resp, &j)
+	satellites = j.Data.Satellites
+	return
+}
+
+func (s *Satellite) Create(ctx context.Context, satellite Info) (created SatelliteTunnelConfig, err error) {
 	body, err := json.Marshal(satellite)
 	if err != nil {
 		return
 	}
-	resp, err := s.restClient.Create(apiVersion, component, body, "")
+	resp, err := s.restClient.CreateContext(ctx, apiVersion, component, body, "")
 	if err != nil {
 		return
 	}
@@ -52,12 +68,12 @@ func (s *Satellite) Create(satellite Info) (created SatelliteTunnelConfig, err e
 	return
 }
 
-func (s *Satellite) Update(id string, satellite Info) (updated SatelliteTunnelConfig, err error) {
+func (s *Satellite) Update(ctx context.Context, id string, satellite Info) (updated SatelliteTunnelConfig, err error) {
 	body, err := json.Marshal(satellite)
 	if err != nil {
 		return
 	}
-	resp, err := s.restClient.Update(apiVersion, component, id, body, "")
+	resp, err := s.restClient.UpdateContext(ctx, apiVersion, component, id, body, "")
 	if err != nil {
 		return
 	}
@@ -67,7 +83,7 @@ func (s *Satellite) Update(id string, satellite Info) (updated SatelliteTunnelCo
 	return
 }
 
-func (s *Satellite) Delete(id string) (err error) {
-	err = s.restClient.Delete(apiVersion, component, id, "")
+func (s *Satellite) Delete(ctx context.Context, id string) (err error) {
+	err = s.restClient.DeleteContext(ctx, apiVersion, component, id, "")
 	return
 }
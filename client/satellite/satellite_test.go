@@ -1,6 +1,7 @@
 package satellite_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/satellite"
@@ -36,22 +37,46 @@ func Test_CreatSatellite(t *testing.T) {
 			},
 		},
 	}
-	got, err := client.Satellite.Create(testSatellite)
+	got, err := client.Satellite.Create(context.Background(), testSatellite)
 	if err != nil {
-		return
+		t.Fatal(err)
 	}
-	want := satellite.SatelliteTunnelConfig{}
-	AssertEqual(t, got, want)
+	want := satellite.SatelliteTunnelConfig{
+		Name:        testSatellite.Metadata.Name,
+		DisplayName: testSatellite.Metadata.DisplayName,
+		Keepalive:   testSatellite.Spec.Keepalive,
+		CIDRs:       testSatellite.Spec.CIDRs,
+		APIKeyID:    testSatellite.Spec.APIKeyID,
+	}
+	AssertEqual(t, satellite.SatelliteTunnelConfig{
+		Name:        got.Name,
+		DisplayName: got.DisplayName,
+		Keepalive:   got.Keepalive,
+		CIDRs:       got.CIDRs,
+		APIKeyID:    got.APIKeyID,
+	}, want)
 }
 
 func Test_GetExistingSatellite(t *testing.T) {
 	client, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	got, err := client.Satellite.Get("53bb4cdb-8118-4830-a10f-0c69e17d78e3")
+	got, err := client.Satellite.Get(context.Background(), "53bb4cdb-8118-4830-a10f-0c69e17d78e3")
 	assert.NoError(t, err, "expected no error here")
 	assert.NotEqual(t, got.ID, "")
 }
 
+func Test_GetAllSatellites(t *testing.T) {
+	client, err := testutil.GetClientHolderForTest()
+	assert.NoError(t, err, "Expected to not get an error here")
+	got, err := client.Satellite.GetAll(context.Background())
+	assert.NoError(t, err, "expected no error here")
+	var names []string
+	for _, s := range got {
+		names = append(names, s.Name)
+	}
+	assert.Contains(t, names, "go-client-test-existing")
+}
+
 func AssertEqual(t *testing.T, got satellite.SatelliteTunnelConfig, want satellite.SatelliteTunnelConfig) {
 	if diff := cmp.Diff(want, got); diff != "" {
 		t.Errorf("service.Spec{} mismatch (-want +got):\n%s", diff)
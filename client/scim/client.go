@@ -1,8 +1,10 @@
 package scim
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
@@ -25,14 +27,14 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get() (scimCreds SCIMCredentialsResponse, err error)
-	Create() (scimCreds createResp, err error)
-	Update(post SCIMProvisionRequest, tInfo []TokenInfo) (err error)
-	Delete(tInfo []TokenInfo) (err error)
-	ProvisionSCIM(post SCIMProvisionRequest) (err error)
+	Get(ctx context.Context) (scimCreds SCIMCredentialsResponse, err error)
+	Create(ctx context.Context) (scimCreds createResp, err error)
+	Update(ctx context.Context, post SCIMProvisionRequest, tInfo []TokenInfo) (err error)
+	Delete(ctx context.Context, tInfo []TokenInfo) (err error)
+	ProvisionSCIM(ctx context.Context, post SCIMProvisionRequest) (err error)
 }
 
-func (k *SCIM) Get() (scimCreds SCIMCredentialsResponse, err error) {
+func (k *SCIM) Get(ctx context.Context) (scimCreds SCIMCredentialsResponse, err error) {
 
 	path := fmt.Sprintf("%s/%s", apiVersion, scimCredentialsPath)
 
@@ -41,9 +43,9 @@ func (k *SCIM) Get() (scimCreds SCIMCredentialsResponse, err error) {
 		return
 	}
 
-	response, err := k.restClient.DoGet(myUrl.String())
+	response, err := k.restClient.DoGetContext(ctx, myUrl.String())
 	if err != nil {
-		err = fmt.Errorf("request to %s %s failed %w", response.Request.Method, response.Request.URL.String(), err)
+		err = fmt.Errorf("request to %s %s failed %w", http.MethodGet, myUrl.String(), err)
 		return
 	}
 	resp, err := restclient.HandleResponse(response)
@@ -59,10 +61,10 @@ func (k *SCIM) Get() (scimCreds SCIMCredentialsResponse, err error) {
 	return j.Data, nil
 }
 
-func (k *SCIM) Create() (scimCreds createResp, err error) {
+func (k *SCIM) Create(ctx context.Context) (scimCreds createResp, err error) {
 
 	//create scim credentials for org
-	response, err := k.restClient.Create(apiVersion, scimCredentialsPath, nil, "")
+	response, err := k.restClient.CreateContext(ctx, apiVersion, scimCredentialsPath, nil, "")
 	if err != nil {
 		return
 	}
@@ -73,20 +75,20 @@ func (k *SCIM) Create() (scimCreds createResp, err error) {
 	return
 }
 
-func (k *SCIM) Update(post SCIMProvisionRequest, tInfo []TokenInfo) (err error) {
+func (k *SCIM) Update(ctx context.Context, post SCIMProvisionRequest, tInfo []TokenInfo) (err error) {
 
-	err = k.ProvisionSCIM(post)
+	err = k.ProvisionSCIM(ctx, post)
 	if err != nil {
 		return
 	}
 
 	if post.IsEnabled {
-		_, err = k.restClient.Create(apiVersion, scimCredentialsPath, nil, "")
+		_, err = k.restClient.CreateContext(ctx, apiVersion, scimCredentialsPath, nil, "")
 		if err != nil {
 			return
 		}
 	} else {
-		err = k.Delete(tInfo)
+		err = k.Delete(ctx, tInfo)
 		if err != nil {
 			return
 		}
@@ -95,9 +97,9 @@ func (k *SCIM) Update(post SCIMProvisionRequest, tInfo []TokenInfo) (err error)
 	return
 }
 
-func (k *SCIM) Delete(tInfo []TokenInfo) (err error) {
+func (k *SCIM) Delete(ctx context.Context, tInfo []TokenInfo) (err error) {
 	for _, t := range tInfo {
-		err = k.restClient.Delete(apiVersion, scimTokenDeletePath, t.UUID, "")
+		err = k.restClient.DeleteContext(ctx, apiVersion, scimTokenDeletePath, t.UUID, "")
 		if err != nil {
 			return
 		}
@@ -105,14 +107,14 @@ func (k *SCIM) Delete(tInfo []TokenInfo) (err error) {
 	return
 }
 
-func (k *SCIM) ProvisionSCIM(post SCIMProvisionRequest) (err error) {
+func (k *SCIM) ProvisionSCIM(ctx context.Context, post SCIMProvisionRequest) (err error) {
 	body, err := json.Marshal(post)
 	if err != nil {
 		return
 	}
 
 	//provision scim for an org
-	_, err = k.restClient.Create(apiVersion, scimProvisionPath, body, "")
+	_, err = k.restClient.CreateContext(ctx, apiVersion, scimProvisionPath, body, "")
 	if err != nil {
 		return
 	}
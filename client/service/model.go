@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 )
@@ -18,14 +20,16 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get(id string) (spec GetServiceSpec, err error)
-	Create(spec CreateService) (created GetServiceSpec, err error)
-	Update(id string, spec CreateService) (updated GetServiceSpec, err error)
-	Delete(id string) (err error)
-	DetachPolicy(id string) (err error)
-	Disable(id string) (err error)
-	Enable(id string) (err error)
-	GetPolicyForService(id string) (attachedPolicy policy.GetPolicy, err error)
+	Get(ctx context.Context, id string) (spec GetServiceSpec, err error)
+	Create(ctx context.Context, spec CreateService) (created GetServiceSpec, err error)
+	Update(ctx context.Context, id string, spec CreateService) (updated GetServiceSpec, err error)
+	Delete(ctx context.Context, id string) (err error)
+	DetachPolicy(ctx context.Context, id string) (err error)
+	Disable(ctx context.Context, id string) (err error)
+	Enable(ctx context.Context, id string) (err error)
+	GetPolicyForService(ctx context.Context, id string) (attachedPolicy policy.GetPolicy, err error)
+	GetAll(ctx context.Context) (services []RegisteredServiceInfo, err error)
+	GetByName(ctx context.Context, name string) (service RegisteredServiceInfo, err error)
 }
 
 type Services struct {
@@ -94,7 +98,7 @@ type Attributes struct {
 	HostTagSelector   []map[string]string `json:"host_tag_selector" toml:"host_tag_selector"`
 	// deprecated: Addresses
 	Addresses         []string `json:"addresses,omitempty" toml:"addresses"`
-	DisablePrivateDns bool     `json:"disable_private_dns"`
+	DisablePrivateDns bool     `json:"disable_private_dns" toml:"disable_private_dns"`
 }
 type FrontendAddress struct {
 	CIDR string `json:"cidr" toml:"cidr"`
@@ -127,14 +131,14 @@ type HTTPSettings struct {
 
 type CustomTrustCookie struct {
 	// SameSite: "", "default", "lax", "none", "strict"
-	SameSite string `json:"same_site,omitempty"`
+	SameSite string `json:"same_site,omitempty" toml:"same_site"`
 	// Path: override the default path of "/"
-	Path string `json:"path,omitempty"`
+	Path string `json:"path,omitempty" toml:"path"`
 }
 type TokenLocation struct {
-	QueryParam          string `json:"query_param,omitempty"`
-	AuthorizationHeader bool   `json:"authorization_header,omitempty"`
-	CustomHeader        string `json:"custom_header,omitempty"`
+	QueryParam          string `json:"query_param,omitempty" toml:"query_param"`
+	AuthorizationHeader bool   `json:"authorization_header,omitempty" toml:"authorization_header"`
+	CustomHeader        string `json:"custom_header,omitempty" toml:"custom_header"`
 }
 
 type ClientCIDRs struct {
@@ -248,13 +252,13 @@ type HostTag struct {
 
 type Backend struct {
 	// BackendTarget specifies the backend workload instance's address or name, ports, and TLS properties.
-	BackendTarget `json:"target"`
+	BackendTarget `json:"target" toml:"target"`
 	// BackendDNSOverrides is an optional section that specifies name-to-address or name-to-name mappings.
 	// Name-to-address mapping could be used instead of DNS lookup. Format is "FQDN: ip_address".
 	// Name-to-name mapping could be used to override one FQDN with the other. Format is "FQDN1: FQDN2"
 	// Example: name-to-address -> "internal.myservice.com" : "10.23.0.1"
 	//          name-to-name    ->    "exposed.service.com" : "internal.myservice.com"
-	BackendDNSOverrides map[string]string `json:"dns_overrides"`
+	BackendDNSOverrides map[string]string `json:"dns_overrides" toml:"dns_overrides"`
 	// BackendWhitelist is an optional section that indicates the whitelisted/allowed names for
 	// the backend workload instance. If this field is populated, then the backend name must
 	// match at least one entry in this field list to establish connection with the backend service.
@@ -262,7 +266,7 @@ type Backend struct {
 	// than one backend name. This field is used for non-http-connect cases with backend names/FQDNs.
 	// For httpConnect usecases where more advanced backend defining patterns are required,
 	// plz use BackendAllowPatterns.
-	BackendWhitelist []string `json:"whitelist"`
+	BackendWhitelist []string `json:"whitelist" toml:"whitelist"`
 	// BackendAllowPatterns is an optional section defines the patterns for the backend workload
 	// instance. If BackendWhitelist/BackendAllowPatterns are both not populated, then all backend
 	// address/name/port are allowed. This field is effective only when BackendWhitelist is not populated.
@@ -270,12 +274,12 @@ type Backend struct {
 	// in this list to establish connection with the backend service.  This could be used
 	// for both httpConnect and non-httpConnect cases.  In non-httpConnect cases only backend
 	// hostnames are effective and other fields are ignored.
-	BackendAllowPatterns []BackendAllowPattern `json:"allow_patterns,omitempty"`
+	BackendAllowPatterns []BackendAllowPattern `json:"allow_patterns,omitempty" toml:"allow_patterns"`
 	// HttpConnect is an optional setting that indicates to use HTTP Connect request to derive
 	// the backend target address.
-	HttpConnect bool `json:"http_connect,omitempty"`
+	HttpConnect bool `json:"http_connect,omitempty" toml:"http_connect"`
 	// ConnectorName indicates that the backend target is in the given connector's private network.
-	ConnectorName string `json:"connector_name"`
+	ConnectorName string `json:"connector_name" toml:"connector_name"`
 }
 
 type BackendTarget struct {
@@ -297,45 +301,45 @@ type BackendTarget struct {
 	// corresponds to "example.com".
 	// As a concrete example, "{{ .Name }}-internal.{{ .Domain }}"
 	// maps to "www-internal.example.com" for client SNI "www.example.com".
-	Name string `json:"name"`
+	Name string `json:"name" toml:"name"`
 	// Port specifies the backend server's TCP port number.
-	Port string `json:"port"`
+	Port string `json:"port" toml:"port"`
 	// TLS indicates whether the connection to the backend server uses TLS.
-	TLS bool `json:"tls"`
+	TLS bool `json:"tls" toml:"tls"`
 	// TLSInsecure indicates whether the backend TLS connection does not validate the server's TLS certificate
-	TLSInsecure bool `json:"tls_insecure"`
+	TLSInsecure bool `json:"tls_insecure" toml:"tls_insecure"`
 	// ClientCertificate indicates whether to provide netagent's client TLS certificate to the server if
 	// the server asks for it in the TLS handshake.
-	ClientCertificate bool `json:"client_certificate"`
+	ClientCertificate bool `json:"client_certificate" toml:"client_certificate"`
 }
 type BackendAllowPattern struct {
 	// Allowed hostnames my include a leading and/or trailing wildcard character "*"
 	// to match multiple hostnames
-	Hostnames []string `json:"hostnames,omitempty"`
+	Hostnames []string `json:"hostnames,omitempty" toml:"hostnames"`
 	// Host may be a CIDR such as 10.1.1.0/24
-	CIDRs []string `json:"cidrs,omitempty"`
+	CIDRs []string `json:"cidrs,omitempty" toml:"cidrs"`
 	// List of allowed ports and port ranges
-	Ports BackendAllowPorts `json:"ports,omitempty"`
+	Ports BackendAllowPorts `json:"ports,omitempty" toml:"ports"`
 }
 
 type BackendAllowPorts struct {
 	// List of allowed ports
-	PortList []int `json:"port_list,omitempty"`
+	PortList []int `json:"port_list,omitempty" toml:"port_list"`
 	// List of allowed port ranges
-	PortRanges []PortRange `json:"port_ranges,omitempty"`
+	PortRanges []PortRange `json:"port_ranges,omitempty" toml:"port_ranges"`
 }
 
 type PortRange struct {
 	// Min and Max values of the port range
-	Min int `json:"min"`
-	Max int `json:"max"`
+	Min int `json:"min" toml:"min"`
+	Max int `json:"max" toml:"max"`
 }
 type CreateService struct {
-	Kind       string   `json:"kind"`
-	APIVersion string   `json:"apiVersion"`
-	Type       string   `json:"type"`
-	Metadata   Metadata `json:"metadata"`
-	Spec       Spec     `json:"spec"`
+	Kind       string   `json:"kind" toml:"kind"`
+	APIVersion string   `json:"apiVersion" toml:"apiVersion"`
+	Type       string   `json:"type" toml:"type"`
+	Metadata   Metadata `json:"metadata" toml:"metadata"`
+	Spec       Spec     `json:"spec" toml:"spec"`
 }
 
 type RegisteredServiceInfo struct {
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html"
@@ -9,13 +10,14 @@ import (
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
 	"github.com/banyansecurity/terraform-banyan-provider/client/policyattachment"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/pkg/errors"
 )
 
 const apiVersion = "api/v1"
 const component = "service"
 
-func (s *Service) Get(id string) (service GetServiceSpec, err error) {
+func (s *Service) Get(ctx context.Context, id string) (service GetServiceSpec, err error) {
 	if id == "" {
 		err = errors.New("need an id to get a service")
 		return
@@ -27,7 +29,7 @@ func (s *Service) Get(id string) (service GetServiceSpec, err error) {
 	}
 	query := myUrl.Query()
 	query.Set("ServiceID", id)
-	resp, err := s.restClient.ReadQuery(component, query, path)
+	resp, err := s.restClient.ReadQueryContext(ctx, component, query, path)
 	if err != nil {
 		return
 	}
@@ -37,7 +39,7 @@ func (s *Service) Get(id string) (service GetServiceSpec, err error) {
 		return
 	}
 	if len(createdServiceJson) == 0 {
-		err = errors.New("service not found")
+		err = fmt.Errorf("service %w", restclient.ErrNotFound)
 		return
 	}
 	if len(createdServiceJson) > 1 {
@@ -54,9 +56,9 @@ func (s *Service) Get(id string) (service GetServiceSpec, err error) {
 	return MapToGetServiceSpec(createdServiceJson[0]), nil
 }
 
-func (s *Service) Disable(id string) (err error) {
+func (s *Service) Disable(ctx context.Context, id string) (err error) {
 	path := "api/v1/disable_registered_service"
-	err = s.updateService(id, path)
+	err = s.updateService(ctx, id, path)
 	if err != nil {
 		return
 	}
@@ -64,9 +66,9 @@ func (s *Service) Disable(id string) (err error) {
 	return
 }
 
-func (s *Service) Enable(id string) (err error) {
+func (s *Service) Enable(ctx context.Context, id string) (err error) {
 	path := "api/v1/enable_registered_service"
-	err = s.updateService(id, path)
+	err = s.updateService(ctx, id, path)
 	if err != nil {
 		return
 	}
@@ -74,7 +76,7 @@ func (s *Service) Enable(id string) (err error) {
 	return
 }
 
-func (s *Service) updateService(id, path string) (err error) {
+func (s *Service) updateService(ctx context.Context, id, path string) (err error) {
 	myUrl, err := url.Parse(path)
 	if err != nil {
 		return
@@ -82,15 +84,15 @@ func (s *Service) updateService(id, path string) (err error) {
 	query := myUrl.Query()
 	query.Set("ServiceID", id)
 	myUrl.RawQuery = query.Encode()
-	_, err = s.restClient.DoPost(myUrl.String(), nil)
+	_, err = s.restClient.DoPostContext(ctx, myUrl.String(), nil)
 	if err != nil {
 		err = fmt.Errorf("error while enable/disable service %s", err)
 	}
 	return
 }
 
-func (s *Service) Delete(id string) (err error) {
-	err = s.Disable(id)
+func (s *Service) Delete(ctx context.Context, id string) (err error) {
+	err = s.Disable(ctx, id)
 	if err != nil {
 		return
 	}
@@ -102,7 +104,7 @@ func (s *Service) Delete(id string) (err error) {
 	query := myUrl.Query()
 	query.Set("ServiceID", id)
 	myUrl.RawQuery = query.Encode()
-	err = s.restClient.DeleteQuery("service", id, query, path)
+	err = s.restClient.DeleteQueryContext(ctx, "service", id, query, path)
 	if err != nil {
 		err = fmt.Errorf("error deleting service %s", err)
 	}
@@ -110,17 +112,17 @@ func (s *Service) Delete(id string) (err error) {
 	return
 }
 
-func (s *Service) DetachPolicy(serviceID string) (err error) {
+func (s *Service) DetachPolicy(ctx context.Context, serviceID string) (err error) {
 	c := policyattachment.NewClient(s.restClient)
-	policyAtt, err := c.Get(serviceID, "service")
+	policyAtt, err := c.Get(ctx, serviceID, "service")
 	if err != nil {
 		return
 	}
-	_ = c.DeleteServiceAttachment(policyAtt.PolicyID, serviceID)
+	_ = c.DeleteServiceAttachment(ctx, policyAtt.PolicyID, serviceID)
 	return
 }
 
-func (s *Service) Create(spec CreateService) (created GetServiceSpec, err error) {
+func (s *Service) Create(ctx context.Context, spec CreateService) (created GetServiceSpec, err error) {
 	path := "api/v1/insert_registered_service"
 	body, err := json.Marshal(spec)
 	if err != nil {
@@ -129,7 +131,7 @@ func (s *Service) Create(spec CreateService) (created GetServiceSpec, err error)
 	// The API will always clobber, which leads to odd behavior
 	// This aligns behavior with user expectations
 	// Don't clobber if the service name already exist
-	existing, err := s.GetByName(spec.Metadata.Name)
+	existing, err := s.GetByName(ctx, spec.Metadata.Name)
 	if err != nil {
 		return
 	}
@@ -138,8 +140,7 @@ func (s *Service) Create(spec CreateService) (created GetServiceSpec, err error)
 		return
 	}
 
-	resp, err := s.restClient.Create(apiVersion, component, body, path)
-	log.Printf("[INFO] Created service %s", resp)
+	resp, err := s.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -159,14 +160,13 @@ func (s *Service) Create(spec CreateService) (created GetServiceSpec, err error)
 	return MapToGetServiceSpec(j), nil
 }
 
-func (s *Service) Update(id string, spec CreateService) (updated GetServiceSpec, err error) {
+func (s *Service) Update(ctx context.Context, id string, spec CreateService) (updated GetServiceSpec, err error) {
 	path := "api/v1/insert_registered_service"
 	body, err := json.Marshal(spec)
 	if err != nil {
 		return
 	}
-	resp, err := s.restClient.Create(apiVersion, component, body, path)
-	log.Printf("[INFO] Updated service %s", resp)
+	resp, err := s.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -217,28 +217,28 @@ func MapToGetServiceSpec(original GetServicesJson) (new GetServiceSpec) {
 }
 
 // get policy for service
-func (s *Service) GetPolicyForService(id string) (attachedPolicy policy.GetPolicy, err error) {
+func (s *Service) GetPolicyForService(ctx context.Context, id string) (attachedPolicy policy.GetPolicy, err error) {
 	paClient := policyattachment.NewClient(s.restClient)
 	pClient := policy.NewClient(s.restClient)
-	policyAtt, err := paClient.Get(id, "service")
+	policyAtt, err := paClient.Get(ctx, id, "service")
 	if err != nil {
 		return
 	}
 	if policyAtt.PolicyID == "" {
 		return
 	}
-	attachedPolicy, err = pClient.Get(policyAtt.PolicyID)
+	attachedPolicy, err = pClient.Get(ctx, policyAtt.PolicyID)
 	return
 }
 
-func (s *Service) GetAll() (services []RegisteredServiceInfo, err error) {
+func (s *Service) GetAll(ctx context.Context) (services []RegisteredServiceInfo, err error) {
 	path := "api/v1/registered_services"
 	myUrl, err := url.Parse(path)
 	if err != nil {
 		return
 	}
 	query := myUrl.Query()
-	resp, err := s.restClient.ReadQuery(component, query, path)
+	resp, err := s.restClient.ReadQueryContext(ctx, component, query, path)
 	if err != nil {
 		return
 	}
@@ -248,8 +248,8 @@ func (s *Service) GetAll() (services []RegisteredServiceInfo, err error) {
 	}
 	return
 }
-func (s *Service) GetByName(name string) (service RegisteredServiceInfo, err error) {
-	specs, err := s.GetAll()
+func (s *Service) GetByName(ctx context.Context, name string) (service RegisteredServiceInfo, err error) {
+	specs, err := s.GetAll(ctx)
 	if err != nil {
 		return
 	}
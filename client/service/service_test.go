@@ -1,41 +1,36 @@
 package service_test
 
 import (
-	"github.com/banyansecurity/terraform-banyan-provider/client/testenv"
+	"context"
 	"testing"
 
-	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/banyansecurity/terraform-banyan-provider/client/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_GetNonexistentService(t *testing.T) {
-	testhost := testenv.GetBanyanHostUrl()
-	apiKey := testenv.GetApiKey()
-	myClient, err := client.NewClientHolder(testhost, apiKey)
+	myClient, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	svc, err := myClient.Service.Get("hah")
-	assert.NoError(t, err, "expected no error here")
-	assert.Equal(t, service.GetServiceSpec{}, svc, "expected to get service x")
+	svc, err := myClient.Service.Get(context.Background(), "hah")
+	assert.ErrorIs(t, err, restclient.ErrNotFound)
+	assert.Equal(t, service.GetServiceSpec{}, svc, "expected to get no service")
 }
 
 func Test_GetExistingService(t *testing.T) {
-	testhost := testenv.GetBanyanHostUrl()
-	apiKey := testenv.GetApiKey()
-	myClient, err := client.NewClientHolder(testhost, apiKey)
+	myClient, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	svc, err := myClient.Service.Get("testservice.us-west.bnn")
+	svc, err := myClient.Service.Get(context.Background(), "testservice.us-west.bnn")
 	assert.NoError(t, err, "expected no error here")
 	assert.NotEqual(t, service.GetServiceSpec{}, svc, "expected to get service x")
 }
 
 func Test_CreateService(t *testing.T) {
 	somestring := "test"
-	testhost := testenv.GetBanyanHostUrl()
-	apiKey := testenv.GetApiKey()
-	myClient, err := client.NewClientHolder(testhost, apiKey)
+	myClient, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	svc, err := myClient.Service.Create(service.CreateService{
+	svc, err := myClient.Service.Create(context.Background(), service.CreateService{
 		APIVersion: "rbac.banyanops.com/v1",
 		Kind:       "BanyanService",
 		Metadata: service.Metadata{
@@ -104,15 +99,16 @@ func Test_CreateService(t *testing.T) {
 	})
 	assert.NoError(t, err, "expect no error when creating a service")
 	assert.NotEqual(t, service.GetServiceSpec{}, svc, "expected to get service x")
+	// Create refuses an existing name, so free it for Test_CreateService2
+	err = myClient.Service.Delete(context.Background(), svc.ServiceID)
+	assert.NoError(t, err)
 }
 
 func Test_CreateService2(t *testing.T) {
 	somestring := "test"
-	testhost := testenv.GetBanyanHostUrl()
-	apiKey := testenv.GetApiKey()
-	myClient, err := client.NewClientHolder(testhost, apiKey)
+	myClient, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	svc, err := myClient.Service.Create(service.CreateService{
+	svc, err := myClient.Service.Create(context.Background(), service.CreateService{
 		APIVersion: "rbac.banyanops.com/v1",
 		Kind:       "BanyanService",
 		Metadata: service.Metadata{
@@ -184,10 +180,8 @@ func Test_CreateService2(t *testing.T) {
 }
 
 func Test_delete(t *testing.T) {
-	testhost := testenv.GetBanyanHostUrl()
-	apiKey := testenv.GetApiKey()
-	myClient, err := client.NewClientHolder(testhost, apiKey)
+	myClient, err := testutil.GetClientHolderForTest()
 	assert.NoError(t, err, "Expected to not get an error here")
-	err = myClient.Service.Delete("terraformtest.dev05-banyan.bnn")
+	err = myClient.Service.Delete(context.Background(), "terraformtest.dev05-banyan.bnn")
 	assert.NoError(t, err)
 }
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// MarshalTOML encodes a service specification in the TOML format used by netagent configs and banyanctl exports
+func MarshalTOML(spec CreateService) ([]byte, error) {
+	var buf bytes.Buffer
+	err := toml.NewEncoder(&buf).Encode(spec)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't encode service %q as toml: %w", spec.Metadata.Name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalTOML decodes a service specification from TOML. Keys which are not part of the specification are
+// rejected rather than dropped.
+func UnmarshalTOML(data []byte) (spec CreateService, err error) {
+	md, err := toml.Decode(string(data), &spec)
+	if err != nil {
+		return spec, fmt.Errorf("couldn't decode service toml: %w", err)
+	}
+	if undecoded := md.Undecoded(); len(undecoded) > 0 {
+		keys := make([]string, len(undecoded))
+		for i, key := range undecoded {
+			keys[i] = key.String()
+		}
+		return spec, fmt.Errorf("couldn't decode service toml: unknown keys %s", strings.Join(keys, ", "))
+	}
+	return
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TOMLRoundTrip(t *testing.T) {
+	template := "WEB_USER"
+	userFacing := "true"
+	spec := service.CreateService{
+		Kind:       "BanyanService",
+		APIVersion: "rbac.banyanops.com/v1",
+		Type:       "origin",
+		Metadata: service.Metadata{
+			Name:        "web",
+			Description: "web service",
+			ClusterName: "cluster1",
+			Tags:        service.Tags{Template: &template, UserFacing: &userFacing},
+		},
+		Spec: service.Spec{
+			Attributes: service.Attributes{
+				TLSSNI:            []string{"web.corp.com"},
+				FrontendAddresses: []service.FrontendAddress{{CIDR: "", Port: "443"}},
+				DisablePrivateDns: true,
+			},
+			Backend: service.Backend{
+				BackendTarget:       service.BackendTarget{Name: "10.0.0.1", Port: "8080", TLS: true},
+				BackendDNSOverrides: map[string]string{"web.corp.com": "10.0.0.1"},
+				BackendAllowPatterns: []service.BackendAllowPattern{{
+					Hostnames: []string{"*.internal"},
+					Ports:     service.BackendAllowPorts{PortList: []int{80}, PortRanges: []service.PortRange{{Min: 8000, Max: 8100}}},
+				}},
+				ConnectorName: "connector1",
+			},
+			CertSettings: service.CertSettings{DNSNames: []string{"web.corp.com"}, Letsencrypt: true},
+			HTTPSettings: service.HTTPSettings{
+				Enabled:      true,
+				OIDCSettings: service.OIDCSettings{Enabled: true, ServiceDomainName: "https://web.corp.com"},
+				HTTPRedirect: service.HTTPRedirect{StatusCode: 302},
+				ExemptedPaths: service.ExemptedPaths{Patterns: []service.Pattern{{
+					Hosts:   []service.Host{{OriginHeader: []string{"https://corp.com"}, Target: []string{"web.corp.com"}}},
+					Methods: []string{"GET"},
+				}}},
+				TokenLoc: &service.TokenLocation{AuthorizationHeader: true},
+			},
+			ClientCIDRs: []service.ClientCIDRs{{Addresses: []service.CIDRAddress{{CIDR: "10.0.0.0/8", Ports: "443"}}}},
+		},
+	}
+	encoded, err := service.MarshalTOML(spec)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), "[spec.backend.target]")
+	decoded, err := service.UnmarshalTOML(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, spec, decoded)
+}
+
+func Test_UnmarshalTOML(t *testing.T) {
+	spec, err := service.UnmarshalTOML([]byte(`
+kind = "BanyanService"
+apiVersion = "rbac.banyanops.com/v1"
+type = "origin"
+
+[metadata]
+name = "ssh"
+cluster = "cluster1"
+
+[metadata.tags]
+template = "TCP_USER"
+
+[spec.attributes]
+tls_sni = ["ssh.corp.com"]
+
+[[spec.attributes.frontend_addresses]]
+cidr = ""
+port = "8443"
+
+[spec.backend.target]
+name = "10.0.0.2"
+port = "22"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "ssh", spec.Metadata.Name)
+	assert.Equal(t, "TCP_USER", *spec.Metadata.Tags.Template)
+	assert.Equal(t, []service.FrontendAddress{{Port: "8443"}}, spec.Spec.Attributes.FrontendAddresses)
+	assert.Equal(t, "22", spec.Spec.Backend.BackendTarget.Port)
+
+	_, err = service.UnmarshalTOML([]byte("kind = \"BanyanService\"\n[spec.backend.targt]\nname = \"x\"\n"))
+	assert.ErrorContains(t, err, "unknown keys spec.backend.targt")
+
+	_, err = service.UnmarshalTOML([]byte("kind = "))
+	assert.ErrorContains(t, err, "couldn't decode service toml")
+}
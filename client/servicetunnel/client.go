@@ -1,10 +1,12 @@
 package servicetunnel
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html"
 	"log"
+	"net/url"
 
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 )
@@ -25,17 +27,18 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	Get(id string) (spec ServiceTunnelInfo, err error)
-	Create(spec Info) (created ServiceTunnelInfo, err error)
-	Update(id string, spec Info) (updated ServiceTunnelInfo, err error)
-	Delete(id string) (err error)
-	AttachPolicy(id string, post PolicyAttachmentPost) (created PolicyAttachmentInfo, err error)
-	DeletePolicy(tunID string, policyID string) (err error)
-	GetPolicy(id string) (policy GetPolicyAttachmentInfo, err error)
+	Get(ctx context.Context, id string) (spec ServiceTunnelInfo, err error)
+	GetAll(ctx context.Context) (tunnels []ServiceTunnelInfoResponse, err error)
+	Create(ctx context.Context, spec Info) (created ServiceTunnelInfo, err error)
+	Update(ctx context.Context, id string, spec Info) (updated ServiceTunnelInfo, err error)
+	Delete(ctx context.Context, id string) (err error)
+	AttachPolicy(ctx context.Context, id string, post PolicyAttachmentPost) (created PolicyAttachmentInfo, err error)
+	DeletePolicy(ctx context.Context, tunID string, policyID string) (err error)
+	GetPolicy(ctx context.Context, id string) (policy GetPolicyAttachmentInfo, err error)
 }
 
-func (a *ServiceTunnel) Get(id string) (spec ServiceTunnelInfo, err error) {
-	resp, err := a.restClient.Read(apiVersion, component, id, "")
+func (a *ServiceTunnel) Get(ctx context.Context, id string) (spec ServiceTunnelInfo, err error) {
+	resp, err := a.restClient.ReadContext(ctx, apiVersion, component, id, "")
 	if err != nil {
 		return
 	}
@@ -43,7 +46,19 @@ func (a *ServiceTunnel) Get(id string) (spec ServiceTunnelInfo, err error) {
 	return
 }
 
-func (a *ServiceTunnel) Create(spec Info) (created ServiceTunnelInfo, err error) {
+// GetAll returns every service tunnel in the org without decoding their specs
+func (a *ServiceTunnel) GetAll(ctx context.Context) (tunnels []ServiceTunnelInfoResponse, err error) {
+	resp, err := a.restClient.ReadQueryContext(ctx, component, url.Values{}, fmt.Sprintf("%s/%s", apiVersion, component))
+	if err != nil {
+		return
+	}
+	var j ListResponse
+	err = json.Unmarshal(resp, &j)
+	tunnels = j.Data.ServiceTunnels
+	return
+}
+
+func (a *ServiceTunnel) Create(ctx context.Context, spec Info) (created ServiceTunnelInfo, err error) {
 	body, err := json.Marshal(Info{
 		Kind:       "BanyanServiceTunnel",
 		APIVersion: "rbac.banyanops.com/v1",
@@ -61,7 +76,7 @@ func (a *ServiceTunnel) Create(spec Info) (created ServiceTunnelInfo, err error)
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Create(apiVersion, component, body, "")
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, component, body, "")
 	if err != nil {
 		return
 	}
@@ -69,7 +84,7 @@ func (a *ServiceTunnel) Create(spec Info) (created ServiceTunnelInfo, err error)
 	return
 }
 
-func (a *ServiceTunnel) Update(id string, spec Info) (updated ServiceTunnelInfo, err error) {
+func (a *ServiceTunnel) Update(ctx context.Context, id string, spec Info) (updated ServiceTunnelInfo, err error) {
 	body, err := json.Marshal(Info{
 		Kind:       "BanyanServiceTunnel",
 		APIVersion: "rbac.banyanops.com/v1",
@@ -87,7 +102,7 @@ func (a *ServiceTunnel) Update(id string, spec Info) (updated ServiceTunnelInfo,
 	if err != nil {
 		return
 	}
-	resp, err := a.restClient.Update(apiVersion, component, id, body, "")
+	resp, err := a.restClient.UpdateContext(ctx, apiVersion, component, id, body, "")
 	if err != nil {
 		return
 	}
@@ -95,16 +110,16 @@ func (a *ServiceTunnel) Update(id string, spec Info) (updated ServiceTunnelInfo,
 	return
 }
 
-func (a *ServiceTunnel) Delete(id string) (err error) {
-	err = a.restClient.Delete(apiVersion, component, id, "")
+func (a *ServiceTunnel) Delete(ctx context.Context, id string) (err error) {
+	err = a.restClient.DeleteContext(ctx, apiVersion, component, id, "")
 	return
 }
 
 // GetPolicy returns the policy attached to the service tunnel
-func (a *ServiceTunnel) GetPolicy(id string) (policy GetPolicyAttachmentInfo, err error) {
+func (a *ServiceTunnel) GetPolicy(ctx context.Context, id string) (policy GetPolicyAttachmentInfo, err error) {
 	path := fmt.Sprintf("%s/%s/%s/security_policy", apiVersion, component, id)
 	var j GetPolicyResponse
-	resp, err := a.restClient.Read(apiVersion, component, id, path)
+	resp, err := a.restClient.ReadContext(ctx, apiVersion, component, id, path)
 	if err != nil {
 		return policy, nil
 	}
@@ -116,7 +131,7 @@ func (a *ServiceTunnel) GetPolicy(id string) (policy GetPolicyAttachmentInfo, er
 	return
 }
 
-func (a *ServiceTunnel) AttachPolicy(id string, post PolicyAttachmentPost) (created PolicyAttachmentInfo, err error) {
+func (a *ServiceTunnel) AttachPolicy(ctx context.Context, id string, post PolicyAttachmentPost) (created PolicyAttachmentInfo, err error) {
 	if id == "" {
 		err = fmt.Errorf("need service tunnel id to attach a policy")
 		return PolicyAttachmentInfo{}, err
@@ -126,19 +141,19 @@ func (a *ServiceTunnel) AttachPolicy(id string, post PolicyAttachmentPost) (crea
 		return
 	}
 	// remove if there is a policy currently attached
-	currentAttached, err := a.GetPolicy(id)
+	currentAttached, err := a.GetPolicy(ctx, id)
 	if err != nil {
 		return
 	}
 	if currentAttached.PolicyID != "" {
 		log.Printf("[INFO] Detaching previously attached policy from service tunnel")
-		err = a.DeletePolicy(id, currentAttached.PolicyID)
+		err = a.DeletePolicy(ctx, id, currentAttached.PolicyID)
 		if err != nil {
 			return PolicyAttachmentInfo{}, err
 		}
 	}
 	path := fmt.Sprintf("%s/%s/%s/security_policy", apiVersion, component, id)
-	resp, err := a.restClient.Create(apiVersion, component, body, path)
+	resp, err := a.restClient.CreateContext(ctx, apiVersion, component, body, path)
 	if err != nil {
 		return
 	}
@@ -151,9 +166,9 @@ func (a *ServiceTunnel) AttachPolicy(id string, post PolicyAttachmentPost) (crea
 	return
 }
 
-func (a *ServiceTunnel) DeletePolicy(tunID string, policyID string) (err error) {
+func (a *ServiceTunnel) DeletePolicy(ctx context.Context, tunID string, policyID string) (err error) {
 	path := fmt.Sprintf("%s/%s/%s/security_policy/%s", apiVersion, component, tunID, policyID)
-	err = a.restClient.Delete(apiVersion, component, tunID, path)
+	err = a.restClient.DeleteContext(ctx, apiVersion, component, tunID, path)
 	return
 }
 
@@ -128,3 +128,13 @@ type Response struct {
 	Data             ServiceTunnelInfoResponse `json:"data"`
 	Count            int                       `json:"count"`
 }
+
+type ListResponse struct {
+	RequestId        string `json:"request_id"`
+	ErrorCode        int    `json:"error_code"`
+	ErrorDescription string `json:"error_description"`
+	Data             struct {
+		ServiceTunnels []ServiceTunnelInfoResponse `json:"service_tunnels"`
+		Count          int                         `json:"count"`
+	} `json:"data"`
+}
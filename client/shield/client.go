@@ -1,6 +1,7 @@
 package shield
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/banyansecurity/terraform-banyan-provider/client/restclient"
 	"log"
@@ -35,17 +36,17 @@ func NewClient(restClient *restclient.Client) Client {
 }
 
 type Client interface {
-	GetAll() (shields []string, err error)
+	GetAll(ctx context.Context) (shields []string, err error)
 }
 
-func (s *Shield) GetAll() (shields []string, err error) {
+func (s *Shield) GetAll(ctx context.Context) (shields []string, err error) {
 	log.Printf("getting shields")
 	path := "api/v2/shield_config"
 	myUrl, err := url.Parse(path)
 	if err != nil {
 		return
 	}
-	response, err := s.restClient.DoGet(myUrl.String())
+	response, err := s.restClient.DoGetContext(ctx, myUrl.String())
 	if err != nil {
 		return
 	}
@@ -1,15 +1,101 @@
 package testutil
 
 import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"testing"
+
 	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/accesstier"
+	"github.com/banyansecurity/terraform-banyan-provider/client/fakeapi"
+	"github.com/banyansecurity/terraform-banyan-provider/client/policy"
+	"github.com/banyansecurity/terraform-banyan-provider/client/satellite"
+	"github.com/banyansecurity/terraform-banyan-provider/client/service"
 	"github.com/banyansecurity/terraform-banyan-provider/client/testenv"
-	"log"
 )
 
+var (
+	fakeServer     *fakeapi.Server
+	fakeServerOnce sync.Once
+)
+
+// GetClientHolderForTest returns a client for the tenant in BANYAN_HOST, or for a shared in-memory
+// fakeapi.Server when BANYAN_HOST is not set
 func GetClientHolderForTest() (newClient *client.Holder, err error) {
+	if os.Getenv("BANYAN_HOST") == "" {
+		newClient, err = client.NewClientHolder(GetFakeServer().URL, "fakeapi-key")
+		if err != nil {
+			log.Fatal("Could not create the test client")
+		}
+		return
+	}
 	newClient, err = client.NewClientHolder(testenv.GetBanyanHostUrl(), testenv.GetApiKey())
 	if err != nil {
 		log.Fatal("Could not create the test client")
 	}
 	return
 }
+
+// NewFakeClient starts an empty fakeapi.Server, which is closed when the test ends, and returns it with a client for it
+func NewFakeClient(t testing.TB) (*fakeapi.Server, *client.Holder) {
+	t.Helper()
+	srv := fakeapi.New()
+	t.Cleanup(srv.Close)
+	c, err := client.NewClientHolder(srv.URL, "fakeapi-key")
+	if err != nil {
+		t.Fatalf("could not create a client for the fake API: %s", err)
+	}
+	return srv, c
+}
+
+// GetFakeServer returns the fakeapi.Server shared by the tests in this process, starting it on first use
+func GetFakeServer() *fakeapi.Server {
+	fakeServerOnce.Do(func() {
+		fakeServer = fakeapi.New()
+		seedFakeServer(fakeServer)
+	})
+	return fakeServer
+}
+
+// seedFakeServer adds the fixtures which the client tests expect to find in the test tenant
+func seedFakeServer(s *fakeapi.Server) {
+	s.AddShield("us-west")
+	for id, name := range map[string]string{
+		"73f0820b-cbf1-4e75-9518-21121a6a271c": "everyone",
+		"9ddf21be-2db3-42f6-aa77-2d1a61931278": "go-client-test",
+	} {
+		s.AddPolicy(policy.GetPolicy{
+			ID:        id,
+			Name:      name,
+			Spec:      fmt.Sprintf(`{"kind":"BanyanPolicy","apiVersion":"rbac.banyanops.com/v1","metadata":{"id":"%s","name":"%s"},"type":"USER"}`, id, name),
+			Version:   1,
+			CreatedBy: "fakeapi",
+		})
+	}
+	s.AddService(service.GetServicesJson{
+		ServiceID:   "testservice.us-west.bnn",
+		ServiceName: "testservice",
+		ClusterName: "us-west",
+		ServiceSpec: `{"kind":"BanyanService","apiVersion":"rbac.banyanops.com/v1","metadata":{"name":"testservice","cluster":"us-west"}}`,
+		Enabled:     "TRUE",
+		CreatedBy:   "fakeapi",
+	})
+	s.AddAccessTier(accesstier.AccessTierInfo{
+		ID:          "b29f24db-fc3a-4eb4-880b-8fb1245e13d3",
+		Name:        "at-recreate-1",
+		Address:     "at-recreate-1.somedomain.com",
+		ClusterName: "us-west",
+		Status:      string(accesstier.Pending),
+		Netagents:   []accesstier.NetagentHostInfo{},
+		CreatedBy:   "fakeapi",
+	})
+	s.AddSatellite(satellite.SatelliteTunnelConfig{
+		ID:        "53bb4cdb-8118-4830-a10f-0c69e17d78e3",
+		OrgID:     fakeapi.OrgID,
+		Name:      "go-client-test-existing",
+		Status:    string(satellite.Healthy),
+		CreatedBy: "fakeapi",
+	})
+}
@@ -0,0 +1,95 @@
+// Command banyan-drift compares a terraform state with the objects of a Banyan org, without changing either. It
+// reports objects which no resource in the state manages, objects which were deleted outside of terraform, and
+// attributes which were changed outside of terraform.
+//
+// Usage:
+//
+//	BANYAN_API_KEY=... go run ./cmd/banyan-drift -state terraform.tfstate
+//	terraform show -json | BANYAN_API_KEY=... go run ./cmd/banyan-drift -state - -format sarif
+//
+// Credentials are resolved like the provider does: flags, then the BANYAN_HOST and BANYAN_API_KEY environment
+// variables, then the profile in the credentials file.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/banyansecurity/terraform-banyan-provider/banyan"
+	"github.com/banyansecurity/terraform-banyan-provider/cmd/internal/cli"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func main() {
+	org := cli.OrgFlags(flag.CommandLine)
+	statePath := flag.String("state", "terraform.tfstate", "state file or terraform show -json output to compare, - reads stdin")
+	format := flag.String("format", "text", "report format: text, json or sarif")
+	out := flag.String("o", "", "file to write the report to, defaults to stdout")
+	detailedExitCode := flag.Bool("detailed-exitcode", false, "exit with status 2 when drift is found")
+	flag.Parse()
+
+	drifts, err := run(org, *statePath, *format, *out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "banyan-drift: %s\n", err)
+		os.Exit(1)
+	}
+	if *detailedExitCode && len(drifts) > 0 {
+		os.Exit(2)
+	}
+}
+
+func run(org *cli.Org, statePath, format, out string) (drifts []banyan.Drift, err error) {
+	var write func(io.Writer, []banyan.Drift) error
+	switch format {
+	case "text":
+		write = writeText
+	case "json":
+		write = writeJSON
+	case "sarif":
+		write = func(w io.Writer, drifts []banyan.Drift) error {
+			return writeSARIF(w, drifts, statePath)
+		}
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected text, json or sarif", format)
+	}
+
+	var r io.Reader = os.Stdin
+	if statePath != "-" {
+		f, err := os.Open(statePath)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	managed, err := readState(r)
+	if err != nil {
+		return
+	}
+	c, err := org.Client()
+	if err != nil {
+		return
+	}
+	drifts, diagnostics := banyan.DetectDrift(context.Background(), c, managed)
+	for _, d := range diagnostics {
+		if d.Severity == diag.Error {
+			return nil, fmt.Errorf("%s", d.Summary)
+		}
+		fmt.Fprintf(os.Stderr, "warning: %s %s\n", d.Summary, d.Detail)
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		w = f
+	}
+	err = write(w, drifts)
+	return
+}
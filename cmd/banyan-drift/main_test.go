@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/banyan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readState(t *testing.T) {
+	t.Parallel()
+	state := `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "banyan_role", "name": "eng", "instances": [{"attributes": {"id": "r1", "name": "eng"}}]},
+    {"mode": "data", "type": "banyan_role", "name": "lookup", "instances": [{"attributes": {"id": "r2"}}]},
+    {"module": "module.web", "mode": "managed", "type": "banyan_service_web", "name": "app", "instances": [
+      {"index_key": 0, "attributes": {"id": "s1"}},
+      {"index_key": "b", "attributes": {"id": "s2"}}
+    ]},
+    {"mode": "managed", "type": "aws_instance", "name": "at", "instances": [{"attributes": {"id": "i-1"}}]}
+  ]
+}`
+	managed, err := readState(strings.NewReader(state))
+	require.NoError(t, err)
+	require.Len(t, managed, 3)
+	assert.Equal(t, banyan.StateResource{Address: "banyan_role.eng", Type: "banyan_role", ID: "r1",
+		Attributes: map[string]interface{}{"id": "r1", "name": "eng"}}, managed[0])
+	assert.Equal(t, "module.web.banyan_service_web.app[0]", managed[1].Address)
+	assert.Equal(t, `module.web.banyan_service_web.app["b"]`, managed[2].Address)
+
+	show := `{
+  "format_version": "1.0",
+  "values": {"root_module": {
+    "resources": [{"address": "banyan_role.eng", "mode": "managed", "type": "banyan_role", "values": {"id": "r1"}}],
+    "child_modules": [{"resources": [
+      {"address": "module.web.banyan_service_web.app", "mode": "managed", "type": "banyan_service_web", "values": {"id": "s1"}}
+    ]}]
+  }}
+}`
+	managed, err = readState(strings.NewReader(show))
+	require.NoError(t, err)
+	require.Len(t, managed, 2)
+	assert.Equal(t, "module.web.banyan_service_web.app", managed[1].Address)
+	assert.Equal(t, "s1", managed[1].ID)
+
+	_, err = readState(strings.NewReader(`{"version": 3}`))
+	assert.Error(t, err)
+}
+
+func Test_writeReports(t *testing.T) {
+	t.Parallel()
+	drifts := []banyan.Drift{
+		{Kind: banyan.DriftChanged, Address: "banyan_role.eng", Type: "banyan_role", ID: "r1", Field: "description", State: "a", Live: "b"},
+		{Kind: banyan.DriftUnmanaged, Type: "banyan_role", ID: "r2", Name: "ops"},
+	}
+
+	var text bytes.Buffer
+	require.NoError(t, writeText(&text, drifts))
+	assert.Equal(t, `changed   banyan_role.eng (r1) description changed outside of terraform from "a" to "b"
+unmanaged banyan_role "ops" (r2) is not managed by terraform
+
+1 unmanaged, 0 deleted, 1 changed
+`, text.String())
+
+	var sarif bytes.Buffer
+	require.NoError(t, writeSARIF(&sarif, drifts, "terraform.tfstate"))
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(sarif.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs[0].Results, 2)
+	assert.Equal(t, "changed", log.Runs[0].Results[0].RuleID)
+	assert.Equal(t, "warning", log.Runs[0].Results[0].Level)
+	assert.Equal(t, "terraform.tfstate", log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "note", log.Runs[0].Results[1].Level)
+	assert.Equal(t, "banyan_role.r2", log.Runs[0].Results[1].Locations[0].LogicalLocations[0].FullyQualifiedName)
+
+	var empty bytes.Buffer
+	require.NoError(t, writeJSON(&empty, nil))
+	assert.JSONEq(t, `{"drifts": []}`, empty.String())
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/banyansecurity/terraform-banyan-provider/banyan"
+)
+
+// writeText writes a line per drift and a summary
+func writeText(w io.Writer, drifts []banyan.Drift) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	counts := make(map[banyan.DriftKind]int)
+	for _, d := range drifts {
+		counts[d.Kind]++
+		fmt.Fprintf(tw, "%s\t%s\n", d.Kind, d)
+	}
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+	if len(drifts) == 0 {
+		_, err = fmt.Fprintln(w, "no drift")
+		return err
+	}
+	_, err = fmt.Fprintf(w, "\n%d unmanaged, %d deleted, %d changed\n",
+		counts[banyan.DriftUnmanaged], counts[banyan.DriftDeleted], counts[banyan.DriftChanged])
+	return err
+}
+
+// writeJSON writes the drifts as a JSON object
+func writeJSON(w io.Writer, drifts []banyan.Drift) error {
+	if drifts == nil {
+		drifts = []banyan.Drift{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Drifts []banyan.Drift `json:"drifts"`
+	}{drifts})
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name           string      `json:"name"`
+			InformationURI string      `json:"informationUri"`
+			Rules          []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation struct {
+		URI string `json:"uri"`
+	} `json:"artifactLocation"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// writeSARIF writes the drifts as a SARIF 2.1.0 log, with a rule per kind of drift. Results point at the state
+// file, and at the resource address or, for unmanaged objects, the resource type and ID.
+func writeSARIF(w io.Writer, drifts []banyan.Drift, statePath string) error {
+	run := sarifRun{Results: []sarifResult{}}
+	run.Tool.Driver.Name = "banyan-drift"
+	run.Tool.Driver.InformationURI = "https://github.com/banyansecurity/terraform-banyan-provider"
+	run.Tool.Driver.Rules = []sarifRule{
+		{ID: string(banyan.DriftUnmanaged), ShortDescription: sarifMessage{"Object is not managed by terraform"}},
+		{ID: string(banyan.DriftDeleted), ShortDescription: sarifMessage{"Object was deleted outside of terraform"}},
+		{ID: string(banyan.DriftChanged), ShortDescription: sarifMessage{"Attribute was changed outside of terraform"}},
+	}
+	for _, d := range drifts {
+		level := "warning"
+		if d.Kind == banyan.DriftUnmanaged {
+			level = "note"
+		}
+		var location sarifLocation
+		if statePath != "" && statePath != "-" {
+			location.PhysicalLocation = &sarifPhysicalLocation{}
+			location.PhysicalLocation.ArtifactLocation.URI = statePath
+		}
+		name := d.Address
+		if name == "" {
+			name = d.Type + "." + d.ID
+		}
+		location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: name, Kind: "resource"}}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    string(d.Kind),
+			Level:     level,
+			Message:   sarifMessage{d.String()},
+			Locations: []sarifLocation{location},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/banyan"
+)
+
+// stateFile is the part of a state file, or of the output of terraform show -json, which is read. A state file has
+// resources at the top, terraform show -json has them under values in a tree of modules.
+type stateFile struct {
+	Version   int             `json:"version"`
+	Resources []stateResource `json:"resources"`
+	Values    *struct {
+		RootModule showModule `json:"root_module"`
+	} `json:"values"`
+}
+
+type stateResource struct {
+	Module    string `json:"module"`
+	Mode      string `json:"mode"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Instances []struct {
+		IndexKey   interface{}            `json:"index_key"`
+		Attributes map[string]interface{} `json:"attributes"`
+	} `json:"instances"`
+}
+
+type showModule struct {
+	Resources []struct {
+		Address string                 `json:"address"`
+		Mode    string                 `json:"mode"`
+		Type    string                 `json:"type"`
+		Values  map[string]interface{} `json:"values"`
+	} `json:"resources"`
+	ChildModules []showModule `json:"child_modules"`
+}
+
+// readState returns the managed banyan_* resource instances of a state file or of the output of terraform show -json
+func readState(r io.Reader) (managed []banyan.StateResource, err error) {
+	var s stateFile
+	err = json.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse state: %w", err)
+	}
+	if s.Values != nil {
+		return s.Values.RootModule.managed(), nil
+	}
+	if s.Version != 4 {
+		return nil, fmt.Errorf("unsupported state version %d, expected a version 4 state file or terraform show -json output", s.Version)
+	}
+	for _, res := range s.Resources {
+		if res.Mode != "managed" || !strings.HasPrefix(res.Type, "banyan_") {
+			continue
+		}
+		address := res.Type + "." + res.Name
+		if res.Module != "" {
+			address = res.Module + "." + address
+		}
+		for _, instance := range res.Instances {
+			managed = append(managed, stateResourceOf(address+indexKey(instance.IndexKey), res.Type, instance.Attributes))
+		}
+	}
+	return
+}
+
+func (m showModule) managed() (managed []banyan.StateResource) {
+	for _, res := range m.Resources {
+		if res.Mode != "managed" || !strings.HasPrefix(res.Type, "banyan_") {
+			continue
+		}
+		managed = append(managed, stateResourceOf(res.Address, res.Type, res.Values))
+	}
+	for _, child := range m.ChildModules {
+		managed = append(managed, child.managed()...)
+	}
+	return
+}
+
+func stateResourceOf(address, resourceType string, attributes map[string]interface{}) banyan.StateResource {
+	id, _ := attributes["id"].(string)
+	return banyan.StateResource{Address: address, Type: resourceType, ID: id, Attributes: attributes}
+}
+
+// indexKey formats the index of a resource instance created with count or for_each like terraform does
+func indexKey(key interface{}) string {
+	switch k := key.(type) {
+	case float64:
+		return fmt.Sprintf("[%d]", int(k))
+	case string:
+		return fmt.Sprintf("[%q]", k)
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+// Command banyan-export writes terraform configuration for the existing objects of a Banyan org, with import blocks
+// so that terraform 1.5 or later takes them over on the next apply.
+//
+// Usage:
+//
+//	BANYAN_API_KEY=... go run ./cmd/banyan-export -o banyan.tf
+//
+// Credentials are resolved like the provider does: flags, then the BANYAN_HOST and BANYAN_API_KEY environment
+// variables, then the profile in the credentials file.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/banyansecurity/terraform-banyan-provider/banyan"
+	"github.com/banyansecurity/terraform-banyan-provider/cmd/internal/cli"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func main() {
+	org := cli.OrgFlags(flag.CommandLine)
+	out := flag.String("o", "", "file to write the configuration to, defaults to stdout")
+	flag.Parse()
+
+	err := run(org, *out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "banyan-export: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(org *cli.Org, out string) (err error) {
+	c, err := org.Client()
+	if err != nil {
+		return
+	}
+	exported, diagnostics := banyan.Export(context.Background(), c)
+	for _, d := range diagnostics {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s", d.Summary)
+		}
+		fmt.Fprintf(os.Stderr, "warning: %s %s\n", d.Summary, d.Detail)
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	err = banyan.WriteHCL(w, exported)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "exported %d resources\n", len(exported))
+	return
+}
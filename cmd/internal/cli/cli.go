@@ -0,0 +1,73 @@
+// Package cli holds what the commands of this repository share: the flags which select the org, and resolving them
+// into a client like the provider does.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/banyansecurity/terraform-banyan-provider/client"
+	"github.com/banyansecurity/terraform-banyan-provider/client/credentials"
+)
+
+// defaultHost is the Banyan Command Center API URL used by the provider when none is configured
+const defaultHost = "https://net.banyanops.com/"
+
+// Org is the org a command talks to
+type Org struct {
+	Host       string
+	APIKey     string
+	Profile    string
+	ConfigFile string
+}
+
+// OrgFlags registers the flags which select the org. The profile and credentials file default to the environment
+// variables the provider reads; the host and API key fall back to theirs in Credentials.
+func OrgFlags(fs *flag.FlagSet) *Org {
+	o := &Org{}
+	fs.StringVar(&o.Host, "host", "", "Banyan Command Center API URL, defaults to BANYAN_HOST unless a profile is selected")
+	fs.StringVar(&o.APIKey, "api-key", "", "admin scoped API key, defaults to BANYAN_API_KEY unless a profile is selected")
+	fs.StringVar(&o.Profile, "profile", os.Getenv("BANYAN_PROFILE"), "profile in the credentials file to read host and api key from")
+	fs.StringVar(&o.ConfigFile, "config-file", os.Getenv("BANYAN_CONFIG_FILE"), "path to the credentials file")
+	return o
+}
+
+// Credentials resolves the host and API key with the precedence of the provider: the flags, then the BANYAN_HOST and
+// BANYAN_API_KEY environment variables, then the profile in the credentials file. An explicitly selected profile
+// takes precedence over the environment variables, and supplies both unless both flags are set.
+func (o *Org) Credentials() (host string, apiKey string, err error) {
+	host, apiKey = o.Host, o.APIKey
+	if o.Profile == "" {
+		if host == "" {
+			host = os.Getenv("BANYAN_HOST")
+		}
+		if apiKey == "" {
+			apiKey = os.Getenv("BANYAN_API_KEY")
+		}
+	}
+	host, apiKey, err = credentials.Resolve(host, apiKey, o.ConfigFile, o.Profile)
+	if err != nil {
+		return
+	}
+	if apiKey == "" {
+		return "", "", fmt.Errorf("an API key is required, set -api-key or BANYAN_API_KEY")
+	}
+	if host == "" {
+		host = defaultHost
+	}
+	if !strings.HasSuffix(host, "/") {
+		host = host + "/"
+	}
+	return
+}
+
+// Client returns a client for the org, see Credentials
+func (o *Org) Client() (c *client.Holder, err error) {
+	host, apiKey, err := o.Credentials()
+	if err != nil {
+		return
+	}
+	return client.NewClientHolder(host, apiKey)
+}
@@ -0,0 +1,64 @@
+package cli_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/banyansecurity/terraform-banyan-provider/cmd/internal/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profiles = `
+[default]
+host    = https://default.example.com/
+api_key = default-key
+
+[profile staging]
+host    = https://staging.example.com
+api_key = staging-key
+`
+
+func Test_OrgCredentials(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BANYAN_HOST", "https://env.example.com/")
+	t.Setenv("BANYAN_API_KEY", "env-key")
+	t.Setenv("BANYAN_PROFILE", "")
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(profiles), 0600))
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantHost string
+		wantKey  string
+	}{
+		{name: "environment", args: nil, wantHost: "https://env.example.com/", wantKey: "env-key"},
+		{name: "flags", args: []string{"-host", "https://flag.example.com", "-api-key", "flag-key"}, wantHost: "https://flag.example.com/", wantKey: "flag-key"},
+		{name: "flag and environment", args: []string{"-api-key", "flag-key"}, wantHost: "https://env.example.com/", wantKey: "flag-key"},
+		{name: "profile over environment", args: []string{"-profile", "staging", "-config-file", path}, wantHost: "https://staging.example.com/", wantKey: "staging-key"},
+		{name: "profile over a single flag", args: []string{"-profile", "staging", "-config-file", path, "-host", "https://flag.example.com/"}, wantHost: "https://staging.example.com/", wantKey: "staging-key"},
+		{name: "both flags over profile", args: []string{"-profile", "staging", "-config-file", path, "-host", "https://flag.example.com/", "-api-key", "flag-key"}, wantHost: "https://flag.example.com/", wantKey: "flag-key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			org := cli.OrgFlags(fs)
+			require.NoError(t, fs.Parse(tt.args))
+			host, apiKey, err := org.Credentials()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, host)
+			assert.Equal(t, tt.wantKey, apiKey)
+		})
+	}
+}
+
+func Test_OrgCredentialsRequireAPIKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BANYAN_HOST", "")
+	t.Setenv("BANYAN_API_KEY", "")
+	_, _, err := (&cli.Org{}).Credentials()
+	assert.EqualError(t, err, "an API key is required, set -api-key or BANYAN_API_KEY")
+}
@@ -0,0 +1,19 @@
+data "banyan_access_tier" "example" {
+  name = "us-west1"
+}
+
+locals {
+  healthy_netagents = [
+    for n in data.banyan_access_tier.example.netagents : n.hostname
+    if n.status == "Healthy"
+  ]
+}
+
+resource "terraform_data" "require_healthy_access_tier" {
+  lifecycle {
+    precondition {
+      condition     = length(local.healthy_netagents) >= 2
+      error_message = "Access tier ${data.banyan_access_tier.example.name} needs at least 2 healthy netagents"
+    }
+  }
+}
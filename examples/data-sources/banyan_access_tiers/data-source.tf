@@ -0,0 +1,10 @@
+data "banyan_access_tiers" "us" {
+  cluster    = "cluster1"
+  name_regex = "^us-"
+}
+
+output "access_tier_netagent_versions" {
+  value = {
+    for at in data.banyan_access_tiers.us.access_tiers : at.name => [for n in at.netagents : n.version]
+  }
+}
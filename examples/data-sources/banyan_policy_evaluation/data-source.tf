@@ -0,0 +1,21 @@
+data "banyan_policy_evaluation" "admin_write" {
+  policy_id = banyan_policy_web.example.id
+  principal {
+    email       = "jane@example.com"
+    groups      = ["Engineering"]
+    trust_level = "High"
+  }
+  request {
+    path   = "/admin/users"
+    action = "write"
+  }
+}
+
+# tests/policy.tftest.hcl
+run "engineers_cannot_write_admin" {
+  command = apply
+  assert {
+    condition     = !data.banyan_policy_evaluation.admin_write.allowed
+    error_message = data.banyan_policy_evaluation.admin_write.reason
+  }
+}
\ No newline at end of file
@@ -0,0 +1,11 @@
+data "banyan_service" "example" {
+  name = "my-example-service"
+}
+
+output "example_service_address" {
+  value = "${data.banyan_service.example.domain}:${data.banyan_service.example.port}"
+}
+
+output "example_service_backend" {
+  value = jsondecode(data.banyan_service.example.spec).spec.backend.target
+}
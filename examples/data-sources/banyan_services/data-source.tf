@@ -0,0 +1,16 @@
+data "banyan_services" "web" {
+  cluster      = "cluster1"
+  service_type = "WEB"
+  enabled      = true
+  name_regex   = "^team-a-"
+  tags = {
+    template = "WEB_USER"
+  }
+}
+
+resource "banyan_policy_attachment" "example" {
+  for_each         = toset(data.banyan_services.web.ids)
+  policy_id        = banyan_policy_web.example.id
+  attached_to_type = "service"
+  attached_to_id   = each.value
+}
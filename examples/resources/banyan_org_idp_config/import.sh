@@ -0,0 +1,17 @@
+# There is only one IdP configuration per org, so any ID can be used to import it.
+# And we need to create an entry in .tf file which represents the resource which would be imported.
+# for e.g adding an entry into main.tf
+# main.tf:
+# resource "banyan_org_idp_config" "myexample" {
+#   idp_name      = "Okta"
+#   redirect_url  = "https://net.banyanops.com/v2/callback"
+#   issuer_url    = "https://mycompany.okta.com"
+#   client_id     = "myclientid"
+#   client_secret = "myclientsecret"
+# }
+
+terraform import banyan_org_idp_config.myexample singleton
+
+terraform show
+# update thw show output configuration into above main.tf file, then resource is managed.
+# BE CAUTIOUS before terraform apply, do terraform plan and verify there are no changes to be applied.
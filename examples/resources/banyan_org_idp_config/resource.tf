@@ -0,0 +1,8 @@
+resource "banyan_org_idp_config" "example" {
+  idp_name      = "Okta"
+  idp_protocol  = "OIDC"
+  redirect_url  = "https://net.banyanops.com/v2/callback"
+  issuer_url    = "https://mycompany.okta.com"
+  client_id     = var.okta_client_id
+  client_secret = var.okta_client_secret
+}
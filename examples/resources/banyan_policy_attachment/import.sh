@@ -0,0 +1,18 @@
+# The ID of a policy attachment is the type of the object the policy is attached to
+# and the ID of that object, separated by a slash.
+# for e.g adding an entry into main.tf
+# main.tf:
+# resource "banyan_policy_attachment" "myexample" {
+#   policy_id        = "46f3a708-2a9a-4c87-b18e-b11b6c92bf24"
+#   attached_to_type = "service"
+#   attached_to_id   = "myexample.cluster1.bnn"
+# }
+
+terraform import banyan_policy_attachment.myexample service/myexample.cluster1.bnn
+
+# or for a service tunnel
+terraform import banyan_policy_attachment.mytunnel service_tunnel/a6c0e4b7-0f62-4b8a-9b3e-7c3f5a0d1e24
+
+terraform show
+# update the show output configuration into above main.tf file, then resource is managed.
+# BE CAUTIOUS before terraform apply, do terraform plan and verify there are no changes to be applied.
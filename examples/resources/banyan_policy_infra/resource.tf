@@ -5,4 +5,10 @@ resource "banyan_policy_infra" "example" {
     roles       = ["ANY"]
     trust_level = "High"
   }
+  access {
+    roles       = ["Contractors"]
+    trust_level = "Medium"
+    start_time  = "2027-01-01T09:00:00Z"
+    end_time    = "2027-01-31T17:00:00Z"
+  }
 }
\ No newline at end of file
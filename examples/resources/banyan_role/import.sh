@@ -8,6 +8,11 @@
 
 terraform import banyan_role.myexample 46f3a708-2a9a-4c87-b18e-b11b6c92bf24
 
+terraform show
+
+# or import via role name
+terraform import banyan_role.myexample name:myexample
+
 terraform show
 # update thw show output configuration into above main.tf file, then resource is managed.
 # BE CAUTIOUS before terraform apply, do terraform plan and verify there are no changes to be applied.
@@ -0,0 +1,59 @@
+resource "banyan_service_raw" "example" {
+  spec_json = jsonencode({
+    kind       = "BanyanService"
+    apiVersion = "rbac.banyanops.com/v1"
+    type       = "origin"
+    metadata = {
+      name        = "example-raw"
+      description = "tcp service with settings from its full specification"
+      tags = {
+        template    = "TCP_USER"
+        user_facing = "true"
+        protocol    = "tcp"
+        domain      = "example-raw.us-west1.mycompany.com"
+        port        = "8443"
+      }
+    }
+    spec = {
+      attributes = {
+        tls_sni            = ["example-raw.us-west1.mycompany.com"]
+        frontend_addresses = [{ cidr = "", port = "8443" }]
+      }
+      backend = {
+        target = { name = "example-raw.internal", port = "5673" }
+      }
+    }
+  })
+  policy = banyan_policy_infra.example.id
+}
+
+resource "banyan_service_raw" "example_toml" {
+  spec_toml = <<-EOT
+    kind = "BanyanService"
+    apiVersion = "rbac.banyanops.com/v1"
+    type = "origin"
+
+    [metadata]
+    name = "example-raw-toml"
+    description = "tcp service from a banyanctl export"
+
+    [metadata.tags]
+    template = "TCP_USER"
+    user_facing = "true"
+    protocol = "tcp"
+    domain = "example-raw-toml.us-west1.mycompany.com"
+    port = "8443"
+
+    [spec.attributes]
+    tls_sni = ["example-raw-toml.us-west1.mycompany.com"]
+
+    [[spec.attributes.frontend_addresses]]
+    cidr = ""
+    port = "8443"
+
+    [spec.backend.target]
+    name = "example-raw-toml.internal"
+    port = "5673"
+  EOT
+  policy = banyan_policy_infra.example.id
+}
\ No newline at end of file
@@ -8,6 +8,11 @@
 
 terraform import banyan_service_tcp.myexample myexample.global-edge.bnn
 
+terraform show
+
+# or import via service name
+terraform import banyan_service_tcp.myexample name:myexample
+
 terraform show
 # update thw show output configuration into above main.tf file, then resource is managed.
 # BE CAUTIOUS before terraform apply, do terraform plan and verify there are no changes to be applied.